@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mapCode maps the category of an error to the gRPC status code
+// that is the closest to the HTTP status code used for it
+func mapCode(category errors.Category) codes.Code {
+	switch category {
+	case errors.InternalError:
+		return codes.Internal
+	case errors.InputError:
+		return codes.InvalidArgument
+	case errors.StateConflict:
+		return codes.FailedPrecondition
+	case errors.ResourceLimitReached:
+		return codes.ResourceExhausted
+	case errors.NotImplemented:
+		return codes.Unimplemented
+	case errors.AuthenticationError:
+		return codes.Unauthenticated
+	case errors.NotFound:
+		return codes.NotFound
+	default:
+		return codes.Internal
+	}
+}
+
+// mapError maps an error returned by a handler to a gRPC status. The
+// status has an Error as detail so that clients get the same error
+// code and description as with the HTTP API. As with the HTTP API, the
+// cause of the error is not exposed to the client
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// errors that carry the http status, like the ones returned
+	// by the rate limiter, are reported by their cause
+	switch httpErr := err.(type) {
+	case *rpc.HttpError:
+		if httpErr.Cause != nil {
+			err = *httpErr.Cause
+		}
+	case rpc.HttpError:
+		if httpErr.Cause != nil {
+			err = *httpErr.Cause
+		}
+	}
+
+	e, ok := err.(errors.Err)
+	if !ok {
+		e = errors.New(errors.ErrInternalError, err)
+	}
+
+	code := e.ErrorCode()
+	s := status.New(mapCode(code.Category()), code.Desc())
+	d, derr := s.WithDetails(&Error{
+		ErrorCode:   int32(code.Code()),
+		Description: code.Desc(),
+	})
+	if derr != nil {
+		return s.Err()
+	}
+
+	return d.Err()
+}
+
+// mapRpcError maps an error reported in an event
+func mapRpcError(err rpc.Error) *Error {
+	return &Error{
+		ErrorCode:   int32(err.ErrorCode),
+		Description: err.Description,
+	}
+}
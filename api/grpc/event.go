@@ -0,0 +1,231 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// EventHandler implements EventServer on top of the handler of
+// the HTTP API, so that both APIs validate and serve the requests in
+// the same way
+type EventHandler struct {
+	handler event.EventHandler
+}
+
+// NewEventHandler creates a new EventHandler that serves the
+// requests with the provided services
+func NewEventHandler(services event.Services) *EventHandler {
+	return &EventHandler{handler: event.NewEventHandler(services)}
+}
+
+// Subscribe is the implementation of EventServer for EventHandler
+func (h *EventHandler) Subscribe(ctx context.Context, req *SubscribeRequest) (*SubscribeResponse, error) {
+	v, err := h.handler.Subscribe(ctx, &event.SubscribeRequest{
+		Events:      req.Events,
+		Filter:      req.Filter,
+		CallbackURL: req.CallbackUrl,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &SubscribeResponse{Id: v.(event.SubscribeResponse).ID}, nil
+}
+
+// Unsubscribe is the implementation of EventServer for EventHandler
+func (h *EventHandler) Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (*UnsubscribeResponse, error) {
+	if _, err := h.handler.Unsubscribe(ctx, &event.UnsubscribeRequest{ID: req.Id}); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &UnsubscribeResponse{}, nil
+}
+
+// ListSubscriptions is the implementation of EventServer for EventHandler
+func (h *EventHandler) ListSubscriptions(
+	ctx context.Context,
+	req *ListSubscriptionsRequest,
+) (*ListSubscriptionsResponse, error) {
+	v, err := h.handler.ListSubscriptions(ctx, &event.ListSubscriptionsRequest{})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(event.ListSubscriptionsResponse)
+	subscriptions := make([]*Subscription, 0, len(res.Subscriptions))
+	for _, sub := range res.Subscriptions {
+		topics := make([]*Topics, 0, len(sub.Topics))
+		for _, t := range sub.Topics {
+			topics = append(topics, &Topics{Topics: t})
+		}
+
+		subscriptions = append(subscriptions, &Subscription{
+			Id:          sub.ID,
+			Event:       sub.Event,
+			Addresses:   sub.Addresses,
+			Topics:      topics,
+			CallbackUrl: sub.CallbackURL,
+			CreatedAtMs: sub.CreatedAtMs,
+			Offset:      sub.Offset,
+		})
+	}
+
+	return &ListSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+// RegisterABI is the implementation of EventServer for EventHandler
+func (h *EventHandler) RegisterABI(ctx context.Context, req *RegisterABIRequest) (*RegisterABIResponse, error) {
+	if _, err := h.handler.RegisterABI(ctx, &event.RegisterABIRequest{
+		Address: req.Address,
+		ABI:     json.RawMessage(req.Abi),
+	}); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &RegisterABIResponse{}, nil
+}
+
+// PollEvent is the implementation of EventServer for EventHandler
+func (h *EventHandler) PollEvent(ctx context.Context, req *PollEventRequest) (*PollEventResponse, error) {
+	var offset *uint64
+	if position, ok := req.Position.(*PollEventRequest_Offset); ok {
+		offset = &position.Offset
+	}
+
+	v, err := h.handler.PollEvent(ctx, &event.PollEventRequest{
+		ID:              req.Id,
+		Offset:          offset,
+		Count:           uint(req.Count),
+		DiscardPrevious: req.DiscardPrevious,
+		WaitMs:          req.WaitMs,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(event.PollEventResponse)
+	events := make([]*SubscriptionEvent, 0, len(res.Events))
+	for _, ev := range res.Events {
+		mapped, err := mapSubscriptionEvent(ev)
+		if err != nil {
+			return nil, mapError(err)
+		}
+
+		events = append(events, mapped)
+	}
+
+	return &PollEventResponse{Offset: res.Offset, Events: events}, nil
+}
+
+// AckEvent is the implementation of EventServer for EventHandler
+func (h *EventHandler) AckEvent(ctx context.Context, req *AckEventRequest) (*AckEventResponse, error) {
+	if _, err := h.handler.AckEvent(ctx, &event.AckEventRequest{
+		ID:      req.Id,
+		EventID: req.EventId,
+	}); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &AckEventResponse{}, nil
+}
+
+// StreamEvent is the implementation of EventServer for EventHandler
+func (h *EventHandler) StreamEvent(req *StreamEventRequest, stream Event_StreamEventServer) error {
+	v, err := h.handler.StreamEvent(stream.Context(), &event.StreamEventRequest{
+		ID:     req.Id,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	return mapError(v.(rpc.Stream).Stream(stream.Context(), eventStreamWriter{stream: stream}))
+}
+
+// eventStreamWriter is the rpc.StreamWriter that sends the events
+// of a StreamEvent call
+type eventStreamWriter struct {
+	stream Event_StreamEventServer
+}
+
+// Write is the implementation of rpc.StreamWriter for eventStreamWriter
+func (w eventStreamWriter) Write(ev rpc.Event) error {
+	mapped, err := mapSubscriptionEvent(ev.(event.Event))
+	if err != nil {
+		return err
+	}
+
+	return w.stream.Send(mapped)
+}
+
+func mapSubscriptionEvent(ev event.Event) (*SubscriptionEvent, errors.Err) {
+	switch r := ev.(type) {
+	case event.DataEvent:
+		// the decoded arguments hold values of arbitrary types, so
+		// they are provided as a JSON object
+		var args string
+		if len(r.Args) > 0 {
+			p, err := json.Marshal(r.Args)
+			if err != nil {
+				return nil, errors.New(errors.ErrSerializeEvent, err)
+			}
+			args = string(p)
+		}
+
+		return &SubscriptionEvent{Event: &SubscriptionEvent_Data{Data: &DataEvent{
+			Id:      r.ID,
+			Address: r.Address,
+			Data:    r.Data,
+			Topics:  r.Topics,
+			Event:   r.Event,
+			Args:    args,
+		}}}, nil
+	case event.BlockEvent:
+		return &SubscriptionEvent{Event: &SubscriptionEvent_Block{Block: &BlockEvent{
+			Id:         r.ID,
+			Number:     r.Number,
+			Hash:       r.Hash,
+			ParentHash: r.ParentHash,
+			Timestamp:  r.Timestamp,
+		}}}, nil
+	case event.ReceiptEvent:
+		return &SubscriptionEvent{Event: &SubscriptionEvent_Receipt{Receipt: &ReceiptEvent{
+			Id:          r.ID,
+			Address:     r.Address,
+			Status:      r.Status,
+			Transaction: mapEventTransaction(r.Transaction),
+		}}}, nil
+	case event.ErrorEvent:
+		return &SubscriptionEvent{Event: &SubscriptionEvent_Error{Error: &ErrorEvent{
+			Id:    r.ID,
+			Cause: mapRpcError(r.Cause),
+		}}}, nil
+	default:
+		panic("received unexpected event type from event handler")
+	}
+}
+
+func mapEventTransaction(tx event.Transaction) *Transaction {
+	logs := make([]*Log, 0, len(tx.Logs))
+	for _, log := range tx.Logs {
+		logs = append(logs, &Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+			Index:   uint32(log.Index),
+		})
+	}
+
+	return &Transaction{
+		Hash:        tx.Hash,
+		BlockNumber: tx.BlockNumber,
+		BlockHash:   tx.BlockHash,
+		GasUsed:     tx.GasUsed,
+		From:        tx.From,
+		Logs:        logs,
+	}
+}
@@ -0,0 +1,3817 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: api/grpc/gateway.proto
+
+package grpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Error struct {
+	// Code that identifies the error.
+	ErrorCode int32 `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// Human readable description of the error.
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{0}
+}
+
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *Error) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type Log struct {
+	// Address of the service that emitted the log.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Topics of the log.
+	Topics []string `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Hex encoded data of the log.
+	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Index of the log in the block.
+	Index                uint32   `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Log) Reset()         { *m = Log{} }
+func (m *Log) String() string { return proto.CompactTextString(m) }
+func (*Log) ProtoMessage()    {}
+func (*Log) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{1}
+}
+
+func (m *Log) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Log.Unmarshal(m, b)
+}
+func (m *Log) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Log.Marshal(b, m, deterministic)
+}
+func (m *Log) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Log.Merge(m, src)
+}
+func (m *Log) XXX_Size() int {
+	return xxx_messageInfo_Log.Size(m)
+}
+func (m *Log) XXX_DiscardUnknown() {
+	xxx_messageInfo_Log.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Log proto.InternalMessageInfo
+
+func (m *Log) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Log) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *Log) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *Log) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type Transaction struct {
+	// Hash of the transaction.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Number of the block in which the transaction was included.
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// Hash of the block in which the transaction was included.
+	BlockHash string `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// Gas used by the transaction.
+	GasUsed uint64 `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	// Address that sent the transaction.
+	From string `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	// Logs emitted by the transaction.
+	Logs                 []*Log   `protobuf:"bytes,6,rep,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{2}
+}
+
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Transaction) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *Transaction) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *Transaction) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *Transaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Transaction) GetLogs() []*Log {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type ErrorEvent struct {
+	// ID of the request or event that failed.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Cause of the failure.
+	Cause                *Error   `protobuf:"bytes,2,opt,name=cause,proto3" json:"cause,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErrorEvent) Reset()         { *m = ErrorEvent{} }
+func (m *ErrorEvent) String() string { return proto.CompactTextString(m) }
+func (*ErrorEvent) ProtoMessage()    {}
+func (*ErrorEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{3}
+}
+
+func (m *ErrorEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorEvent.Unmarshal(m, b)
+}
+func (m *ErrorEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorEvent.Marshal(b, m, deterministic)
+}
+func (m *ErrorEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorEvent.Merge(m, src)
+}
+func (m *ErrorEvent) XXX_Size() int {
+	return xxx_messageInfo_ErrorEvent.Size(m)
+}
+func (m *ErrorEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorEvent proto.InternalMessageInfo
+
+func (m *ErrorEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ErrorEvent) GetCause() *Error {
+	if m != nil {
+		return m.Cause
+	}
+	return nil
+}
+
+type ExecuteServiceEvent struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Address of the executed service.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex encoded output of the execution.
+	Output string `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// Transaction that executed the service.
+	Transaction          *Transaction `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ExecuteServiceEvent) Reset()         { *m = ExecuteServiceEvent{} }
+func (m *ExecuteServiceEvent) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceEvent) ProtoMessage()    {}
+func (*ExecuteServiceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{4}
+}
+
+func (m *ExecuteServiceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceEvent.Unmarshal(m, b)
+}
+func (m *ExecuteServiceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceEvent.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceEvent.Merge(m, src)
+}
+func (m *ExecuteServiceEvent) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceEvent.Size(m)
+}
+func (m *ExecuteServiceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceEvent proto.InternalMessageInfo
+
+func (m *ExecuteServiceEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ExecuteServiceEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ExecuteServiceEvent) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *ExecuteServiceEvent) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type DeployServiceEvent struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Address of the deployed service.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Transaction that deployed the service.
+	Transaction          *Transaction `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DeployServiceEvent) Reset()         { *m = DeployServiceEvent{} }
+func (m *DeployServiceEvent) String() string { return proto.CompactTextString(m) }
+func (*DeployServiceEvent) ProtoMessage()    {}
+func (*DeployServiceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{5}
+}
+
+func (m *DeployServiceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeployServiceEvent.Unmarshal(m, b)
+}
+func (m *DeployServiceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeployServiceEvent.Marshal(b, m, deterministic)
+}
+func (m *DeployServiceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeployServiceEvent.Merge(m, src)
+}
+func (m *DeployServiceEvent) XXX_Size() int {
+	return xxx_messageInfo_DeployServiceEvent.Size(m)
+}
+func (m *DeployServiceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeployServiceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeployServiceEvent proto.InternalMessageInfo
+
+func (m *DeployServiceEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeployServiceEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DeployServiceEvent) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type ServiceEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*ServiceEvent_Execute
+	//	*ServiceEvent_Deploy
+	//	*ServiceEvent_Error
+	Event                isServiceEvent_Event `protobuf_oneof:"event"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ServiceEvent) Reset()         { *m = ServiceEvent{} }
+func (m *ServiceEvent) String() string { return proto.CompactTextString(m) }
+func (*ServiceEvent) ProtoMessage()    {}
+func (*ServiceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{6}
+}
+
+func (m *ServiceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceEvent.Unmarshal(m, b)
+}
+func (m *ServiceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceEvent.Marshal(b, m, deterministic)
+}
+func (m *ServiceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceEvent.Merge(m, src)
+}
+func (m *ServiceEvent) XXX_Size() int {
+	return xxx_messageInfo_ServiceEvent.Size(m)
+}
+func (m *ServiceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceEvent proto.InternalMessageInfo
+
+type isServiceEvent_Event interface {
+	isServiceEvent_Event()
+}
+
+type ServiceEvent_Execute struct {
+	Execute *ExecuteServiceEvent `protobuf:"bytes,1,opt,name=execute,proto3,oneof"`
+}
+
+type ServiceEvent_Deploy struct {
+	Deploy *DeployServiceEvent `protobuf:"bytes,2,opt,name=deploy,proto3,oneof"`
+}
+
+type ServiceEvent_Error struct {
+	Error *ErrorEvent `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*ServiceEvent_Execute) isServiceEvent_Event() {}
+
+func (*ServiceEvent_Deploy) isServiceEvent_Event() {}
+
+func (*ServiceEvent_Error) isServiceEvent_Event() {}
+
+func (m *ServiceEvent) GetEvent() isServiceEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ServiceEvent) GetExecute() *ExecuteServiceEvent {
+	if x, ok := m.GetEvent().(*ServiceEvent_Execute); ok {
+		return x.Execute
+	}
+	return nil
+}
+
+func (m *ServiceEvent) GetDeploy() *DeployServiceEvent {
+	if x, ok := m.GetEvent().(*ServiceEvent_Deploy); ok {
+		return x.Deploy
+	}
+	return nil
+}
+
+func (m *ServiceEvent) GetError() *ErrorEvent {
+	if x, ok := m.GetEvent().(*ServiceEvent_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ServiceEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ServiceEvent_Execute)(nil),
+		(*ServiceEvent_Deploy)(nil),
+		(*ServiceEvent_Error)(nil),
+	}
+}
+
+type DeployServiceRequest struct {
+	// Hex encoded code of the service.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Wait for the response instead of returning only its ID.
+	Wait bool `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	// Maximum time to wait for the response.
+	TimeoutMs            uint64   `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeployServiceRequest) Reset()         { *m = DeployServiceRequest{} }
+func (m *DeployServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeployServiceRequest) ProtoMessage()    {}
+func (*DeployServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{7}
+}
+
+func (m *DeployServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeployServiceRequest.Unmarshal(m, b)
+}
+func (m *DeployServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeployServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeployServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeployServiceRequest.Merge(m, src)
+}
+func (m *DeployServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeployServiceRequest.Size(m)
+}
+func (m *DeployServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeployServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeployServiceRequest proto.InternalMessageInfo
+
+func (m *DeployServiceRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *DeployServiceRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *DeployServiceRequest) GetTimeoutMs() uint64 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+type DeployServiceResponse struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Response to the request. Only set if the request waited for it
+	// and it was available before the timeout.
+	Event                *ServiceEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DeployServiceResponse) Reset()         { *m = DeployServiceResponse{} }
+func (m *DeployServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*DeployServiceResponse) ProtoMessage()    {}
+func (*DeployServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{8}
+}
+
+func (m *DeployServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeployServiceResponse.Unmarshal(m, b)
+}
+func (m *DeployServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeployServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *DeployServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeployServiceResponse.Merge(m, src)
+}
+func (m *DeployServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_DeployServiceResponse.Size(m)
+}
+func (m *DeployServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeployServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeployServiceResponse proto.InternalMessageInfo
+
+func (m *DeployServiceResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeployServiceResponse) GetEvent() *ServiceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ExecuteServiceRequest struct {
+	// Hex encoded data of the execution.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Address of the service.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Wait for the response instead of returning only its ID.
+	Wait bool `protobuf:"varint,3,opt,name=wait,proto3" json:"wait,omitempty"`
+	// Maximum time to wait for the response.
+	TimeoutMs            uint64   `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteServiceRequest) Reset()         { *m = ExecuteServiceRequest{} }
+func (m *ExecuteServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceRequest) ProtoMessage()    {}
+func (*ExecuteServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{9}
+}
+
+func (m *ExecuteServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceRequest.Unmarshal(m, b)
+}
+func (m *ExecuteServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceRequest.Merge(m, src)
+}
+func (m *ExecuteServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceRequest.Size(m)
+}
+func (m *ExecuteServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceRequest proto.InternalMessageInfo
+
+func (m *ExecuteServiceRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *ExecuteServiceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ExecuteServiceRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *ExecuteServiceRequest) GetTimeoutMs() uint64 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+type ExecuteServiceResponse struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Response to the request. Only set if the request waited for it
+	// and it was available before the timeout.
+	Event                *ServiceEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExecuteServiceResponse) Reset()         { *m = ExecuteServiceResponse{} }
+func (m *ExecuteServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceResponse) ProtoMessage()    {}
+func (*ExecuteServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{10}
+}
+
+func (m *ExecuteServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceResponse.Unmarshal(m, b)
+}
+func (m *ExecuteServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceResponse.Merge(m, src)
+}
+func (m *ExecuteServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceResponse.Size(m)
+}
+func (m *ExecuteServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceResponse proto.InternalMessageInfo
+
+func (m *ExecuteServiceResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ExecuteServiceResponse) GetEvent() *ServiceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ExecuteServiceBatchItem struct {
+	// Hex encoded data of the execution.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Address of the service.
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteServiceBatchItem) Reset()         { *m = ExecuteServiceBatchItem{} }
+func (m *ExecuteServiceBatchItem) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceBatchItem) ProtoMessage()    {}
+func (*ExecuteServiceBatchItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{11}
+}
+
+func (m *ExecuteServiceBatchItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceBatchItem.Unmarshal(m, b)
+}
+func (m *ExecuteServiceBatchItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceBatchItem.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceBatchItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceBatchItem.Merge(m, src)
+}
+func (m *ExecuteServiceBatchItem) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceBatchItem.Size(m)
+}
+func (m *ExecuteServiceBatchItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceBatchItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceBatchItem proto.InternalMessageInfo
+
+func (m *ExecuteServiceBatchItem) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *ExecuteServiceBatchItem) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type ExecuteServiceBatchRequest struct {
+	Requests             []*ExecuteServiceBatchItem `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ExecuteServiceBatchRequest) Reset()         { *m = ExecuteServiceBatchRequest{} }
+func (m *ExecuteServiceBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceBatchRequest) ProtoMessage()    {}
+func (*ExecuteServiceBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{12}
+}
+
+func (m *ExecuteServiceBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceBatchRequest.Unmarshal(m, b)
+}
+func (m *ExecuteServiceBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceBatchRequest.Merge(m, src)
+}
+func (m *ExecuteServiceBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceBatchRequest.Size(m)
+}
+func (m *ExecuteServiceBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceBatchRequest proto.InternalMessageInfo
+
+func (m *ExecuteServiceBatchRequest) GetRequests() []*ExecuteServiceBatchItem {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type ExecuteServiceBatchItemResponse struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Cause of the failure if the request could not be submitted.
+	Cause                *Error   `protobuf:"bytes,2,opt,name=cause,proto3" json:"cause,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteServiceBatchItemResponse) Reset()         { *m = ExecuteServiceBatchItemResponse{} }
+func (m *ExecuteServiceBatchItemResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceBatchItemResponse) ProtoMessage()    {}
+func (*ExecuteServiceBatchItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{13}
+}
+
+func (m *ExecuteServiceBatchItemResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceBatchItemResponse.Unmarshal(m, b)
+}
+func (m *ExecuteServiceBatchItemResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceBatchItemResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceBatchItemResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceBatchItemResponse.Merge(m, src)
+}
+func (m *ExecuteServiceBatchItemResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceBatchItemResponse.Size(m)
+}
+func (m *ExecuteServiceBatchItemResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceBatchItemResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceBatchItemResponse proto.InternalMessageInfo
+
+func (m *ExecuteServiceBatchItemResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ExecuteServiceBatchItemResponse) GetCause() *Error {
+	if m != nil {
+		return m.Cause
+	}
+	return nil
+}
+
+type ExecuteServiceBatchResponse struct {
+	Responses            []*ExecuteServiceBatchItemResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *ExecuteServiceBatchResponse) Reset()         { *m = ExecuteServiceBatchResponse{} }
+func (m *ExecuteServiceBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecuteServiceBatchResponse) ProtoMessage()    {}
+func (*ExecuteServiceBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{14}
+}
+
+func (m *ExecuteServiceBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteServiceBatchResponse.Unmarshal(m, b)
+}
+func (m *ExecuteServiceBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteServiceBatchResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecuteServiceBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteServiceBatchResponse.Merge(m, src)
+}
+func (m *ExecuteServiceBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecuteServiceBatchResponse.Size(m)
+}
+func (m *ExecuteServiceBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteServiceBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteServiceBatchResponse proto.InternalMessageInfo
+
+func (m *ExecuteServiceBatchResponse) GetResponses() []*ExecuteServiceBatchItemResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+type CallServiceRequest struct {
+	// Hex encoded data of the call.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Address of the service.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Block at which the call is made.
+	Block                string   `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallServiceRequest) Reset()         { *m = CallServiceRequest{} }
+func (m *CallServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*CallServiceRequest) ProtoMessage()    {}
+func (*CallServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{15}
+}
+
+func (m *CallServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallServiceRequest.Unmarshal(m, b)
+}
+func (m *CallServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *CallServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallServiceRequest.Merge(m, src)
+}
+func (m *CallServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_CallServiceRequest.Size(m)
+}
+func (m *CallServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallServiceRequest proto.InternalMessageInfo
+
+func (m *CallServiceRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *CallServiceRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *CallServiceRequest) GetBlock() string {
+	if m != nil {
+		return m.Block
+	}
+	return ""
+}
+
+type CallServiceResponse struct {
+	// Address of the service.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex encoded output of the call.
+	Output               string   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallServiceResponse) Reset()         { *m = CallServiceResponse{} }
+func (m *CallServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*CallServiceResponse) ProtoMessage()    {}
+func (*CallServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{16}
+}
+
+func (m *CallServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallServiceResponse.Unmarshal(m, b)
+}
+func (m *CallServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *CallServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallServiceResponse.Merge(m, src)
+}
+func (m *CallServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_CallServiceResponse.Size(m)
+}
+func (m *CallServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallServiceResponse proto.InternalMessageInfo
+
+func (m *CallServiceResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *CallServiceResponse) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+type EstimateGasRequest struct {
+	// Hex encoded data of the deployment or execution.
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Address of the service. Empty for a deployment.
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateGasRequest) Reset()         { *m = EstimateGasRequest{} }
+func (m *EstimateGasRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasRequest) ProtoMessage()    {}
+func (*EstimateGasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{17}
+}
+
+func (m *EstimateGasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateGasRequest.Unmarshal(m, b)
+}
+func (m *EstimateGasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateGasRequest.Marshal(b, m, deterministic)
+}
+func (m *EstimateGasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateGasRequest.Merge(m, src)
+}
+func (m *EstimateGasRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimateGasRequest.Size(m)
+}
+func (m *EstimateGasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateGasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateGasRequest proto.InternalMessageInfo
+
+func (m *EstimateGasRequest) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *EstimateGasRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type EstimateGasResponse struct {
+	Gas      uint64 `protobuf:"varint,1,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasPrice uint64 `protobuf:"varint,2,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// Whether the execution of a confidential service could not be
+	// estimated, in which case gas is the gas provided to the
+	// transactions sent to confidential services.
+	Fallback             bool     `protobuf:"varint,3,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateGasResponse) Reset()         { *m = EstimateGasResponse{} }
+func (m *EstimateGasResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateGasResponse) ProtoMessage()    {}
+func (*EstimateGasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{18}
+}
+
+func (m *EstimateGasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateGasResponse.Unmarshal(m, b)
+}
+func (m *EstimateGasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateGasResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimateGasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateGasResponse.Merge(m, src)
+}
+func (m *EstimateGasResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateGasResponse.Size(m)
+}
+func (m *EstimateGasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateGasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateGasResponse proto.InternalMessageInfo
+
+func (m *EstimateGasResponse) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *EstimateGasResponse) GetGasPrice() uint64 {
+	if m != nil {
+		return m.GasPrice
+	}
+	return 0
+}
+
+func (m *EstimateGasResponse) GetFallback() bool {
+	if m != nil {
+		return m.Fallback
+	}
+	return false
+}
+
+type PollServiceRequest struct {
+	// Offset from which to poll. If it is not set, the responses are
+	// polled from the committed cursor.
+	//
+	// Types that are valid to be assigned to Position:
+	//	*PollServiceRequest_Offset
+	Position isPollServiceRequest_Position `protobuf_oneof:"position"`
+	// Maximum number of responses to return.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Discard the responses before the offset.
+	DiscardPrevious bool `protobuf:"varint,3,opt,name=discard_previous,json=discardPrevious,proto3" json:"discard_previous,omitempty"`
+	// Maximum time to wait for responses to be available.
+	WaitMs               uint64   `protobuf:"varint,4,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollServiceRequest) Reset()         { *m = PollServiceRequest{} }
+func (m *PollServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*PollServiceRequest) ProtoMessage()    {}
+func (*PollServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{19}
+}
+
+func (m *PollServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollServiceRequest.Unmarshal(m, b)
+}
+func (m *PollServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *PollServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollServiceRequest.Merge(m, src)
+}
+func (m *PollServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_PollServiceRequest.Size(m)
+}
+func (m *PollServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollServiceRequest proto.InternalMessageInfo
+
+type isPollServiceRequest_Position interface {
+	isPollServiceRequest_Position()
+}
+
+type PollServiceRequest_Offset struct {
+	Offset uint64 `protobuf:"varint,1,opt,name=offset,proto3,oneof"`
+}
+
+func (*PollServiceRequest_Offset) isPollServiceRequest_Position() {}
+
+func (m *PollServiceRequest) GetPosition() isPollServiceRequest_Position {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *PollServiceRequest) GetOffset() uint64 {
+	if x, ok := m.GetPosition().(*PollServiceRequest_Offset); ok {
+		return x.Offset
+	}
+	return 0
+}
+
+func (m *PollServiceRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PollServiceRequest) GetDiscardPrevious() bool {
+	if m != nil {
+		return m.DiscardPrevious
+	}
+	return false
+}
+
+func (m *PollServiceRequest) GetWaitMs() uint64 {
+	if m != nil {
+		return m.WaitMs
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PollServiceRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PollServiceRequest_Offset)(nil),
+	}
+}
+
+type PollServiceResponse struct {
+	Offset               uint64          `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Events               []*ServiceEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PollServiceResponse) Reset()         { *m = PollServiceResponse{} }
+func (m *PollServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*PollServiceResponse) ProtoMessage()    {}
+func (*PollServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{20}
+}
+
+func (m *PollServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollServiceResponse.Unmarshal(m, b)
+}
+func (m *PollServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *PollServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollServiceResponse.Merge(m, src)
+}
+func (m *PollServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_PollServiceResponse.Size(m)
+}
+func (m *PollServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollServiceResponse proto.InternalMessageInfo
+
+func (m *PollServiceResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *PollServiceResponse) GetEvents() []*ServiceEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type GetServiceStatusRequest struct {
+	// ID of the asynchronous request.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServiceStatusRequest) Reset()         { *m = GetServiceStatusRequest{} }
+func (m *GetServiceStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServiceStatusRequest) ProtoMessage()    {}
+func (*GetServiceStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{21}
+}
+
+func (m *GetServiceStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceStatusRequest.Unmarshal(m, b)
+}
+func (m *GetServiceStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetServiceStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceStatusRequest.Merge(m, src)
+}
+func (m *GetServiceStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServiceStatusRequest.Size(m)
+}
+func (m *GetServiceStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceStatusRequest proto.InternalMessageInfo
+
+func (m *GetServiceStatusRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetServiceStatusResponse struct {
+	// ID of the asynchronous request.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Status of the request.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Response to the request if it is available.
+	Event                *ServiceEvent `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetServiceStatusResponse) Reset()         { *m = GetServiceStatusResponse{} }
+func (m *GetServiceStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServiceStatusResponse) ProtoMessage()    {}
+func (*GetServiceStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{22}
+}
+
+func (m *GetServiceStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceStatusResponse.Unmarshal(m, b)
+}
+func (m *GetServiceStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetServiceStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceStatusResponse.Merge(m, src)
+}
+func (m *GetServiceStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetServiceStatusResponse.Size(m)
+}
+func (m *GetServiceStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceStatusResponse proto.InternalMessageInfo
+
+func (m *GetServiceStatusResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetServiceStatusResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GetServiceStatusResponse) GetEvent() *ServiceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type CancelServiceRequest struct {
+	// ID of the asynchronous request.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelServiceRequest) Reset()         { *m = CancelServiceRequest{} }
+func (m *CancelServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelServiceRequest) ProtoMessage()    {}
+func (*CancelServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{23}
+}
+
+func (m *CancelServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelServiceRequest.Unmarshal(m, b)
+}
+func (m *CancelServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelServiceRequest.Merge(m, src)
+}
+func (m *CancelServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelServiceRequest.Size(m)
+}
+func (m *CancelServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelServiceRequest proto.InternalMessageInfo
+
+func (m *CancelServiceRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type CancelServiceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelServiceResponse) Reset()         { *m = CancelServiceResponse{} }
+func (m *CancelServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelServiceResponse) ProtoMessage()    {}
+func (*CancelServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{24}
+}
+
+func (m *CancelServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelServiceResponse.Unmarshal(m, b)
+}
+func (m *CancelServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelServiceResponse.Merge(m, src)
+}
+func (m *CancelServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelServiceResponse.Size(m)
+}
+func (m *CancelServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelServiceResponse proto.InternalMessageInfo
+
+type AckServiceRequest struct {
+	// ID of the last response processed by the client.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckServiceRequest) Reset()         { *m = AckServiceRequest{} }
+func (m *AckServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*AckServiceRequest) ProtoMessage()    {}
+func (*AckServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{25}
+}
+
+func (m *AckServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckServiceRequest.Unmarshal(m, b)
+}
+func (m *AckServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *AckServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckServiceRequest.Merge(m, src)
+}
+func (m *AckServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_AckServiceRequest.Size(m)
+}
+func (m *AckServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckServiceRequest proto.InternalMessageInfo
+
+func (m *AckServiceRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type AckServiceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckServiceResponse) Reset()         { *m = AckServiceResponse{} }
+func (m *AckServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*AckServiceResponse) ProtoMessage()    {}
+func (*AckServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{26}
+}
+
+func (m *AckServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckServiceResponse.Unmarshal(m, b)
+}
+func (m *AckServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *AckServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckServiceResponse.Merge(m, src)
+}
+func (m *AckServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_AckServiceResponse.Size(m)
+}
+func (m *AckServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckServiceResponse proto.InternalMessageInfo
+
+type StreamServiceRequest struct {
+	// Offset from which to stream.
+	Offset               uint64   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamServiceRequest) Reset()         { *m = StreamServiceRequest{} }
+func (m *StreamServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamServiceRequest) ProtoMessage()    {}
+func (*StreamServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{27}
+}
+
+func (m *StreamServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamServiceRequest.Unmarshal(m, b)
+}
+func (m *StreamServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamServiceRequest.Merge(m, src)
+}
+func (m *StreamServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamServiceRequest.Size(m)
+}
+func (m *StreamServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamServiceRequest proto.InternalMessageInfo
+
+func (m *StreamServiceRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type GetCodeRequest struct {
+	// Address of the service.
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCodeRequest) Reset()         { *m = GetCodeRequest{} }
+func (m *GetCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCodeRequest) ProtoMessage()    {}
+func (*GetCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{28}
+}
+
+func (m *GetCodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCodeRequest.Unmarshal(m, b)
+}
+func (m *GetCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCodeRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCodeRequest.Merge(m, src)
+}
+func (m *GetCodeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCodeRequest.Size(m)
+}
+func (m *GetCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCodeRequest proto.InternalMessageInfo
+
+func (m *GetCodeRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type GetCodeResponse struct {
+	// Address of the service.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex encoded code of the service.
+	Code                 string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCodeResponse) Reset()         { *m = GetCodeResponse{} }
+func (m *GetCodeResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCodeResponse) ProtoMessage()    {}
+func (*GetCodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{29}
+}
+
+func (m *GetCodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCodeResponse.Unmarshal(m, b)
+}
+func (m *GetCodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCodeResponse.Marshal(b, m, deterministic)
+}
+func (m *GetCodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCodeResponse.Merge(m, src)
+}
+func (m *GetCodeResponse) XXX_Size() int {
+	return xxx_messageInfo_GetCodeResponse.Size(m)
+}
+func (m *GetCodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCodeResponse proto.InternalMessageInfo
+
+func (m *GetCodeResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetCodeResponse) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type GetPublicKeyRequest struct {
+	// Address of the service.
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyRequest) Reset()         { *m = GetPublicKeyRequest{} }
+func (m *GetPublicKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyRequest) ProtoMessage()    {}
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{30}
+}
+
+func (m *GetPublicKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyRequest.Unmarshal(m, b)
+}
+func (m *GetPublicKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyRequest.Merge(m, src)
+}
+func (m *GetPublicKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyRequest.Size(m)
+}
+func (m *GetPublicKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyRequest proto.InternalMessageInfo
+
+func (m *GetPublicKeyRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type GetPublicKeyResponse struct {
+	Timestamp            uint64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	PublicKey            string   `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature            string   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyResponse) Reset()         { *m = GetPublicKeyResponse{} }
+func (m *GetPublicKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyResponse) ProtoMessage()    {}
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{31}
+}
+
+func (m *GetPublicKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyResponse.Unmarshal(m, b)
+}
+func (m *GetPublicKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyResponse.Merge(m, src)
+}
+func (m *GetPublicKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyResponse.Size(m)
+}
+func (m *GetPublicKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyResponse proto.InternalMessageInfo
+
+func (m *GetPublicKeyResponse) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *GetPublicKeyResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetPublicKeyResponse) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *GetPublicKeyResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type DataEvent struct {
+	// ID of the event.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Address of the service that emitted the log.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex encoded data of the log.
+	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Topics of the log.
+	Topics []string `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Name of the decoded event. Only set if the ABI of the service
+	// was registered.
+	Event string `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	// JSON encoded object with the decoded arguments of the event. Only
+	// set if the ABI of the service was registered.
+	Args                 string   `protobuf:"bytes,6,opt,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataEvent) Reset()         { *m = DataEvent{} }
+func (m *DataEvent) String() string { return proto.CompactTextString(m) }
+func (*DataEvent) ProtoMessage()    {}
+func (*DataEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{32}
+}
+
+func (m *DataEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataEvent.Unmarshal(m, b)
+}
+func (m *DataEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataEvent.Marshal(b, m, deterministic)
+}
+func (m *DataEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataEvent.Merge(m, src)
+}
+func (m *DataEvent) XXX_Size() int {
+	return xxx_messageInfo_DataEvent.Size(m)
+}
+func (m *DataEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataEvent proto.InternalMessageInfo
+
+func (m *DataEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DataEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DataEvent) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *DataEvent) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *DataEvent) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *DataEvent) GetArgs() string {
+	if m != nil {
+		return m.Args
+	}
+	return ""
+}
+
+type BlockEvent struct {
+	// ID of the event.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number               uint64   `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Hash                 string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash           string   `protobuf:"bytes,4,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Timestamp            uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockEvent) Reset()         { *m = BlockEvent{} }
+func (m *BlockEvent) String() string { return proto.CompactTextString(m) }
+func (*BlockEvent) ProtoMessage()    {}
+func (*BlockEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{33}
+}
+
+func (m *BlockEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockEvent.Unmarshal(m, b)
+}
+func (m *BlockEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockEvent.Marshal(b, m, deterministic)
+}
+func (m *BlockEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEvent.Merge(m, src)
+}
+func (m *BlockEvent) XXX_Size() int {
+	return xxx_messageInfo_BlockEvent.Size(m)
+}
+func (m *BlockEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEvent proto.InternalMessageInfo
+
+func (m *BlockEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *BlockEvent) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *BlockEvent) GetParentHash() string {
+	if m != nil {
+		return m.ParentHash
+	}
+	return ""
+}
+
+func (m *BlockEvent) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type ReceiptEvent struct {
+	// ID of the event.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Address of the service.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Status of the transaction.
+	Status               uint64       `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Transaction          *Transaction `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReceiptEvent) Reset()         { *m = ReceiptEvent{} }
+func (m *ReceiptEvent) String() string { return proto.CompactTextString(m) }
+func (*ReceiptEvent) ProtoMessage()    {}
+func (*ReceiptEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{34}
+}
+
+func (m *ReceiptEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptEvent.Unmarshal(m, b)
+}
+func (m *ReceiptEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptEvent.Marshal(b, m, deterministic)
+}
+func (m *ReceiptEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptEvent.Merge(m, src)
+}
+func (m *ReceiptEvent) XXX_Size() int {
+	return xxx_messageInfo_ReceiptEvent.Size(m)
+}
+func (m *ReceiptEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptEvent proto.InternalMessageInfo
+
+func (m *ReceiptEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ReceiptEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ReceiptEvent) GetStatus() uint64 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReceiptEvent) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type SubscriptionEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*SubscriptionEvent_Data
+	//	*SubscriptionEvent_Block
+	//	*SubscriptionEvent_Receipt
+	//	*SubscriptionEvent_Error
+	Event                isSubscriptionEvent_Event `protobuf_oneof:"event"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *SubscriptionEvent) Reset()         { *m = SubscriptionEvent{} }
+func (m *SubscriptionEvent) String() string { return proto.CompactTextString(m) }
+func (*SubscriptionEvent) ProtoMessage()    {}
+func (*SubscriptionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{35}
+}
+
+func (m *SubscriptionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriptionEvent.Unmarshal(m, b)
+}
+func (m *SubscriptionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriptionEvent.Marshal(b, m, deterministic)
+}
+func (m *SubscriptionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriptionEvent.Merge(m, src)
+}
+func (m *SubscriptionEvent) XXX_Size() int {
+	return xxx_messageInfo_SubscriptionEvent.Size(m)
+}
+func (m *SubscriptionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriptionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriptionEvent proto.InternalMessageInfo
+
+type isSubscriptionEvent_Event interface {
+	isSubscriptionEvent_Event()
+}
+
+type SubscriptionEvent_Data struct {
+	Data *DataEvent `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type SubscriptionEvent_Block struct {
+	Block *BlockEvent `protobuf:"bytes,2,opt,name=block,proto3,oneof"`
+}
+
+type SubscriptionEvent_Receipt struct {
+	Receipt *ReceiptEvent `protobuf:"bytes,3,opt,name=receipt,proto3,oneof"`
+}
+
+type SubscriptionEvent_Error struct {
+	Error *ErrorEvent `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+func (*SubscriptionEvent_Data) isSubscriptionEvent_Event() {}
+
+func (*SubscriptionEvent_Block) isSubscriptionEvent_Event() {}
+
+func (*SubscriptionEvent_Receipt) isSubscriptionEvent_Event() {}
+
+func (*SubscriptionEvent_Error) isSubscriptionEvent_Event() {}
+
+func (m *SubscriptionEvent) GetEvent() isSubscriptionEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *SubscriptionEvent) GetData() *DataEvent {
+	if x, ok := m.GetEvent().(*SubscriptionEvent_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (m *SubscriptionEvent) GetBlock() *BlockEvent {
+	if x, ok := m.GetEvent().(*SubscriptionEvent_Block); ok {
+		return x.Block
+	}
+	return nil
+}
+
+func (m *SubscriptionEvent) GetReceipt() *ReceiptEvent {
+	if x, ok := m.GetEvent().(*SubscriptionEvent_Receipt); ok {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (m *SubscriptionEvent) GetError() *ErrorEvent {
+	if x, ok := m.GetEvent().(*SubscriptionEvent_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubscriptionEvent) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubscriptionEvent_Data)(nil),
+		(*SubscriptionEvent_Block)(nil),
+		(*SubscriptionEvent_Receipt)(nil),
+		(*SubscriptionEvent_Error)(nil),
+	}
+}
+
+type SubscribeRequest struct {
+	// Events of the subscription. They all need to be of the same type.
+	Events []string `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Filter of the subscription as URL query parameters.
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// URL to which the events are delivered.
+	CallbackUrl          string   `protobuf:"bytes,3,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{36}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *SubscribeRequest) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
+type SubscribeResponse struct {
+	// ID of the subscription.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()    {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{37}
+}
+
+func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeResponse.Unmarshal(m, b)
+}
+func (m *SubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResponse.Merge(m, src)
+}
+func (m *SubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeResponse.Size(m)
+}
+func (m *SubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+func (m *SubscribeResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type UnsubscribeRequest struct {
+	// ID of the subscription.
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsubscribeRequest) Reset()         { *m = UnsubscribeRequest{} }
+func (m *UnsubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeRequest) ProtoMessage()    {}
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{38}
+}
+
+func (m *UnsubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsubscribeRequest.Unmarshal(m, b)
+}
+func (m *UnsubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *UnsubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeRequest.Merge(m, src)
+}
+func (m *UnsubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_UnsubscribeRequest.Size(m)
+}
+func (m *UnsubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeRequest proto.InternalMessageInfo
+
+func (m *UnsubscribeRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type UnsubscribeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsubscribeResponse) Reset()         { *m = UnsubscribeResponse{} }
+func (m *UnsubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*UnsubscribeResponse) ProtoMessage()    {}
+func (*UnsubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{39}
+}
+
+func (m *UnsubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsubscribeResponse.Unmarshal(m, b)
+}
+func (m *UnsubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *UnsubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsubscribeResponse.Merge(m, src)
+}
+func (m *UnsubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_UnsubscribeResponse.Size(m)
+}
+func (m *UnsubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsubscribeResponse proto.InternalMessageInfo
+
+type ListSubscriptionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSubscriptionsRequest) Reset()         { *m = ListSubscriptionsRequest{} }
+func (m *ListSubscriptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSubscriptionsRequest) ProtoMessage()    {}
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{40}
+}
+
+func (m *ListSubscriptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSubscriptionsRequest.Unmarshal(m, b)
+}
+func (m *ListSubscriptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSubscriptionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSubscriptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSubscriptionsRequest.Merge(m, src)
+}
+func (m *ListSubscriptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSubscriptionsRequest.Size(m)
+}
+func (m *ListSubscriptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSubscriptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSubscriptionsRequest proto.InternalMessageInfo
+
+type Topics struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Topics) Reset()         { *m = Topics{} }
+func (m *Topics) String() string { return proto.CompactTextString(m) }
+func (*Topics) ProtoMessage()    {}
+func (*Topics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{41}
+}
+
+func (m *Topics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Topics.Unmarshal(m, b)
+}
+func (m *Topics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Topics.Marshal(b, m, deterministic)
+}
+func (m *Topics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Topics.Merge(m, src)
+}
+func (m *Topics) XXX_Size() int {
+	return xxx_messageInfo_Topics.Size(m)
+}
+func (m *Topics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Topics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Topics proto.InternalMessageInfo
+
+func (m *Topics) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+type Subscription struct {
+	Id                   uint64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event                string    `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Addresses            []string  `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Topics               []*Topics `protobuf:"bytes,4,rep,name=topics,proto3" json:"topics,omitempty"`
+	CallbackUrl          string    `protobuf:"bytes,5,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	CreatedAtMs          int64     `protobuf:"varint,6,opt,name=created_at_ms,json=createdAtMs,proto3" json:"created_at_ms,omitempty"`
+	Offset               uint64    `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Subscription) Reset()         { *m = Subscription{} }
+func (m *Subscription) String() string { return proto.CompactTextString(m) }
+func (*Subscription) ProtoMessage()    {}
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{42}
+}
+
+func (m *Subscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Subscription.Unmarshal(m, b)
+}
+func (m *Subscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Subscription.Marshal(b, m, deterministic)
+}
+func (m *Subscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Subscription.Merge(m, src)
+}
+func (m *Subscription) XXX_Size() int {
+	return xxx_messageInfo_Subscription.Size(m)
+}
+func (m *Subscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_Subscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Subscription proto.InternalMessageInfo
+
+func (m *Subscription) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Subscription) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *Subscription) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *Subscription) GetTopics() []*Topics {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *Subscription) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
+func (m *Subscription) GetCreatedAtMs() int64 {
+	if m != nil {
+		return m.CreatedAtMs
+	}
+	return 0
+}
+
+func (m *Subscription) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListSubscriptionsResponse struct {
+	Subscriptions        []*Subscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListSubscriptionsResponse) Reset()         { *m = ListSubscriptionsResponse{} }
+func (m *ListSubscriptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSubscriptionsResponse) ProtoMessage()    {}
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{43}
+}
+
+func (m *ListSubscriptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSubscriptionsResponse.Unmarshal(m, b)
+}
+func (m *ListSubscriptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSubscriptionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSubscriptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSubscriptionsResponse.Merge(m, src)
+}
+func (m *ListSubscriptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSubscriptionsResponse.Size(m)
+}
+func (m *ListSubscriptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSubscriptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSubscriptionsResponse proto.InternalMessageInfo
+
+func (m *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type RegisterABIRequest struct {
+	// Address of the service.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// JSON encoded ABI of the service.
+	Abi                  string   `protobuf:"bytes,2,opt,name=abi,proto3" json:"abi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterABIRequest) Reset()         { *m = RegisterABIRequest{} }
+func (m *RegisterABIRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterABIRequest) ProtoMessage()    {}
+func (*RegisterABIRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{44}
+}
+
+func (m *RegisterABIRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterABIRequest.Unmarshal(m, b)
+}
+func (m *RegisterABIRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterABIRequest.Marshal(b, m, deterministic)
+}
+func (m *RegisterABIRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterABIRequest.Merge(m, src)
+}
+func (m *RegisterABIRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterABIRequest.Size(m)
+}
+func (m *RegisterABIRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterABIRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterABIRequest proto.InternalMessageInfo
+
+func (m *RegisterABIRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RegisterABIRequest) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
+type RegisterABIResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterABIResponse) Reset()         { *m = RegisterABIResponse{} }
+func (m *RegisterABIResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterABIResponse) ProtoMessage()    {}
+func (*RegisterABIResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{45}
+}
+
+func (m *RegisterABIResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterABIResponse.Unmarshal(m, b)
+}
+func (m *RegisterABIResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterABIResponse.Marshal(b, m, deterministic)
+}
+func (m *RegisterABIResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterABIResponse.Merge(m, src)
+}
+func (m *RegisterABIResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterABIResponse.Size(m)
+}
+func (m *RegisterABIResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterABIResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterABIResponse proto.InternalMessageInfo
+
+type PollEventRequest struct {
+	// ID of the subscription.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Offset from which to poll. If it is not set, the events are
+	// polled from the committed cursor.
+	//
+	// Types that are valid to be assigned to Position:
+	//	*PollEventRequest_Offset
+	Position isPollEventRequest_Position `protobuf_oneof:"position"`
+	// Maximum number of events to return.
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Discard the events before the offset.
+	DiscardPrevious bool `protobuf:"varint,4,opt,name=discard_previous,json=discardPrevious,proto3" json:"discard_previous,omitempty"`
+	// Maximum time to wait for events to be available.
+	WaitMs               uint64   `protobuf:"varint,5,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollEventRequest) Reset()         { *m = PollEventRequest{} }
+func (m *PollEventRequest) String() string { return proto.CompactTextString(m) }
+func (*PollEventRequest) ProtoMessage()    {}
+func (*PollEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{46}
+}
+
+func (m *PollEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollEventRequest.Unmarshal(m, b)
+}
+func (m *PollEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollEventRequest.Marshal(b, m, deterministic)
+}
+func (m *PollEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollEventRequest.Merge(m, src)
+}
+func (m *PollEventRequest) XXX_Size() int {
+	return xxx_messageInfo_PollEventRequest.Size(m)
+}
+func (m *PollEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollEventRequest proto.InternalMessageInfo
+
+func (m *PollEventRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type isPollEventRequest_Position interface {
+	isPollEventRequest_Position()
+}
+
+type PollEventRequest_Offset struct {
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3,oneof"`
+}
+
+func (*PollEventRequest_Offset) isPollEventRequest_Position() {}
+
+func (m *PollEventRequest) GetPosition() isPollEventRequest_Position {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *PollEventRequest) GetOffset() uint64 {
+	if x, ok := m.GetPosition().(*PollEventRequest_Offset); ok {
+		return x.Offset
+	}
+	return 0
+}
+
+func (m *PollEventRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PollEventRequest) GetDiscardPrevious() bool {
+	if m != nil {
+		return m.DiscardPrevious
+	}
+	return false
+}
+
+func (m *PollEventRequest) GetWaitMs() uint64 {
+	if m != nil {
+		return m.WaitMs
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PollEventRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PollEventRequest_Offset)(nil),
+	}
+}
+
+type PollEventResponse struct {
+	Offset               uint64               `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Events               []*SubscriptionEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PollEventResponse) Reset()         { *m = PollEventResponse{} }
+func (m *PollEventResponse) String() string { return proto.CompactTextString(m) }
+func (*PollEventResponse) ProtoMessage()    {}
+func (*PollEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{47}
+}
+
+func (m *PollEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollEventResponse.Unmarshal(m, b)
+}
+func (m *PollEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollEventResponse.Marshal(b, m, deterministic)
+}
+func (m *PollEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollEventResponse.Merge(m, src)
+}
+func (m *PollEventResponse) XXX_Size() int {
+	return xxx_messageInfo_PollEventResponse.Size(m)
+}
+func (m *PollEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollEventResponse proto.InternalMessageInfo
+
+func (m *PollEventResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *PollEventResponse) GetEvents() []*SubscriptionEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type AckEventRequest struct {
+	// ID of the subscription.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ID of the last event processed by the client.
+	EventId              uint64   `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckEventRequest) Reset()         { *m = AckEventRequest{} }
+func (m *AckEventRequest) String() string { return proto.CompactTextString(m) }
+func (*AckEventRequest) ProtoMessage()    {}
+func (*AckEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{48}
+}
+
+func (m *AckEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckEventRequest.Unmarshal(m, b)
+}
+func (m *AckEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckEventRequest.Marshal(b, m, deterministic)
+}
+func (m *AckEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckEventRequest.Merge(m, src)
+}
+func (m *AckEventRequest) XXX_Size() int {
+	return xxx_messageInfo_AckEventRequest.Size(m)
+}
+func (m *AckEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckEventRequest proto.InternalMessageInfo
+
+func (m *AckEventRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AckEventRequest) GetEventId() uint64 {
+	if m != nil {
+		return m.EventId
+	}
+	return 0
+}
+
+type AckEventResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckEventResponse) Reset()         { *m = AckEventResponse{} }
+func (m *AckEventResponse) String() string { return proto.CompactTextString(m) }
+func (*AckEventResponse) ProtoMessage()    {}
+func (*AckEventResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{49}
+}
+
+func (m *AckEventResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckEventResponse.Unmarshal(m, b)
+}
+func (m *AckEventResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckEventResponse.Marshal(b, m, deterministic)
+}
+func (m *AckEventResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckEventResponse.Merge(m, src)
+}
+func (m *AckEventResponse) XXX_Size() int {
+	return xxx_messageInfo_AckEventResponse.Size(m)
+}
+func (m *AckEventResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckEventResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckEventResponse proto.InternalMessageInfo
+
+type StreamEventRequest struct {
+	// ID of the subscription.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Offset from which to stream.
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamEventRequest) Reset()         { *m = StreamEventRequest{} }
+func (m *StreamEventRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamEventRequest) ProtoMessage()    {}
+func (*StreamEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7adb366f252ce5ff, []int{50}
+}
+
+func (m *StreamEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamEventRequest.Unmarshal(m, b)
+}
+func (m *StreamEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamEventRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamEventRequest.Merge(m, src)
+}
+func (m *StreamEventRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamEventRequest.Size(m)
+}
+func (m *StreamEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamEventRequest proto.InternalMessageInfo
+
+func (m *StreamEventRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *StreamEventRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Error)(nil), "oasis.gateway.v0.Error")
+	proto.RegisterType((*Log)(nil), "oasis.gateway.v0.Log")
+	proto.RegisterType((*Transaction)(nil), "oasis.gateway.v0.Transaction")
+	proto.RegisterType((*ErrorEvent)(nil), "oasis.gateway.v0.ErrorEvent")
+	proto.RegisterType((*ExecuteServiceEvent)(nil), "oasis.gateway.v0.ExecuteServiceEvent")
+	proto.RegisterType((*DeployServiceEvent)(nil), "oasis.gateway.v0.DeployServiceEvent")
+	proto.RegisterType((*ServiceEvent)(nil), "oasis.gateway.v0.ServiceEvent")
+	proto.RegisterType((*DeployServiceRequest)(nil), "oasis.gateway.v0.DeployServiceRequest")
+	proto.RegisterType((*DeployServiceResponse)(nil), "oasis.gateway.v0.DeployServiceResponse")
+	proto.RegisterType((*ExecuteServiceRequest)(nil), "oasis.gateway.v0.ExecuteServiceRequest")
+	proto.RegisterType((*ExecuteServiceResponse)(nil), "oasis.gateway.v0.ExecuteServiceResponse")
+	proto.RegisterType((*ExecuteServiceBatchItem)(nil), "oasis.gateway.v0.ExecuteServiceBatchItem")
+	proto.RegisterType((*ExecuteServiceBatchRequest)(nil), "oasis.gateway.v0.ExecuteServiceBatchRequest")
+	proto.RegisterType((*ExecuteServiceBatchItemResponse)(nil), "oasis.gateway.v0.ExecuteServiceBatchItemResponse")
+	proto.RegisterType((*ExecuteServiceBatchResponse)(nil), "oasis.gateway.v0.ExecuteServiceBatchResponse")
+	proto.RegisterType((*CallServiceRequest)(nil), "oasis.gateway.v0.CallServiceRequest")
+	proto.RegisterType((*CallServiceResponse)(nil), "oasis.gateway.v0.CallServiceResponse")
+	proto.RegisterType((*EstimateGasRequest)(nil), "oasis.gateway.v0.EstimateGasRequest")
+	proto.RegisterType((*EstimateGasResponse)(nil), "oasis.gateway.v0.EstimateGasResponse")
+	proto.RegisterType((*PollServiceRequest)(nil), "oasis.gateway.v0.PollServiceRequest")
+	proto.RegisterType((*PollServiceResponse)(nil), "oasis.gateway.v0.PollServiceResponse")
+	proto.RegisterType((*GetServiceStatusRequest)(nil), "oasis.gateway.v0.GetServiceStatusRequest")
+	proto.RegisterType((*GetServiceStatusResponse)(nil), "oasis.gateway.v0.GetServiceStatusResponse")
+	proto.RegisterType((*CancelServiceRequest)(nil), "oasis.gateway.v0.CancelServiceRequest")
+	proto.RegisterType((*CancelServiceResponse)(nil), "oasis.gateway.v0.CancelServiceResponse")
+	proto.RegisterType((*AckServiceRequest)(nil), "oasis.gateway.v0.AckServiceRequest")
+	proto.RegisterType((*AckServiceResponse)(nil), "oasis.gateway.v0.AckServiceResponse")
+	proto.RegisterType((*StreamServiceRequest)(nil), "oasis.gateway.v0.StreamServiceRequest")
+	proto.RegisterType((*GetCodeRequest)(nil), "oasis.gateway.v0.GetCodeRequest")
+	proto.RegisterType((*GetCodeResponse)(nil), "oasis.gateway.v0.GetCodeResponse")
+	proto.RegisterType((*GetPublicKeyRequest)(nil), "oasis.gateway.v0.GetPublicKeyRequest")
+	proto.RegisterType((*GetPublicKeyResponse)(nil), "oasis.gateway.v0.GetPublicKeyResponse")
+	proto.RegisterType((*DataEvent)(nil), "oasis.gateway.v0.DataEvent")
+	proto.RegisterType((*BlockEvent)(nil), "oasis.gateway.v0.BlockEvent")
+	proto.RegisterType((*ReceiptEvent)(nil), "oasis.gateway.v0.ReceiptEvent")
+	proto.RegisterType((*SubscriptionEvent)(nil), "oasis.gateway.v0.SubscriptionEvent")
+	proto.RegisterType((*SubscribeRequest)(nil), "oasis.gateway.v0.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "oasis.gateway.v0.SubscribeResponse")
+	proto.RegisterType((*UnsubscribeRequest)(nil), "oasis.gateway.v0.UnsubscribeRequest")
+	proto.RegisterType((*UnsubscribeResponse)(nil), "oasis.gateway.v0.UnsubscribeResponse")
+	proto.RegisterType((*ListSubscriptionsRequest)(nil), "oasis.gateway.v0.ListSubscriptionsRequest")
+	proto.RegisterType((*Topics)(nil), "oasis.gateway.v0.Topics")
+	proto.RegisterType((*Subscription)(nil), "oasis.gateway.v0.Subscription")
+	proto.RegisterType((*ListSubscriptionsResponse)(nil), "oasis.gateway.v0.ListSubscriptionsResponse")
+	proto.RegisterType((*RegisterABIRequest)(nil), "oasis.gateway.v0.RegisterABIRequest")
+	proto.RegisterType((*RegisterABIResponse)(nil), "oasis.gateway.v0.RegisterABIResponse")
+	proto.RegisterType((*PollEventRequest)(nil), "oasis.gateway.v0.PollEventRequest")
+	proto.RegisterType((*PollEventResponse)(nil), "oasis.gateway.v0.PollEventResponse")
+	proto.RegisterType((*AckEventRequest)(nil), "oasis.gateway.v0.AckEventRequest")
+	proto.RegisterType((*AckEventResponse)(nil), "oasis.gateway.v0.AckEventResponse")
+	proto.RegisterType((*StreamEventRequest)(nil), "oasis.gateway.v0.StreamEventRequest")
+}
+
+func init() { proto.RegisterFile("api/grpc/gateway.proto", fileDescriptor_7adb366f252ce5ff) }
+
+var fileDescriptor_7adb366f252ce5ff = []byte{
+	// 1830 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4d, 0x73, 0x13, 0xc9,
+	0x19, 0x66, 0xac, 0x2f, 0xeb, 0x15, 0x06, 0xd3, 0x96, 0x6d, 0x31, 0x40, 0xd6, 0x0c, 0x18, 0xcc,
+	0x6e, 0xb0, 0x59, 0x85, 0xca, 0x21, 0xd9, 0xca, 0xc6, 0x06, 0x97, 0x4d, 0x2d, 0x6c, 0xc8, 0x00,
+	0x95, 0x64, 0xab, 0x88, 0x68, 0xcd, 0xb4, 0xe5, 0x29, 0x24, 0xcd, 0x64, 0xba, 0x87, 0x85, 0x53,
+	0x8e, 0xd9, 0xaa, 0x9c, 0x52, 0x95, 0x43, 0xce, 0xf9, 0x1f, 0xf9, 0x0f, 0xf9, 0x0f, 0xf9, 0x11,
+	0xb9, 0xa6, 0xba, 0xfb, 0x1d, 0xcd, 0xa7, 0x34, 0xb6, 0x37, 0xb7, 0xee, 0x9e, 0xf7, 0xbb, 0xdf,
+	0x8f, 0xa7, 0x25, 0xd8, 0xa0, 0x81, 0xb7, 0x37, 0x0a, 0x03, 0x67, 0x6f, 0x44, 0x05, 0xfb, 0x9e,
+	0x7e, 0xda, 0x0d, 0x42, 0x5f, 0xf8, 0x64, 0xd5, 0xa7, 0xdc, 0xe3, 0xbb, 0xf1, 0xe1, 0x87, 0x47,
+	0xd6, 0x31, 0x34, 0x0e, 0xc3, 0xd0, 0x0f, 0xc9, 0x2d, 0x00, 0x26, 0x17, 0x03, 0xc7, 0x77, 0x59,
+	0xcf, 0xd8, 0x32, 0x76, 0x1a, 0x76, 0x5b, 0x9d, 0x3c, 0xf1, 0x5d, 0x46, 0xb6, 0xa0, 0xe3, 0x32,
+	0xee, 0x84, 0x5e, 0x20, 0x3c, 0x7f, 0xda, 0x5b, 0xda, 0x32, 0x76, 0xda, 0x76, 0xfa, 0xc8, 0xa2,
+	0x50, 0x7b, 0xee, 0x8f, 0x48, 0x0f, 0x5a, 0xd4, 0x75, 0x43, 0xc6, 0xb9, 0x12, 0xd2, 0xb6, 0xe3,
+	0x2d, 0xd9, 0x80, 0xa6, 0xf0, 0x03, 0xcf, 0xe1, 0xbd, 0xa5, 0xad, 0xda, 0x4e, 0xdb, 0xc6, 0x1d,
+	0x21, 0x50, 0x77, 0xa9, 0xa0, 0xbd, 0x9a, 0x22, 0x57, 0x6b, 0xd2, 0x85, 0x86, 0x37, 0x75, 0xd9,
+	0xc7, 0x5e, 0x7d, 0xcb, 0xd8, 0x59, 0xb1, 0xf5, 0xc6, 0xfa, 0x97, 0x01, 0x9d, 0xd7, 0x21, 0x9d,
+	0x72, 0xea, 0x48, 0x95, 0x92, 0xf3, 0x94, 0xf2, 0x53, 0x54, 0xa4, 0xd6, 0xe4, 0x36, 0x5c, 0x1e,
+	0x8e, 0x7d, 0xe7, 0xfd, 0x60, 0x1a, 0x4d, 0x86, 0x2c, 0x54, 0x96, 0xd6, 0xed, 0x8e, 0x3a, 0xfb,
+	0x56, 0x1d, 0x49, 0x57, 0x35, 0x89, 0x62, 0xd6, 0x6a, 0xdb, 0xea, 0xe4, 0x58, 0x4a, 0xb8, 0x0e,
+	0xcb, 0x23, 0xca, 0x07, 0x11, 0x67, 0xae, 0x52, 0x5f, 0xb7, 0x5b, 0x23, 0xca, 0xdf, 0x70, 0xe6,
+	0x4a, 0x85, 0x27, 0xa1, 0x3f, 0xe9, 0x35, 0xb4, 0x42, 0xb9, 0x26, 0x0f, 0xa0, 0x3e, 0xf6, 0x47,
+	0xbc, 0xd7, 0xdc, 0xaa, 0xed, 0x74, 0xfa, 0xeb, 0xbb, 0xf9, 0x10, 0xef, 0x3e, 0xf7, 0x47, 0xb6,
+	0x22, 0xb1, 0xbe, 0x01, 0x50, 0xc1, 0x3e, 0xfc, 0xc0, 0xa6, 0x82, 0x5c, 0x81, 0x25, 0xcf, 0x55,
+	0xb6, 0xd7, 0xed, 0x25, 0xcf, 0x25, 0x0f, 0xa1, 0xe1, 0xd0, 0x88, 0x33, 0x65, 0x72, 0xa7, 0xbf,
+	0x59, 0x94, 0xa4, 0x98, 0x6d, 0x4d, 0x65, 0xfd, 0xc3, 0x80, 0xb5, 0xc3, 0x8f, 0xcc, 0x89, 0x04,
+	0x7b, 0xc5, 0xc2, 0x0f, 0x9e, 0xc3, 0xca, 0xc5, 0xa6, 0x2e, 0x64, 0xa9, 0x70, 0x21, 0x7e, 0x24,
+	0x82, 0x48, 0x60, 0x0c, 0x70, 0x47, 0xbe, 0x86, 0x8e, 0x48, 0xa2, 0xac, 0x62, 0xd0, 0xe9, 0xdf,
+	0x2a, 0x9a, 0x93, 0xba, 0x0a, 0x3b, 0xcd, 0x61, 0xfd, 0x19, 0xc8, 0x53, 0x16, 0x8c, 0xfd, 0x4f,
+	0x17, 0x34, 0x2c, 0x67, 0x40, 0xed, 0xdc, 0x06, 0xfc, 0xdb, 0x80, 0xcb, 0x19, 0xdd, 0xfb, 0xd0,
+	0x62, 0x3a, 0x56, 0xca, 0x80, 0x4e, 0x7f, 0xbb, 0x24, 0xba, 0xc5, 0x60, 0x1e, 0x5f, 0xb2, 0x63,
+	0x3e, 0xf2, 0x2b, 0x68, 0xba, 0xca, 0x29, 0xbc, 0x9f, 0xbb, 0x45, 0x09, 0x45, 0xa7, 0x8f, 0x2f,
+	0xd9, 0xc8, 0x45, 0x1e, 0x43, 0x43, 0x95, 0x13, 0xba, 0x73, 0x73, 0xce, 0xf5, 0xc6, 0x6c, 0x9a,
+	0xf8, 0xa0, 0x05, 0x0d, 0x26, 0x4f, 0xac, 0xb7, 0xd0, 0xcd, 0x88, 0xb7, 0xd9, 0x9f, 0x22, 0xc6,
+	0xc5, 0xac, 0x7a, 0x8c, 0x54, 0xf5, 0x10, 0xa8, 0x7f, 0x4f, 0x3d, 0xa1, 0x0c, 0x5d, 0xb6, 0xd5,
+	0x5a, 0x26, 0xbd, 0xf0, 0x26, 0xcc, 0x8f, 0xc4, 0x60, 0xc2, 0x95, 0x0d, 0x75, 0xbb, 0x8d, 0x27,
+	0x2f, 0xb8, 0xf5, 0x16, 0xd6, 0x73, 0xe2, 0x79, 0xe0, 0x4f, 0x39, 0x2b, 0xdc, 0xda, 0x63, 0x34,
+	0x08, 0xa3, 0xf0, 0x93, 0xa2, 0x1b, 0x69, 0xff, 0x6d, 0xb4, 0xfe, 0x23, 0xac, 0x67, 0xc3, 0xbb,
+	0xc8, 0xfc, 0xf9, 0x89, 0x11, 0x3b, 0x56, 0x9b, 0xeb, 0x58, 0x3d, 0xef, 0xd8, 0x1f, 0x61, 0x23,
+	0xaf, 0xf9, 0xff, 0xea, 0xd9, 0x11, 0x6c, 0x66, 0xe5, 0x1f, 0x50, 0xe1, 0x9c, 0x3e, 0x13, 0x6c,
+	0x72, 0x3e, 0xdf, 0x2c, 0x07, 0xcc, 0x12, 0x41, 0x71, 0x9c, 0x0e, 0x61, 0x39, 0xd4, 0x4b, 0xd9,
+	0x57, 0x65, 0xa7, 0x79, 0x50, 0x95, 0xc1, 0x33, 0x43, 0xec, 0x19, 0xab, 0xf5, 0x0e, 0x3e, 0x9b,
+	0x47, 0x34, 0x2f, 0x2c, 0xe7, 0x6c, 0x4b, 0x53, 0xb8, 0x51, 0xea, 0x06, 0x4a, 0xff, 0x0d, 0xb4,
+	0x43, 0x5c, 0xc7, 0x8e, 0x7c, 0x79, 0x76, 0x47, 0x90, 0xd3, 0x4e, 0x64, 0x58, 0xbf, 0x07, 0xf2,
+	0x84, 0x8e, 0xc7, 0x3f, 0x2a, 0xad, 0xba, 0xd0, 0x50, 0xed, 0x1f, 0xfb, 0xa0, 0xde, 0x58, 0x47,
+	0xb0, 0x96, 0x91, 0x8c, 0x1e, 0x2c, 0x1c, 0x70, 0xd8, 0x4f, 0x97, 0xd2, 0xfd, 0xd4, 0x3a, 0x00,
+	0x72, 0xc8, 0x85, 0x37, 0xa1, 0x82, 0x1d, 0x51, 0x7e, 0x21, 0x13, 0xad, 0x77, 0xb0, 0x96, 0x91,
+	0x81, 0xc6, 0xac, 0x42, 0x6d, 0x44, 0x39, 0xde, 0x96, 0x5c, 0x92, 0x1b, 0xd0, 0x96, 0xd3, 0x2b,
+	0x08, 0x3d, 0x87, 0xe1, 0xf0, 0x93, 0xe3, 0xec, 0xa5, 0xdc, 0x13, 0x13, 0x96, 0x4f, 0xe8, 0x78,
+	0x3c, 0xa4, 0xe8, 0xeb, 0xb2, 0x3d, 0xdb, 0x5b, 0x7f, 0x37, 0x80, 0xbc, 0xf4, 0x0b, 0x91, 0xec,
+	0x41, 0xd3, 0x3f, 0x39, 0xe1, 0x4c, 0x68, 0x25, 0xb2, 0xa1, 0xe9, 0xbd, 0x8c, 0x9a, 0xe3, 0x47,
+	0x58, 0x2f, 0x2b, 0xb6, 0xde, 0x90, 0x07, 0xb0, 0xea, 0x7a, 0xdc, 0xa1, 0xa1, 0x3b, 0x08, 0x42,
+	0xf6, 0xc1, 0xf3, 0x23, 0x8e, 0xaa, 0xae, 0xe2, 0xf9, 0x4b, 0x3c, 0x26, 0x9b, 0xd0, 0x92, 0x15,
+	0x9c, 0x94, 0x6d, 0x53, 0x6e, 0x5f, 0xf0, 0x03, 0x80, 0xe5, 0xc0, 0xe7, 0x9e, 0x6a, 0xe5, 0x0c,
+	0xd6, 0x32, 0x56, 0xa1, 0xe3, 0x1b, 0x59, 0xb3, 0x66, 0x46, 0xfd, 0x1c, 0x9a, 0xaa, 0x2e, 0x35,
+	0xc8, 0xa8, 0xae, 0x62, 0xa4, 0xb6, 0x1e, 0xc0, 0xe6, 0x11, 0x13, 0xf8, 0xe9, 0x95, 0xa0, 0x22,
+	0x9a, 0x5d, 0x54, 0xae, 0x20, 0xac, 0x8f, 0xd0, 0x2b, 0x92, 0xce, 0x29, 0x9e, 0x0d, 0x68, 0x72,
+	0x45, 0x11, 0xa7, 0x84, 0xde, 0x25, 0xbd, 0xa6, 0x76, 0x9e, 0x5e, 0x73, 0x0f, 0xba, 0x4f, 0xe8,
+	0xd4, 0x61, 0xf9, 0x3b, 0xca, 0x5b, 0xb8, 0x09, 0xeb, 0x39, 0x3a, 0x6d, 0x9e, 0x75, 0x07, 0xae,
+	0xed, 0x3b, 0xef, 0x2b, 0xb8, 0xbb, 0x40, 0xd2, 0x44, 0xc8, 0xba, 0x0b, 0xdd, 0x57, 0x22, 0x64,
+	0x74, 0x92, 0xe3, 0x9e, 0x73, 0x11, 0xd6, 0xe7, 0x70, 0xe5, 0x88, 0x09, 0x89, 0x1d, 0x93, 0x4c,
+	0x9a, 0x53, 0x38, 0xd6, 0xd7, 0x70, 0x75, 0x46, 0x5b, 0x59, 0x65, 0x04, 0xea, 0x0a, 0xa2, 0xea,
+	0x80, 0xaa, 0xb5, 0xb5, 0x07, 0x6b, 0x47, 0x4c, 0xbc, 0x8c, 0x86, 0x63, 0xcf, 0xf9, 0x86, 0x7d,
+	0xaa, 0xd6, 0xf8, 0x57, 0x03, 0xba, 0x59, 0x0e, 0xd4, 0x7b, 0x13, 0xd4, 0xec, 0xe0, 0x82, 0x4e,
+	0x02, 0xf4, 0x28, 0x39, 0x58, 0xd0, 0x42, 0x6e, 0x01, 0x04, 0x4a, 0xd8, 0xe0, 0x3d, 0xfb, 0x14,
+	0x63, 0xca, 0x20, 0x16, 0x2f, 0xc5, 0x72, 0x6f, 0x34, 0xa5, 0x22, 0x0a, 0x99, 0x4a, 0xf6, 0xb6,
+	0x9d, 0x1c, 0x48, 0x6b, 0xda, 0x4f, 0xa9, 0xa0, 0xe7, 0xc5, 0x49, 0x65, 0xc8, 0x39, 0x41, 0xd9,
+	0xf5, 0x0c, 0xca, 0xee, 0xc6, 0x19, 0xa7, 0xb1, 0xab, 0xde, 0x48, 0x09, 0x34, 0x54, 0xe0, 0x55,
+	0x49, 0x90, 0x6b, 0xeb, 0x2f, 0x06, 0xc0, 0x81, 0xec, 0x80, 0xe5, 0xe6, 0x6c, 0x40, 0x33, 0x03,
+	0xad, 0x71, 0x37, 0x03, 0xe3, 0xb5, 0x14, 0x18, 0xff, 0x0c, 0x3a, 0x01, 0x0d, 0xd9, 0x54, 0x68,
+	0xa8, 0xad, 0x1d, 0x07, 0x7d, 0xa4, 0xb0, 0x76, 0x26, 0xdc, 0x8d, 0x5c, 0xb8, 0xad, 0xbf, 0x19,
+	0x70, 0xd9, 0x66, 0x0e, 0xf3, 0x02, 0x71, 0x01, 0x6c, 0x8b, 0x85, 0xa7, 0xa1, 0x0e, 0xee, 0x7e,
+	0x3c, 0xb6, 0xfd, 0xaf, 0x01, 0xd7, 0x5e, 0x45, 0xc3, 0xd9, 0xbb, 0x47, 0x1b, 0xf6, 0x65, 0xaa,
+	0x99, 0x77, 0xfa, 0x37, 0x4a, 0xa0, 0x61, 0x7c, 0xbd, 0xc7, 0x97, 0xf0, 0xa2, 0x1e, 0xc7, 0x43,
+	0x67, 0x69, 0x1e, 0x1e, 0x4c, 0x2e, 0x41, 0xe2, 0x41, 0x45, 0x4c, 0x7e, 0x01, 0xad, 0x50, 0x47,
+	0x64, 0x7e, 0xeb, 0x48, 0x87, 0x4c, 0x22, 0x58, 0x64, 0x48, 0x10, 0x68, 0xfd, 0x42, 0x08, 0x94,
+	0xc1, 0x2a, 0x3a, 0x3e, 0x4c, 0x57, 0x3f, 0xb6, 0x5b, 0x43, 0x67, 0x9b, 0xde, 0xc9, 0xf3, 0x13,
+	0x6f, 0x2c, 0x30, 0x49, 0xda, 0x36, 0xee, 0xe4, 0xeb, 0xcc, 0xc1, 0x81, 0x33, 0x88, 0xc2, 0x31,
+	0x26, 0x4b, 0x27, 0x3e, 0x7b, 0x13, 0x8e, 0x65, 0x8f, 0x4a, 0xa9, 0x29, 0xef, 0xab, 0xd6, 0x5d,
+	0x20, 0x6f, 0xa6, 0x3c, 0x6f, 0x4d, 0x9e, 0x6a, 0x1d, 0xd6, 0x32, 0x54, 0xd8, 0xca, 0x4c, 0xe8,
+	0x3d, 0xf7, 0xb8, 0x48, 0xdf, 0x62, 0xdc, 0xec, 0xad, 0x2d, 0x68, 0xbe, 0xd6, 0x05, 0x93, 0x14,
+	0x92, 0x91, 0x2e, 0x24, 0xeb, 0x3f, 0xf2, 0x6d, 0x91, 0x62, 0x2d, 0x24, 0x65, 0x37, 0x8d, 0x23,
+	0x67, 0x95, 0x76, 0x13, 0xda, 0x98, 0x9b, 0x4c, 0xe6, 0xa4, 0x94, 0x98, 0x1c, 0x90, 0x47, 0x99,
+	0xaa, 0xed, 0xf4, 0x7b, 0x25, 0x19, 0xa9, 0xbe, 0xcf, 0xea, 0x39, 0x1f, 0xc9, 0x46, 0x21, 0x92,
+	0xc4, 0x82, 0x15, 0x27, 0x64, 0x54, 0x30, 0x77, 0x40, 0xd5, 0x94, 0x95, 0x55, 0x5e, 0xb3, 0x3b,
+	0x78, 0xb8, 0x2f, 0x5e, 0xf0, 0x54, 0xfb, 0x6e, 0x65, 0xda, 0x37, 0x85, 0xeb, 0x25, 0x31, 0xc2,
+	0xdb, 0x78, 0x0a, 0x2b, 0x3c, 0xfd, 0x01, 0x81, 0x5c, 0xd9, 0x14, 0x4b, 0x91, 0xd9, 0x59, 0x26,
+	0xeb, 0xd7, 0x40, 0x6c, 0x36, 0xf2, 0xb8, 0x60, 0xe1, 0xfe, 0xc1, 0xb3, 0xca, 0x9e, 0x2d, 0xb1,
+	0x0e, 0x1d, 0x7a, 0x18, 0x55, 0xb9, 0x94, 0xf7, 0x9b, 0x91, 0x80, 0xf7, 0xfb, 0x4f, 0x03, 0x56,
+	0x25, 0x66, 0xd0, 0xb3, 0xb3, 0x3c, 0x37, 0x52, 0xb8, 0x66, 0x69, 0x1e, 0xae, 0xa9, 0x55, 0xe1,
+	0x9a, 0x7a, 0x25, 0xae, 0x69, 0xcc, 0xc5, 0x35, 0xa7, 0x70, 0x2d, 0x65, 0x63, 0x05, 0xaa, 0xf9,
+	0x65, 0x0e, 0xd5, 0xdc, 0x59, 0x1c, 0xe9, 0x2c, 0xb4, 0xf9, 0x0a, 0xae, 0xee, 0x3b, 0xef, 0x17,
+	0x06, 0xe3, 0x3a, 0x2c, 0x2b, 0xe2, 0x81, 0xe7, 0x62, 0x57, 0x6f, 0xa9, 0xfd, 0x33, 0xd7, 0x22,
+	0xb0, 0x9a, 0x70, 0x63, 0x80, 0xbf, 0x02, 0xa2, 0xb1, 0xc0, 0x42, 0xa1, 0x1b, 0xd9, 0x08, 0xc7,
+	0xce, 0xf4, 0x7f, 0x68, 0x43, 0x0b, 0x41, 0x04, 0x79, 0x07, 0x2b, 0x99, 0x67, 0x27, 0xb9, 0x57,
+	0xf1, 0xaa, 0x46, 0x65, 0xe6, 0xfd, 0x4a, 0x3a, 0x0c, 0xa9, 0x03, 0x57, 0xb2, 0xaf, 0x09, 0x72,
+	0xbf, 0xea, 0xbd, 0x11, 0xeb, 0xd8, 0xa9, 0x26, 0x44, 0x25, 0x21, 0xac, 0x95, 0x3c, 0x59, 0xc8,
+	0x4f, 0xcf, 0xf4, 0xb2, 0x89, 0xd5, 0x3d, 0x3c, 0x23, 0x35, 0xea, 0xfc, 0x0e, 0x3a, 0xa9, 0xe7,
+	0x09, 0x29, 0xf9, 0x39, 0xa2, 0xf8, 0x2e, 0x32, 0xb7, 0x2b, 0xa8, 0x12, 0xd9, 0xa9, 0xd7, 0x46,
+	0x99, 0xec, 0xe2, 0x83, 0xc6, 0xdc, 0xae, 0xa0, 0x4a, 0x64, 0xa7, 0x00, 0x7d, 0x99, 0xec, 0xe2,
+	0x2b, 0xc4, 0xdc, 0xae, 0xa0, 0x42, 0xd9, 0x1e, 0xac, 0xe6, 0xa1, 0x39, 0x29, 0x79, 0x27, 0xcf,
+	0x41, 0xfa, 0xe6, 0xe7, 0x67, 0x21, 0x45, 0x55, 0xef, 0x60, 0x25, 0x83, 0xb1, 0xcb, 0x32, 0xb7,
+	0x0c, 0xac, 0x9b, 0xf7, 0x2b, 0xe9, 0x50, 0xc3, 0xef, 0x00, 0x12, 0x1c, 0x4e, 0x4a, 0x4a, 0xbe,
+	0x00, 0xe5, 0xcd, 0xbb, 0x8b, 0x89, 0x50, 0xf0, 0x1f, 0x60, 0x25, 0x03, 0xe5, 0xcb, 0x4c, 0x2f,
+	0xc3, 0xfa, 0x66, 0xc5, 0x33, 0xe5, 0x91, 0x41, 0xbe, 0x85, 0x16, 0x22, 0x79, 0xb2, 0x55, 0x1a,
+	0xcc, 0xd4, 0x83, 0xc0, 0xbc, 0xbd, 0x80, 0x02, 0x4d, 0x7d, 0x0b, 0x97, 0xd3, 0x30, 0x9d, 0x6c,
+	0x97, 0xb2, 0xe4, 0x81, 0xbf, 0x79, 0xaf, 0x8a, 0x4c, 0x8b, 0xef, 0xff, 0xd0, 0x80, 0x86, 0x06,
+	0x70, 0xaf, 0xa1, 0x3d, 0x43, 0x1d, 0xc4, 0x9a, 0xdb, 0x5e, 0x67, 0x58, 0xc3, 0xbc, 0xb3, 0x90,
+	0x26, 0xc9, 0xf5, 0x14, 0x00, 0x29, 0xcb, 0xf5, 0x22, 0x8a, 0x31, 0xb7, 0x2b, 0xa8, 0x50, 0xf6,
+	0x18, 0xae, 0x15, 0x26, 0x34, 0x29, 0xc9, 0xe0, 0x79, 0x50, 0xc7, 0xfc, 0xe2, 0x4c, 0xb4, 0x89,
+	0x27, 0xa9, 0x51, 0x5b, 0xe6, 0x49, 0x71, 0x96, 0x9b, 0xdb, 0x15, 0x54, 0x28, 0xfb, 0x35, 0xb4,
+	0x67, 0xa3, 0xb0, 0x2c, 0xf6, 0xf9, 0x59, 0x6e, 0xde, 0x59, 0x48, 0x83, 0x52, 0x7f, 0x0b, 0xcb,
+	0xf1, 0xe0, 0x22, 0xb7, 0x4b, 0xeb, 0x22, 0x23, 0xd3, 0x5a, 0x44, 0x92, 0x04, 0x21, 0x35, 0xf7,
+	0xca, 0x82, 0x50, 0x1c, 0x8b, 0xe6, 0x59, 0x66, 0xf5, 0x23, 0xe3, 0xe0, 0xe1, 0x77, 0x5f, 0x8c,
+	0x3c, 0x71, 0x1a, 0x0d, 0x77, 0x1d, 0x7f, 0xb2, 0xa7, 0x58, 0xc6, 0x74, 0xc8, 0xf5, 0xea, 0x21,
+	0x32, 0xef, 0xc5, 0xff, 0xeb, 0x0c, 0x9b, 0xea, 0x0f, 0x9d, 0x9f, 0xfd, 0x6f, 0x00, 0x9c, 0x7f,
+	0x14, 0xc0, 0xea, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ServiceClient is the client API for Service service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ServiceClient interface {
+	// Deploy a new service.
+	DeployService(ctx context.Context, in *DeployServiceRequest, opts ...grpc.CallOption) (*DeployServiceResponse, error)
+	// Execute a method of a deployed service.
+	ExecuteService(ctx context.Context, in *ExecuteServiceRequest, opts ...grpc.CallOption) (*ExecuteServiceResponse, error)
+	// Execute a batch of methods of deployed services.
+	ExecuteServiceBatch(ctx context.Context, in *ExecuteServiceBatchRequest, opts ...grpc.CallOption) (*ExecuteServiceBatchResponse, error)
+	// Call a method of a deployed service without sending a transaction.
+	CallService(ctx context.Context, in *CallServiceRequest, opts ...grpc.CallOption) (*CallServiceResponse, error)
+	// Estimate the gas required to deploy or execute a service.
+	EstimateGas(ctx context.Context, in *EstimateGasRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error)
+	// Poll the responses to the asynchronous requests of the session.
+	PollService(ctx context.Context, in *PollServiceRequest, opts ...grpc.CallOption) (*PollServiceResponse, error)
+	// Get the status of an asynchronous request.
+	GetServiceStatus(ctx context.Context, in *GetServiceStatusRequest, opts ...grpc.CallOption) (*GetServiceStatusResponse, error)
+	// Cancel an asynchronous request that has not sent its transaction.
+	CancelService(ctx context.Context, in *CancelServiceRequest, opts ...grpc.CallOption) (*CancelServiceResponse, error)
+	// Acknowledge the responses to the asynchronous requests of the session.
+	AckService(ctx context.Context, in *AckServiceRequest, opts ...grpc.CallOption) (*AckServiceResponse, error)
+	// Stream the responses to the asynchronous requests of the session.
+	StreamService(ctx context.Context, in *StreamServiceRequest, opts ...grpc.CallOption) (Service_StreamServiceClient, error)
+	// Get the code of a deployed service.
+	GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*GetCodeResponse, error)
+	// Get the public key of a deployed service.
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+}
+
+type serviceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewServiceClient(cc *grpc.ClientConn) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) DeployService(ctx context.Context, in *DeployServiceRequest, opts ...grpc.CallOption) (*DeployServiceResponse, error) {
+	out := new(DeployServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/DeployService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ExecuteService(ctx context.Context, in *ExecuteServiceRequest, opts ...grpc.CallOption) (*ExecuteServiceResponse, error) {
+	out := new(ExecuteServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/ExecuteService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ExecuteServiceBatch(ctx context.Context, in *ExecuteServiceBatchRequest, opts ...grpc.CallOption) (*ExecuteServiceBatchResponse, error) {
+	out := new(ExecuteServiceBatchResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/ExecuteServiceBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CallService(ctx context.Context, in *CallServiceRequest, opts ...grpc.CallOption) (*CallServiceResponse, error) {
+	out := new(CallServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/CallService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) EstimateGas(ctx context.Context, in *EstimateGasRequest, opts ...grpc.CallOption) (*EstimateGasResponse, error) {
+	out := new(EstimateGasResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/EstimateGas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) PollService(ctx context.Context, in *PollServiceRequest, opts ...grpc.CallOption) (*PollServiceResponse, error) {
+	out := new(PollServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/PollService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetServiceStatus(ctx context.Context, in *GetServiceStatusRequest, opts ...grpc.CallOption) (*GetServiceStatusResponse, error) {
+	out := new(GetServiceStatusResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/GetServiceStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CancelService(ctx context.Context, in *CancelServiceRequest, opts ...grpc.CallOption) (*CancelServiceResponse, error) {
+	out := new(CancelServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/CancelService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) AckService(ctx context.Context, in *AckServiceRequest, opts ...grpc.CallOption) (*AckServiceResponse, error) {
+	out := new(AckServiceResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/AckService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) StreamService(ctx context.Context, in *StreamServiceRequest, opts ...grpc.CallOption) (Service_StreamServiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[0], "/oasis.gateway.v0.Service/StreamService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceStreamServiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_StreamServiceClient interface {
+	Recv() (*ServiceEvent, error)
+	grpc.ClientStream
+}
+
+type serviceStreamServiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceStreamServiceClient) Recv() (*ServiceEvent, error) {
+	m := new(ServiceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*GetCodeResponse, error) {
+	out := new(GetCodeResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/GetCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Service/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServiceServer is the server API for Service service.
+type ServiceServer interface {
+	// Deploy a new service.
+	DeployService(context.Context, *DeployServiceRequest) (*DeployServiceResponse, error)
+	// Execute a method of a deployed service.
+	ExecuteService(context.Context, *ExecuteServiceRequest) (*ExecuteServiceResponse, error)
+	// Execute a batch of methods of deployed services.
+	ExecuteServiceBatch(context.Context, *ExecuteServiceBatchRequest) (*ExecuteServiceBatchResponse, error)
+	// Call a method of a deployed service without sending a transaction.
+	CallService(context.Context, *CallServiceRequest) (*CallServiceResponse, error)
+	// Estimate the gas required to deploy or execute a service.
+	EstimateGas(context.Context, *EstimateGasRequest) (*EstimateGasResponse, error)
+	// Poll the responses to the asynchronous requests of the session.
+	PollService(context.Context, *PollServiceRequest) (*PollServiceResponse, error)
+	// Get the status of an asynchronous request.
+	GetServiceStatus(context.Context, *GetServiceStatusRequest) (*GetServiceStatusResponse, error)
+	// Cancel an asynchronous request that has not sent its transaction.
+	CancelService(context.Context, *CancelServiceRequest) (*CancelServiceResponse, error)
+	// Acknowledge the responses to the asynchronous requests of the session.
+	AckService(context.Context, *AckServiceRequest) (*AckServiceResponse, error)
+	// Stream the responses to the asynchronous requests of the session.
+	StreamService(*StreamServiceRequest, Service_StreamServiceServer) error
+	// Get the code of a deployed service.
+	GetCode(context.Context, *GetCodeRequest) (*GetCodeResponse, error)
+	// Get the public key of a deployed service.
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) DeployService(ctx context.Context, req *DeployServiceRequest) (*DeployServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployService not implemented")
+}
+func (*UnimplementedServiceServer) ExecuteService(ctx context.Context, req *ExecuteServiceRequest) (*ExecuteServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteService not implemented")
+}
+func (*UnimplementedServiceServer) ExecuteServiceBatch(ctx context.Context, req *ExecuteServiceBatchRequest) (*ExecuteServiceBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteServiceBatch not implemented")
+}
+func (*UnimplementedServiceServer) CallService(ctx context.Context, req *CallServiceRequest) (*CallServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallService not implemented")
+}
+func (*UnimplementedServiceServer) EstimateGas(ctx context.Context, req *EstimateGasRequest) (*EstimateGasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateGas not implemented")
+}
+func (*UnimplementedServiceServer) PollService(ctx context.Context, req *PollServiceRequest) (*PollServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PollService not implemented")
+}
+func (*UnimplementedServiceServer) GetServiceStatus(ctx context.Context, req *GetServiceStatusRequest) (*GetServiceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceStatus not implemented")
+}
+func (*UnimplementedServiceServer) CancelService(ctx context.Context, req *CancelServiceRequest) (*CancelServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelService not implemented")
+}
+func (*UnimplementedServiceServer) AckService(ctx context.Context, req *AckServiceRequest) (*AckServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckService not implemented")
+}
+func (*UnimplementedServiceServer) StreamService(req *StreamServiceRequest, srv Service_StreamServiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamService not implemented")
+}
+func (*UnimplementedServiceServer) GetCode(ctx context.Context, req *GetCodeRequest) (*GetCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCode not implemented")
+}
+func (*UnimplementedServiceServer) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+
+func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+func _Service_DeployService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeployService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/DeployService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeployService(ctx, req.(*DeployServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ExecuteService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ExecuteService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/ExecuteService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ExecuteService(ctx, req.(*ExecuteServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ExecuteServiceBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteServiceBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ExecuteServiceBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/ExecuteServiceBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ExecuteServiceBatch(ctx, req.(*ExecuteServiceBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CallService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CallService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/CallService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CallService(ctx, req.(*CallServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_EstimateGas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateGasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).EstimateGas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/EstimateGas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).EstimateGas(ctx, req.(*EstimateGasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_PollService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).PollService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/PollService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PollService(ctx, req.(*PollServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetServiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetServiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/GetServiceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetServiceStatus(ctx, req.(*GetServiceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CancelService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CancelService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/CancelService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CancelService(ctx, req.(*CancelServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_AckService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).AckService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/AckService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).AckService(ctx, req.(*AckServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_StreamService_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamServiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).StreamService(m, &serviceStreamServiceServer{stream})
+}
+
+type Service_StreamServiceServer interface {
+	Send(*ServiceEvent) error
+	grpc.ServerStream
+}
+
+type serviceStreamServiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceStreamServiceServer) Send(m *ServiceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/GetCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetCode(ctx, req.(*GetCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Service/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "oasis.gateway.v0.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeployService",
+			Handler:    _Service_DeployService_Handler,
+		},
+		{
+			MethodName: "ExecuteService",
+			Handler:    _Service_ExecuteService_Handler,
+		},
+		{
+			MethodName: "ExecuteServiceBatch",
+			Handler:    _Service_ExecuteServiceBatch_Handler,
+		},
+		{
+			MethodName: "CallService",
+			Handler:    _Service_CallService_Handler,
+		},
+		{
+			MethodName: "EstimateGas",
+			Handler:    _Service_EstimateGas_Handler,
+		},
+		{
+			MethodName: "PollService",
+			Handler:    _Service_PollService_Handler,
+		},
+		{
+			MethodName: "GetServiceStatus",
+			Handler:    _Service_GetServiceStatus_Handler,
+		},
+		{
+			MethodName: "CancelService",
+			Handler:    _Service_CancelService_Handler,
+		},
+		{
+			MethodName: "AckService",
+			Handler:    _Service_AckService_Handler,
+		},
+		{
+			MethodName: "GetCode",
+			Handler:    _Service_GetCode_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Service_GetPublicKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamService",
+			Handler:       _Service_StreamService_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/grpc/gateway.proto",
+}
+
+// EventClient is the client API for Event service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventClient interface {
+	// Create a subscription.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error)
+	// Destroy a subscription.
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error)
+	// List the active subscriptions of the session.
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// Register the ABI used to decode the logs of a service.
+	RegisterABI(ctx context.Context, in *RegisterABIRequest, opts ...grpc.CallOption) (*RegisterABIResponse, error)
+	// Poll the events of a subscription.
+	PollEvent(ctx context.Context, in *PollEventRequest, opts ...grpc.CallOption) (*PollEventResponse, error)
+	// Acknowledge the events of a subscription.
+	AckEvent(ctx context.Context, in *AckEventRequest, opts ...grpc.CallOption) (*AckEventResponse, error)
+	// Stream the events of a subscription.
+	StreamEvent(ctx context.Context, in *StreamEventRequest, opts ...grpc.CallOption) (Event_StreamEventClient, error)
+}
+
+type eventClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventClient(cc *grpc.ClientConn) EventClient {
+	return &eventClient{cc}
+}
+
+func (c *eventClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error) {
+	out := new(SubscribeResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/Subscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error) {
+	out := new(UnsubscribeResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/Unsubscribe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/ListSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) RegisterABI(ctx context.Context, in *RegisterABIRequest, opts ...grpc.CallOption) (*RegisterABIResponse, error) {
+	out := new(RegisterABIResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/RegisterABI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) PollEvent(ctx context.Context, in *PollEventRequest, opts ...grpc.CallOption) (*PollEventResponse, error) {
+	out := new(PollEventResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/PollEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) AckEvent(ctx context.Context, in *AckEventRequest, opts ...grpc.CallOption) (*AckEventResponse, error) {
+	out := new(AckEventResponse)
+	err := c.cc.Invoke(ctx, "/oasis.gateway.v0.Event/AckEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventClient) StreamEvent(ctx context.Context, in *StreamEventRequest, opts ...grpc.CallOption) (Event_StreamEventClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Event_serviceDesc.Streams[0], "/oasis.gateway.v0.Event/StreamEvent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventStreamEventClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Event_StreamEventClient interface {
+	Recv() (*SubscriptionEvent, error)
+	grpc.ClientStream
+}
+
+type eventStreamEventClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventStreamEventClient) Recv() (*SubscriptionEvent, error) {
+	m := new(SubscriptionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventServer is the server API for Event service.
+type EventServer interface {
+	// Create a subscription.
+	Subscribe(context.Context, *SubscribeRequest) (*SubscribeResponse, error)
+	// Destroy a subscription.
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error)
+	// List the active subscriptions of the session.
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// Register the ABI used to decode the logs of a service.
+	RegisterABI(context.Context, *RegisterABIRequest) (*RegisterABIResponse, error)
+	// Poll the events of a subscription.
+	PollEvent(context.Context, *PollEventRequest) (*PollEventResponse, error)
+	// Acknowledge the events of a subscription.
+	AckEvent(context.Context, *AckEventRequest) (*AckEventResponse, error)
+	// Stream the events of a subscription.
+	StreamEvent(*StreamEventRequest, Event_StreamEventServer) error
+}
+
+// UnimplementedEventServer can be embedded to have forward compatible implementations.
+type UnimplementedEventServer struct {
+}
+
+func (*UnimplementedEventServer) Subscribe(ctx context.Context, req *SubscribeRequest) (*SubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedEventServer) Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (*UnsubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (*UnimplementedEventServer) ListSubscriptions(ctx context.Context, req *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (*UnimplementedEventServer) RegisterABI(ctx context.Context, req *RegisterABIRequest) (*RegisterABIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterABI not implemented")
+}
+func (*UnimplementedEventServer) PollEvent(ctx context.Context, req *PollEventRequest) (*PollEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PollEvent not implemented")
+}
+func (*UnimplementedEventServer) AckEvent(ctx context.Context, req *AckEventRequest) (*AckEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckEvent not implemented")
+}
+func (*UnimplementedEventServer) StreamEvent(req *StreamEventRequest, srv Event_StreamEventServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvent not implemented")
+}
+
+func RegisterEventServer(s *grpc.Server, srv EventServer) {
+	s.RegisterService(&_Event_serviceDesc, srv)
+}
+
+func _Event_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/Subscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).Subscribe(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/ListSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_RegisterABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterABIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).RegisterABI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/RegisterABI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).RegisterABI(ctx, req.(*RegisterABIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_PollEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).PollEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/PollEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).PollEvent(ctx, req.(*PollEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_AckEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServer).AckEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oasis.gateway.v0.Event/AckEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServer).AckEvent(ctx, req.(*AckEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Event_StreamEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServer).StreamEvent(m, &eventStreamEventServer{stream})
+}
+
+type Event_StreamEventServer interface {
+	Send(*SubscriptionEvent) error
+	grpc.ServerStream
+}
+
+type eventStreamEventServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventStreamEventServer) Send(m *SubscriptionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Event_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "oasis.gateway.v0.Event",
+	HandlerType: (*EventServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Subscribe",
+			Handler:    _Event_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Event_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Event_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "RegisterABI",
+			Handler:    _Event_RegisterABI_Handler,
+		},
+		{
+			MethodName: "PollEvent",
+			Handler:    _Event_PollEvent_Handler,
+		},
+		{
+			MethodName: "AckEvent",
+			Handler:    _Event_AckEvent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvent",
+			Handler:       _Event_StreamEvent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/grpc/gateway.proto",
+}
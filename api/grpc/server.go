@@ -0,0 +1,275 @@
+package grpc
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	auth "github.com/oasislabs/oasis-gateway/auth/core"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ServerProps are the properties used to create a gRPC server
+// that serves the public API
+type ServerProps struct {
+	Auth    auth.Auth
+	Logger  log.Logger
+	Service service.Services
+	Event   event.Services
+
+	// MaxMsgBytes is the maximum size of a message received by
+	// the server. If it is not set the gRPC default is used
+	MaxMsgBytes uint
+
+	// Limiter if set limits the rate at which the calls are served.
+	// Each call counts as one operation, except for batches which
+	// count as many operations as requests they carry
+	Limiter rpc.OperationLimiter
+
+	// Options are additional options for the server, such as
+	// its transport credentials
+	Options []grpc.ServerOption
+}
+
+// operationRoutes maps the methods of the services to the routes of the
+// HTTP API that expose the same operations, so that a call is limited in
+// the same bucket as the requests sent to the route over any transport
+var operationRoutes = map[string]string{
+	"/oasis.gateway.v0.Service/DeployService":       "/service/deploy",
+	"/oasis.gateway.v0.Service/ExecuteService":      "/service/execute",
+	"/oasis.gateway.v0.Service/ExecuteServiceBatch": "/service/executeBatch",
+	"/oasis.gateway.v0.Service/CallService":         "/service/call",
+	"/oasis.gateway.v0.Service/EstimateGas":         "/service/estimateGas",
+	"/oasis.gateway.v0.Service/PollService":         "/service/poll",
+	"/oasis.gateway.v0.Service/GetServiceStatus":    "/service/status",
+	"/oasis.gateway.v0.Service/CancelService":       "/service/cancel",
+	"/oasis.gateway.v0.Service/AckService":          "/service/ack",
+	"/oasis.gateway.v0.Service/StreamService":       "/service/stream",
+	"/oasis.gateway.v0.Service/GetCode":             "/service/getCode",
+	"/oasis.gateway.v0.Service/GetPublicKey":        "/service/getPublicKey",
+	"/oasis.gateway.v0.Event/Subscribe":             "/event/subscribe",
+	"/oasis.gateway.v0.Event/Unsubscribe":           "/event/unsubscribe",
+	"/oasis.gateway.v0.Event/ListSubscriptions":     "/event/list",
+	"/oasis.gateway.v0.Event/RegisterABI":           "/event/registerAbi",
+	"/oasis.gateway.v0.Event/PollEvent":             "/event/poll",
+	"/oasis.gateway.v0.Event/AckEvent":              "/event/ack",
+	"/oasis.gateway.v0.Event/StreamEvent":           "/event/stream",
+}
+
+// operationRoute returns the route under which the calls to the
+// method are limited, which is the method itself if the method does
+// not have a counterpart in the HTTP API
+func operationRoute(method string) string {
+	if route, ok := operationRoutes[method]; ok {
+		return route
+	}
+
+	return method
+}
+
+// interceptor authenticates the calls and sets up their context in
+// the same way the HTTP router does for HTTP requests
+type interceptor struct {
+	auth    *auth.GrpcAuth
+	logger  log.Logger
+	limiter rpc.OperationLimiter
+}
+
+// NewServer creates a gRPC server with the Service and Event services
+// registered. The calls are authenticated with the provided Auth, which
+// gets the metadata of the call instead of the headers of an HTTP request
+func NewServer(props ServerProps) *grpc.Server {
+	if props.Auth == nil {
+		panic("auth must be set")
+	}
+
+	if props.Logger == nil {
+		panic("log must be set")
+	}
+
+	i := &interceptor{
+		auth:    auth.NewGrpcAuth(props.Auth, props.Logger),
+		logger:  props.Logger.ForClass("grpc", "Server"),
+		limiter: props.Limiter,
+	}
+
+	options := append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(i.unary),
+		grpc.StreamInterceptor(i.stream),
+	}, props.Options...)
+	if props.MaxMsgBytes > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(int(props.MaxMsgBytes)))
+	}
+
+	s := grpc.NewServer(options...)
+	RegisterServiceServer(s, NewServiceHandler(props.Service))
+	RegisterEventServer(s, NewEventHandler(props.Event))
+
+	return s
+}
+
+// metadataValue returns the first value of the metadata key, or an
+// empty string if it is not set. Keys are case insensitive, so HTTP
+// header names can be used
+func metadataValue(md metadata.MD, key string) string {
+	values := md.Get(strings.ToLower(key))
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// context authenticates the call and sets the trace ID and the
+// idempotency key provided by the client in its context
+func (i *interceptor) context(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	traceID := rpc.ParseTraceID(metadataValue(md, rpc.HttpHeaderTraceID))
+	ctx = context.WithValue(ctx, log.ContextKeyTraceID, traceID)
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ctx = context.WithValue(ctx, rpc.RemoteAddr{}, p.Addr.String())
+	}
+
+	if key := metadataValue(md, rpc.HttpHeaderIdempotencyKey); len(key) > 0 {
+		ctx = context.WithValue(ctx, rpc.IdempotencyKey{}, key)
+	}
+
+	ctx, err := i.auth.Authenticate(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return ctx, nil
+}
+
+// allow checks with the limiter whether the operations of the call can be
+// served. If the limit is exceeded, the time after which the client can
+// retry is sent in the metadata of the call as it would be in the headers
+// of an HTTP response
+func (i *interceptor) allow(ctx context.Context, method string, req interface{}) error {
+	if i.limiter == nil {
+		return nil
+	}
+
+	operations := rpc.CountOperations(req)
+	if operations <= 0 {
+		return nil
+	}
+
+	err := i.limiter.Allow(ctx, operationRoute(method), operations)
+	if err == nil {
+		return nil
+	}
+
+	if httpErr, ok := err.(*rpc.HttpError); ok && len(httpErr.Headers) > 0 {
+		md := metadata.MD{}
+		for key, values := range httpErr.Headers {
+			md.Append(strings.ToLower(key), values...)
+		}
+		_ = grpc.SetHeader(ctx, md)
+	}
+
+	return mapError(err)
+}
+
+// recover converts a panic in the handling of a call into an error so
+// that the server does not crash. As with the HTTP API, the cause of the
+// panic is not exposed to the client
+func (i *interceptor) recover(ctx context.Context, method string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	var cause error
+	switch x := r.(type) {
+	case string:
+		cause = stderr.New(x)
+	case error:
+		cause = x
+	default:
+		cause = fmt.Errorf("unknown panic %+v", r)
+	}
+
+	i.logger.Warn(ctx, "unexpected panic caught", log.MapFields{
+		"method":     method,
+		"call_type":  "GrpcCallHandleFailure",
+		"err":        cause,
+		"stacktrace": string(debug.Stack()),
+	})
+
+	*err = mapError(stderr.New("Unexpected error occurred."))
+}
+
+func (i *interceptor) unary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (res interface{}, err error) {
+	defer i.recover(ctx, info.FullMethod, &err)
+
+	ctx, err = i.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Debug(ctx, "", log.MapFields{
+		"method":    info.FullMethod,
+		"call_type": "GrpcCallHandleAttempt",
+	})
+
+	if err := i.allow(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (i *interceptor) stream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer i.recover(ss.Context(), info.FullMethod, &err)
+
+	ctx, err := i.context(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	i.logger.Debug(ctx, "", log.MapFields{
+		"method":    info.FullMethod,
+		"call_type": "GrpcCallHandleAttempt",
+	})
+
+	// the request of a stream is received by the handler, so
+	// a stream counts as a single operation
+	if err := i.allow(ctx, info.FullMethod, nil); err != nil {
+		return err
+	}
+
+	return handler(srv, serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// serverStream is a grpc.ServerStream with the context
+// set up by the interceptor
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context is the implementation of grpc.ServerStream for serverStream
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
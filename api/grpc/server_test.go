@@ -0,0 +1,325 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	auth "github.com/oasislabs/oasis-gateway/auth/core"
+	"github.com/oasislabs/oasis-gateway/auth/insecure"
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var Logger = log.NewLogrus(log.LogrusLoggerProperties{
+	Output: ioutil.Discard,
+})
+
+// MockServiceClient implements the methods of service.Client used
+// by the tests. Calls to other methods panic
+type MockServiceClient struct {
+	mock.Mock
+	service.Client
+}
+
+func (c *MockServiceClient) DeployServiceAsync(ctx context.Context, req backend.DeployServiceRequest) (uint64, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return 0, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(uint64), nil
+}
+
+func (c *MockServiceClient) PollService(ctx context.Context, req backend.PollServiceRequest) (backend.Events, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.Events{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.Events), nil
+}
+
+// MockEventClient implements the methods of event.Client used
+// by the tests. Calls to other methods panic
+type MockEventClient struct {
+	mock.Mock
+	event.Client
+}
+
+func (c *MockEventClient) AckEvent(ctx context.Context, req backend.AckEventRequest) errors.Err {
+	args := c.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+func (c *MockEventClient) StreamEvent(
+	ctx context.Context,
+	req backend.StreamEventRequest,
+	ch chan<- backend.Event,
+) errors.Err {
+	args := c.Called(ctx, req, ch)
+	for _, ev := range args.Get(0).([]backend.Event) {
+		ch <- ev
+	}
+
+	return nil
+}
+
+// MockLimiter records the operations it is asked
+// to allow and fails with err if it is set
+type MockLimiter struct {
+	routes     []string
+	operations []int
+	err        error
+}
+
+func (l *MockLimiter) Allow(ctx context.Context, route string, operations int) error {
+	l.routes = append(l.routes, route)
+	l.operations = append(l.operations, operations)
+	return l.err
+}
+
+type Clients struct {
+	service  ServiceClient
+	event    EventClient
+	mservice *MockServiceClient
+	mevent   *MockEventClient
+}
+
+func serve(t *testing.T) (*Clients, func()) {
+	return serveWithLimiter(t, nil)
+}
+
+func serveWithLimiter(t *testing.T, limiter rpc.OperationLimiter) (*Clients, func()) {
+	mservice := &MockServiceClient{}
+	mevent := &MockEventClient{}
+	s := NewServer(ServerProps{
+		Auth:    insecure.InsecureAuth{},
+		Logger:  Logger,
+		Service: service.Services{Logger: Logger, Client: mservice, Verifier: insecure.InsecureAuth{}},
+		Event:   event.Services{Logger: Logger, Client: mevent},
+		Limiter: limiter,
+	})
+
+	lis := bufconn.Listen(1 << 16)
+	go func() { _ = s.Serve(lis) }()
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) { return lis.Dial() }))
+	assert.Nil(t, err)
+
+	return &Clients{
+		service:  NewServiceClient(conn),
+		event:    NewEventClient(conn),
+		mservice: mservice,
+		mevent:   mevent,
+	}, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func authenticated(pairs ...string) context.Context {
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs(
+		append([]string{insecure.HeaderKey, "aad", auth.RequestHeaderSessionKey, "session"}, pairs...)...))
+}
+
+func sessionKey(t *testing.T) string {
+	session, err := auth.SessionKey("aad", "session")
+	assert.Nil(t, err)
+	return session
+}
+
+func errorDetail(t *testing.T, err error) *Error {
+	details := status.Convert(err).Details()
+	assert.Equal(t, 1, len(details))
+	return details[0].(*Error)
+}
+
+func TestDeployServiceOK(t *testing.T) {
+	clients, stop := serve(t)
+	defer stop()
+
+	clients.mservice.On("DeployServiceAsync", mock.Anything, mock.Anything).Return(uint64(1), nil)
+
+	res, err := clients.service.DeployService(
+		authenticated(rpc.HttpHeaderIdempotencyKey, "key"), &DeployServiceRequest{Data: "0x0000"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), res.Id)
+	assert.Nil(t, res.Event)
+	clients.mservice.AssertCalled(t, "DeployServiceAsync", mock.Anything, backend.DeployServiceRequest{
+		AAD:            "aad",
+		Data:           "0x0000",
+		SessionKey:     sessionKey(t),
+		IdempotencyKey: "key",
+	})
+}
+
+func TestDeployServiceErrNoSessionKey(t *testing.T) {
+	clients, stop := serve(t)
+	defer stop()
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(insecure.HeaderKey, "aad"))
+	_, err := clients.service.DeployService(ctx, &DeployServiceRequest{Data: "0x0000"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, &Error{
+		ErrorCode:   int32(errors.ErrAuthenticateRequest.Code()),
+		Description: errors.ErrAuthenticateRequest.Desc(),
+	}, errorDetail(t, err))
+	clients.mservice.AssertNotCalled(t, "DeployServiceAsync", mock.Anything, mock.Anything)
+}
+
+func TestDeployServiceRateLimited(t *testing.T) {
+	httpErr := rpc.HttpTooManyRequests(context.Background(), errors.New(errors.ErrRateLimitExceeded, nil))
+	httpErr.Headers = http.Header{"Retry-After": {"2"}}
+	limiter := &MockLimiter{err: httpErr}
+	clients, stop := serveWithLimiter(t, limiter)
+	defer stop()
+
+	var md metadata.MD
+	_, err := clients.service.DeployService(authenticated(),
+		&DeployServiceRequest{Data: "0x0000"}, grpc.Header(&md))
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, int32(errors.ErrRateLimitExceeded.Code()), errorDetail(t, err).ErrorCode)
+	assert.Equal(t, []string{"2"}, md.Get("retry-after"))
+	assert.Equal(t, []string{"/service/deploy"}, limiter.routes)
+	clients.mservice.AssertNotCalled(t, "DeployServiceAsync", mock.Anything, mock.Anything)
+}
+
+func TestExecuteServiceBatchRateLimitedPerRequest(t *testing.T) {
+	limiter := &MockLimiter{err: errors.New(errors.ErrRateLimitExceeded, nil)}
+	clients, stop := serveWithLimiter(t, limiter)
+	defer stop()
+
+	_, err := clients.service.ExecuteServiceBatch(authenticated(), &ExecuteServiceBatchRequest{
+		Requests: []*ExecuteServiceBatchItem{
+			{Data: "0x00", Address: "0x01"},
+			{Data: "0x00", Address: "0x02"},
+		},
+	})
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []int{2}, limiter.operations)
+}
+
+func TestPollServiceFromCursor(t *testing.T) {
+	clients, stop := serve(t)
+	defer stop()
+
+	clients.mservice.On("PollService", mock.Anything, mock.Anything).Return(backend.Events{
+		Offset: 3,
+		Events: []backend.Event{backend.ErrorEvent{
+			ID: 2,
+			Cause: rpc.Error{
+				ErrorCode:   errors.ErrRequestCancelled.Code(),
+				Description: errors.ErrRequestCancelled.Desc(),
+			},
+		}},
+	}, nil)
+
+	res, err := clients.service.PollService(authenticated(), &PollServiceRequest{Count: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), res.Offset)
+	assert.Equal(t, []*ServiceEvent{{Event: &ServiceEvent_Error{Error: &ErrorEvent{
+		Id: 2,
+		Cause: &Error{
+			ErrorCode:   int32(errors.ErrRequestCancelled.Code()),
+			Description: errors.ErrRequestCancelled.Desc(),
+		},
+	}}}}, res.Events)
+
+	_, err = clients.service.PollService(authenticated(), &PollServiceRequest{
+		Position: &PollServiceRequest_Offset{Offset: 0},
+		Count:    1,
+	})
+	assert.Nil(t, err)
+
+	clients.mservice.AssertCalled(t, "PollService", mock.Anything, backend.PollServiceRequest{
+		Count:      1,
+		FromCursor: true,
+		SessionKey: sessionKey(t),
+	})
+	clients.mservice.AssertCalled(t, "PollService", mock.Anything, backend.PollServiceRequest{
+		Count:      1,
+		SessionKey: sessionKey(t),
+	})
+}
+
+func TestAckEventErrSubscriptionNotFound(t *testing.T) {
+	clients, stop := serve(t)
+	defer stop()
+
+	clients.mevent.On("AckEvent", mock.Anything, mock.Anything).
+		Return(errors.New(errors.ErrSubscriptionNotFound, nil))
+
+	_, err := clients.event.AckEvent(authenticated(), &AckEventRequest{Id: 1, EventId: 2})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(errors.ErrSubscriptionNotFound.Code()), errorDetail(t, err).ErrorCode)
+	clients.mevent.AssertCalled(t, "AckEvent", mock.Anything, backend.AckEventRequest{
+		ID:         1,
+		EventID:    2,
+		SessionKey: sessionKey(t),
+	})
+}
+
+func TestStreamEventOK(t *testing.T) {
+	clients, stop := serve(t)
+	defer stop()
+
+	clients.mevent.On("StreamEvent", mock.Anything, mock.Anything, mock.Anything).
+		Return([]backend.Event{
+			backend.DataEvent{
+				ID:      0,
+				Address: "0x0000000000000000000000000000000000000001",
+				Data:    "0x01",
+				Topics:  []string{"0x02"},
+				Event:   "Transfer",
+				Args:    map[string]interface{}{"value": "1"},
+			},
+			backend.BlockEvent{ID: 1, Number: 2, Hash: "0x03"},
+		})
+
+	stream, err := clients.event.StreamEvent(authenticated(), &StreamEventRequest{Id: 1})
+	assert.Nil(t, err)
+
+	ev, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, &DataEvent{
+		Id:      0,
+		Address: "0x0000000000000000000000000000000000000001",
+		Data:    "0x01",
+		Topics:  []string{"0x02"},
+		Event:   "Transfer",
+		Args:    `{"value":"1"}`,
+	}, ev.GetData())
+
+	ev, err = stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, &BlockEvent{Id: 1, Number: 2, Hash: "0x03"}, ev.GetBlock())
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,294 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// ServiceHandler implements ServiceServer on top of the handler of
+// the HTTP API, so that both APIs validate and serve the requests in
+// the same way
+type ServiceHandler struct {
+	handler service.ServiceHandler
+}
+
+// NewServiceHandler creates a new ServiceHandler that serves the
+// requests with the provided services
+func NewServiceHandler(services service.Services) *ServiceHandler {
+	return &ServiceHandler{handler: service.NewServiceHandler(services)}
+}
+
+// DeployService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) DeployService(ctx context.Context, req *DeployServiceRequest) (*DeployServiceResponse, error) {
+	v, err := h.handler.DeployService(ctx, &service.DeployServiceRequest{
+		Data:      req.Data,
+		Wait:      req.Wait,
+		TimeoutMs: req.TimeoutMs,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	id, event := mapAsyncResponse(v)
+	return &DeployServiceResponse{Id: id, Event: event}, nil
+}
+
+// ExecuteService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) ExecuteService(ctx context.Context, req *ExecuteServiceRequest) (*ExecuteServiceResponse, error) {
+	v, err := h.handler.ExecuteService(ctx, &service.ExecuteServiceRequest{
+		Data:      req.Data,
+		Address:   req.Address,
+		Wait:      req.Wait,
+		TimeoutMs: req.TimeoutMs,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	id, event := mapAsyncResponse(v)
+	return &ExecuteServiceResponse{Id: id, Event: event}, nil
+}
+
+// OperationCount is the implementation of rpc.OperationCounter for
+// ExecuteServiceBatchRequest, so that each of the executions of the
+// batch is rate limited as if it was sent on its own
+func (r *ExecuteServiceBatchRequest) OperationCount() int {
+	return len(r.Requests)
+}
+
+// ExecuteServiceBatch is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) ExecuteServiceBatch(
+	ctx context.Context,
+	req *ExecuteServiceBatchRequest,
+) (*ExecuteServiceBatchResponse, error) {
+	items := make([]service.ExecuteServiceBatchItem, 0, len(req.Requests))
+	for _, item := range req.Requests {
+		items = append(items, service.ExecuteServiceBatchItem{
+			Data:    item.Data,
+			Address: item.Address,
+		})
+	}
+
+	v, err := h.handler.ExecuteServiceBatch(ctx, &service.ExecuteServiceBatchRequest{Requests: items})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.ExecuteServiceBatchResponse)
+	responses := make([]*ExecuteServiceBatchItemResponse, 0, len(res.Responses))
+	for _, r := range res.Responses {
+		var cause *Error
+		if r.Cause != nil {
+			cause = mapRpcError(*r.Cause)
+		}
+
+		responses = append(responses, &ExecuteServiceBatchItemResponse{Id: r.ID, Cause: cause})
+	}
+
+	return &ExecuteServiceBatchResponse{Responses: responses}, nil
+}
+
+// CallService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) CallService(ctx context.Context, req *CallServiceRequest) (*CallServiceResponse, error) {
+	v, err := h.handler.CallService(ctx, &service.CallServiceRequest{
+		Data:    req.Data,
+		Address: req.Address,
+		Block:   req.Block,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.CallServiceResponse)
+	return &CallServiceResponse{Address: res.Address, Output: res.Output}, nil
+}
+
+// EstimateGas is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) EstimateGas(ctx context.Context, req *EstimateGasRequest) (*EstimateGasResponse, error) {
+	v, err := h.handler.EstimateGas(ctx, &service.EstimateGasRequest{
+		Data:    req.Data,
+		Address: req.Address,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.EstimateGasResponse)
+	return &EstimateGasResponse{Gas: res.Gas, GasPrice: res.GasPrice, Fallback: res.Fallback}, nil
+}
+
+// PollService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) PollService(ctx context.Context, req *PollServiceRequest) (*PollServiceResponse, error) {
+	var offset *uint64
+	if position, ok := req.Position.(*PollServiceRequest_Offset); ok {
+		offset = &position.Offset
+	}
+
+	v, err := h.handler.PollService(ctx, &service.PollServiceRequest{
+		Offset:          offset,
+		Count:           uint(req.Count),
+		DiscardPrevious: req.DiscardPrevious,
+		WaitMs:          req.WaitMs,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.PollServiceResponse)
+	events := make([]*ServiceEvent, 0, len(res.Events))
+	for _, ev := range res.Events {
+		events = append(events, mapServiceEvent(ev))
+	}
+
+	return &PollServiceResponse{Offset: res.Offset, Events: events}, nil
+}
+
+// GetServiceStatus is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) GetServiceStatus(
+	ctx context.Context,
+	req *GetServiceStatusRequest,
+) (*GetServiceStatusResponse, error) {
+	v, err := h.handler.GetServiceStatus(ctx, &service.GetServiceStatusRequest{ID: req.Id})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.GetServiceStatusResponse)
+	var event *ServiceEvent
+	if res.Event != nil {
+		event = mapServiceEvent(res.Event)
+	}
+
+	return &GetServiceStatusResponse{Id: res.ID, Status: res.Status, Event: event}, nil
+}
+
+// CancelService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) CancelService(ctx context.Context, req *CancelServiceRequest) (*CancelServiceResponse, error) {
+	if _, err := h.handler.CancelService(ctx, &service.CancelServiceRequest{ID: req.Id}); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &CancelServiceResponse{}, nil
+}
+
+// AckService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) AckService(ctx context.Context, req *AckServiceRequest) (*AckServiceResponse, error) {
+	if _, err := h.handler.AckService(ctx, &service.AckServiceRequest{ID: req.Id}); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &AckServiceResponse{}, nil
+}
+
+// StreamService is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) StreamService(req *StreamServiceRequest, stream Service_StreamServiceServer) error {
+	v, err := h.handler.StreamService(stream.Context(), &service.StreamServiceRequest{Offset: req.Offset})
+	if err != nil {
+		return mapError(err)
+	}
+
+	return mapError(v.(rpc.Stream).Stream(stream.Context(), serviceStreamWriter{stream: stream}))
+}
+
+// GetCode is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) GetCode(ctx context.Context, req *GetCodeRequest) (*GetCodeResponse, error) {
+	v, err := h.handler.GetCode(ctx, &service.GetCodeRequest{Address: req.Address})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.GetCodeResponse)
+	return &GetCodeResponse{Address: res.Address, Code: res.Code}, nil
+}
+
+// GetPublicKey is the implementation of ServiceServer for ServiceHandler
+func (h *ServiceHandler) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	v, err := h.handler.GetPublicKey(ctx, &service.GetPublicKeyRequest{Address: req.Address})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	res := v.(service.GetPublicKeyResponse)
+	return &GetPublicKeyResponse{
+		Timestamp: res.Timestamp,
+		Address:   res.Address,
+		PublicKey: res.PublicKey,
+		Signature: res.Signature,
+	}, nil
+}
+
+// serviceStreamWriter is the rpc.StreamWriter that sends the events
+// of a StreamService call
+type serviceStreamWriter struct {
+	stream Service_StreamServiceServer
+}
+
+// Write is the implementation of rpc.StreamWriter for serviceStreamWriter
+func (w serviceStreamWriter) Write(ev rpc.Event) error {
+	return w.stream.Send(mapServiceEvent(ev.(service.Event)))
+}
+
+// mapAsyncResponse maps the response to a request that may have waited
+// for its response. The event is only set if the response was available
+func mapAsyncResponse(v interface{}) (uint64, *ServiceEvent) {
+	switch r := v.(type) {
+	case service.AsyncResponse:
+		return r.ID, nil
+	case service.Event:
+		return r.EventID(), mapServiceEvent(r)
+	default:
+		panic("received unexpected response type from service handler")
+	}
+}
+
+func mapServiceEvent(ev service.Event) *ServiceEvent {
+	switch r := ev.(type) {
+	case service.ExecuteServiceEvent:
+		return &ServiceEvent{Event: &ServiceEvent_Execute{Execute: &ExecuteServiceEvent{
+			Id:          r.ID,
+			Address:     r.Address,
+			Output:      r.Output,
+			Transaction: mapServiceTransaction(r.Transaction),
+		}}}
+	case service.DeployServiceEvent:
+		return &ServiceEvent{Event: &ServiceEvent_Deploy{Deploy: &DeployServiceEvent{
+			Id:          r.ID,
+			Address:     r.Address,
+			Transaction: mapServiceTransaction(r.Transaction),
+		}}}
+	case service.ErrorEvent:
+		return &ServiceEvent{Event: &ServiceEvent_Error{Error: &ErrorEvent{
+			Id:    r.ID,
+			Cause: mapRpcError(r.Cause),
+		}}}
+	default:
+		panic("received unexpected event type from service handler")
+	}
+}
+
+func mapServiceTransaction(tx *service.Transaction) *Transaction {
+	if tx == nil {
+		return nil
+	}
+
+	logs := make([]*Log, 0, len(tx.Logs))
+	for _, log := range tx.Logs {
+		logs = append(logs, &Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+			Index:   uint32(log.Index),
+		})
+	}
+
+	return &Transaction{
+		Hash:        tx.Hash,
+		BlockNumber: tx.BlockNumber,
+		BlockHash:   tx.BlockHash,
+		GasUsed:     tx.GasUsed,
+		From:        tx.From,
+		Logs:        logs,
+	}
+}
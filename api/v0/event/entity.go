@@ -1,6 +1,10 @@
 package event
 
-import "github.com/oasislabs/oasis-gateway/rpc"
+import (
+	"encoding/json"
+
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
 
 // AsyncResponse is the response returned by APIs that are asynchronous
 // that return an ID that can be used by the user to receive and identify
@@ -26,14 +30,74 @@ type SubscribeRequest struct {
 	Events []string `json:"events"`
 
 	// Filter is a url encoded list of query parameters that specify
-	// filters to be applied to the subscribed topic
+	// filters to be applied to the subscribed topic. The address
+	// parameter can be repeated to receive the events of any of the
+	// addresses. Each topic parameter is the set of comma separated
+	// topics that are accepted at its position, where an empty set
+	// accepts any topic
 	Filter string `json:"filter"`
+
+	// CallbackURL is optional. If set, the events of the subscription
+	// are also POSTed to the URL in order, and they are discarded from
+	// the subscription once they are delivered
+	CallbackURL string `json:"callbackUrl,omitempty"`
 }
 
 // SubscribeResponse returns an AsyncResponse which contains the ID
 // that can be used to poll for notifications on the subscription
 type SubscribeResponse AsyncResponse
 
+// ListSubscriptionsRequest is used by the user to find out the
+// subscriptions that are active for its session
+type ListSubscriptionsRequest struct{}
+
+// Subscription describes an active subscription
+type Subscription struct {
+	// ID is the id of the subscription returned in SubscribeResponse
+	ID uint64 `json:"id"`
+
+	// Event is the event type of the subscription
+	Event string `json:"event"`
+
+	// Addresses the subscription filters events by
+	Addresses []string `json:"addresses"`
+
+	// Topics is the list of topic sets the subscription filters
+	// events by
+	Topics [][]string `json:"topics"`
+
+	// CallbackURL is the URL the events of the subscription are
+	// delivered to, if any
+	CallbackURL string `json:"callbackUrl,omitempty"`
+
+	// CreatedAtMs is the time at which the subscription was created
+	// in milliseconds since the unix epoch
+	CreatedAtMs int64 `json:"createdAtMs"`
+
+	// Offset is the offset of the first event the subscription still
+	// keeps, from which the client can poll the subscription
+	Offset uint64 `json:"offset"`
+}
+
+// ListSubscriptionsResponse is the list of subscriptions that are
+// active for the session
+type ListSubscriptionsResponse struct {
+	// Subscriptions are the active subscriptions ordered by ID
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// RegisterABIRequest is used by the user to register the ABI of a
+// service so that the logs the service emits are decoded for the
+// subscriptions of its session
+type RegisterABIRequest struct {
+	// Address of the service
+	Address string `json:"address"`
+
+	// ABI is the JSON ABI of the service as generated by the
+	// compiler
+	ABI json.RawMessage `json:"abi"`
+}
+
 // PollEventRequest is a request that allows the user to
 // poll for events either from asynchronous requests or from
 // subscriptions
@@ -43,8 +107,10 @@ type PollEventRequest struct {
 
 	// Offset at which events need to be provided. Events are all ordered
 	// with sequence numbers and it is up to the client to specify which
-	// events it wants to receive from an offset in the sequence
-	Offset uint64 `json:"offset"`
+	// events it wants to receive from an offset in the sequence. If it
+	// is not set, the events are provided from the cursor committed with
+	// an AckEventRequest
+	Offset *uint64 `json:"offset,omitempty"`
 
 	// Count for the number of items the client would prefer to receive
 	// at most from a single response
@@ -53,6 +119,37 @@ type PollEventRequest struct {
 	// DiscardPrevious allows the client to define whether the server should
 	// discard all the events that have a sequence number lower than the offer
 	DiscardPrevious bool `json:"discardPrevious"`
+
+	// WaitMs is the maximum amount of time in milliseconds the request
+	// waits for events to be available when there are none at or after
+	// Offset. If not set the request returns immediately
+	WaitMs uint64 `json:"waitMs"`
+}
+
+// AckEventRequest is used by the user to acknowledge the events of a
+// subscription that it has already processed
+type AckEventRequest struct {
+	// ID is the id of the subscription returned in SubscribeResponse
+	ID uint64 `json:"id"`
+
+	// EventID is the ID of the last event the client has processed. The
+	// events with this ID or a lower one are discarded, and polling
+	// without an offset continues after it
+	EventID uint64 `json:"eventId"`
+}
+
+// StreamEventRequest is a request that allows the user to receive
+// the events of a subscription as they become available
+type StreamEventRequest struct {
+	// ID is the id of the subscription returned in SubscribeResponse
+	ID uint64 `json:"id"`
+
+	// Offset at which events need to be provided. Events are all ordered
+	// with sequence numbers and it is up to the client to specify from
+	// which offset in the sequence it wants to receive events. If the
+	// client sets the Last-Event-ID header to resume a stream, the
+	// stream continues after the last event received
+	Offset uint64 `json:"offset"`
 }
 
 // PollEventResponse is the list of events that are returned for
@@ -82,11 +179,102 @@ type DataEvent struct {
 	// ID to identify the event itself within the sequence of events.
 	ID uint64 `json:"id"`
 
+	// Address of the service that emitted the event
+	Address string `json:"address"`
+
 	// Data is the blob of data related to this event
-	Data string `json:"data"`
+	Data string `json:"data" bytes:"hex"`
 
 	// Topics is the list of topics to which the event refers
 	Topics []string `json:"topics"`
+
+	// Event is the name of the event that emitted the log. It is only
+	// set if the ABI of the service has been registered
+	Event string `json:"event,omitempty"`
+
+	// Args are the indexed and non-indexed arguments of the event by
+	// name, or by position for unnamed arguments. Integers are encoded
+	// as decimal strings and addresses and bytes as hex strings. Indexed
+	// arguments of dynamic types hold the hash of the value. They are
+	// only set if the ABI of the service has been registered
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// BlockEvent is the event that can be polled by the user from a
+// subscription to new blocks
+type BlockEvent struct {
+	// ID to identify the event itself within the sequence of events.
+	ID uint64 `json:"id"`
+
+	// Number of the block
+	Number uint64 `json:"number"`
+
+	// Hash of the block
+	Hash string `json:"hash"`
+
+	// ParentHash is the hash of the block's parent
+	ParentHash string `json:"parentHash"`
+
+	// Timestamp at which the block was created in seconds since
+	// the unix epoch
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// ReceiptEvent is the event that can be polled by the user from a
+// subscription to receipts once a transaction sent to one of the
+// subscribed addresses is included in a block
+type ReceiptEvent struct {
+	// ID to identify the event itself within the sequence of events.
+	ID uint64 `json:"id"`
+
+	// Address of the service the transaction was sent to
+	Address string `json:"address"`
+
+	// Status of the transaction, which is 1 if the transaction
+	// succeeded and 0 otherwise
+	Status uint64 `json:"status"`
+
+	// Transaction is the metadata of the transaction
+	Transaction Transaction `json:"transaction"`
+}
+
+// Transaction is the metadata of a transaction that has been
+// included in a block
+type Transaction struct {
+	// Hash of the transaction
+	Hash string `json:"hash"`
+
+	// BlockNumber is the number of the block in which the
+	// transaction was included
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// BlockHash is the hash of the block in which the
+	// transaction was included
+	BlockHash string `json:"blockHash"`
+
+	// GasUsed is the amount of gas used by the transaction
+	GasUsed uint64 `json:"gasUsed"`
+
+	// From is the address of the wallet that paid for the transaction
+	From string `json:"from"`
+
+	// Logs are the logs generated by the transaction
+	Logs []Log `json:"logs"`
+}
+
+// Log is a log generated by the execution of a transaction
+type Log struct {
+	// Address of the service that generated the log
+	Address string `json:"address"`
+
+	// Topics of the log
+	Topics []string `json:"topics"`
+
+	// Data of the log
+	Data string `json:"data" bytes:"hex"`
+
+	// Index of the log within the block
+	Index uint `json:"index"`
 }
 
 // ErrorEvent is the event that can be polled by the user
@@ -105,6 +293,16 @@ func (e DataEvent) EventID() uint64 {
 	return e.ID
 }
 
+// EventID is the implementation of Event for BlockEvent
+func (e BlockEvent) EventID() uint64 {
+	return e.ID
+}
+
+// EventID is the implementation of Event for ReceiptEvent
+func (e ReceiptEvent) EventID() uint64 {
+	return e.ID
+}
+
 // EventID is the implementation of Event for ErrorEvent
 func (e ErrorEvent) EventID() uint64 {
 	return e.ID
@@ -13,3 +13,11 @@ func TestDataEventEventID(t *testing.T) {
 func TestErrorEventEventID(t *testing.T) {
 	assert.Equal(t, uint64(1), ErrorEvent{ID: 1}.EventID())
 }
+
+func TestBlockEventEventID(t *testing.T) {
+	assert.Equal(t, uint64(1), BlockEvent{ID: 1}.EventID())
+}
+
+func TestReceiptEventEventID(t *testing.T) {
+	assert.Equal(t, uint64(1), ReceiptEvent{ID: 1}.EventID())
+}
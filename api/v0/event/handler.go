@@ -4,6 +4,8 @@ import (
 	"context"
 	stderr "errors"
 	"net/url"
+	"strings"
+	"time"
 
 	auth "github.com/oasislabs/oasis-gateway/auth/core"
 	backend "github.com/oasislabs/oasis-gateway/backend/core"
@@ -12,12 +14,20 @@ import (
 	"github.com/oasislabs/oasis-gateway/rpc"
 )
 
+// maxPollWait is the maximum amount of time a poll request
+// can wait for events to be available
+const maxPollWait = 30 * time.Second
+
 // Client interface for the underlying operations needed for the API
 // implementation
 type Client interface {
 	Subscribe(context.Context, backend.SubscribeRequest) (uint64, errors.Err)
 	Unsubscribe(context.Context, backend.UnsubscribeRequest) errors.Err
+	ListSubscriptions(context.Context, backend.ListSubscriptionsRequest) (backend.ListSubscriptionsResponse, errors.Err)
+	RegisterABI(context.Context, backend.RegisterABIRequest) errors.Err
 	PollEvent(context.Context, backend.PollEventRequest) (backend.Events, errors.Err)
+	AckEvent(context.Context, backend.AckEventRequest) errors.Err
+	StreamEvent(context.Context, backend.StreamEventRequest, chan<- backend.Event) errors.Err
 }
 
 type Services struct {
@@ -46,8 +56,10 @@ func (h EventHandler) Subscribe(ctx context.Context, v interface{}) (interface{}
 		return nil, err
 	}
 
-	if len(req.Events) > 1 {
-		err := errors.New(errors.ErrEmptyInput, stderr.New("only one event supported"))
+	// the same event can be provided more than once, but all the
+	// events of the subscription need to be of the same type
+	if !sameEvents(req.Events) {
+		err := errors.New(errors.ErrMultipleEventTypes, nil)
 		h.logger.Debug(ctx, "failed to handle request", log.MapFields{
 			"call_type": "SubscribeFailure",
 		}, err)
@@ -63,20 +75,25 @@ func (h EventHandler) Subscribe(ctx context.Context, v interface{}) (interface{}
 		return nil, err
 	}
 
-	address := query.Get("address")
-	if len(address) == 0 {
-		err := errors.New(errors.ErrParseQueryParams, nil)
-		h.logger.Debug(ctx, "request does not contain address", log.MapFields{
-			"call_type": "SubscribeFailure",
-		}, err)
-		return nil, err
+	// whether addresses are required depends on the event type,
+	// so it is up to the backend to verify them
+	addresses := query["address"]
+	for _, address := range addresses {
+		if len(address) == 0 {
+			err := errors.New(errors.ErrParseQueryParams, stderr.New("address cannot be empty"))
+			h.logger.Debug(ctx, "request contains empty address", log.MapFields{
+				"call_type": "SubscribeFailure",
+			}, err)
+			return nil, err
+		}
 	}
 
 	id, err := h.client.Subscribe(ctx, backend.SubscribeRequest{
-		Event:      req.Events[0],
-		Address:    address,
-		SessionKey: session,
-		Topics:     query["topic"],
+		Event:       req.Events[0],
+		Addresses:   addresses,
+		SessionKey:  session,
+		Topics:      parseTopics(query["topic"]),
+		CallbackURL: req.CallbackURL,
 	})
 	if err != nil {
 		h.logger.Debug(ctx, "failed to subscribe", log.MapFields{
@@ -90,6 +107,40 @@ func (h EventHandler) Subscribe(ctx context.Context, v interface{}) (interface{}
 	}, nil
 }
 
+// sameEvents returns true if all the events are of the same type
+func sameEvents(events []string) bool {
+	for _, event := range events {
+		if event != events[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseTopics parses the topic query parameters into the sets of topics
+// accepted at each position. Each parameter is a comma separated list of
+// topics, and an empty parameter accepts any topic at its position
+func parseTopics(params []string) [][]string {
+	if len(params) == 0 {
+		return nil
+	}
+
+	topics := make([][]string, 0, len(params))
+	for _, param := range params {
+		var set []string
+		for _, topic := range strings.Split(param, ",") {
+			if topic = strings.TrimSpace(topic); len(topic) > 0 {
+				set = append(set, topic)
+			}
+		}
+
+		topics = append(topics, set)
+	}
+
+	return topics
+}
+
 // Unsubscribe destroys an existing client subscription and all the
 // resources associated with it
 func (h EventHandler) Unsubscribe(ctx context.Context, v interface{}) (interface{}, error) {
@@ -111,6 +162,59 @@ func (h EventHandler) Unsubscribe(ctx context.Context, v interface{}) (interface
 	return nil, nil
 }
 
+// ListSubscriptions returns the subscriptions that are active for
+// the client's session
+func (h EventHandler) ListSubscriptions(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+
+	res, err := h.client.ListSubscriptions(ctx, backend.ListSubscriptionsRequest{
+		SessionKey: session,
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "failed to list subscriptions", log.MapFields{
+			"call_type": "ListSubscriptionsFailure",
+		}, err)
+		return nil, err
+	}
+
+	subscriptions := make([]Subscription, 0, len(res.Subscriptions))
+	for _, sub := range res.Subscriptions {
+		subscriptions = append(subscriptions, Subscription{
+			ID:          sub.ID,
+			Event:       sub.Event,
+			Addresses:   sub.Addresses,
+			Topics:      sub.Topics,
+			CallbackURL: sub.CallbackURL,
+			CreatedAtMs: sub.CreatedAt.UnixNano() / int64(time.Millisecond),
+			Offset:      sub.Offset,
+		})
+	}
+
+	return ListSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+// RegisterABI registers the ABI of a service so that the logs it emits
+// are decoded for the subscriptions of the client's session
+func (h EventHandler) RegisterABI(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*RegisterABIRequest)
+
+	err := h.client.RegisterABI(ctx, backend.RegisterABIRequest{
+		SessionKey: session,
+		Address:    req.Address,
+		ABI:        string(req.ABI),
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "failed to register abi", log.MapFields{
+			"call_type": "RegisterABIFailure",
+			"address":   req.Address,
+		}, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 // EventPoll allows the user to query for new events associated
 // with a specific subscription
 func (h EventHandler) PollEvent(ctx context.Context, v interface{}) (interface{}, error) {
@@ -120,10 +224,27 @@ func (h EventHandler) PollEvent(ctx context.Context, v interface{}) (interface{}
 		req.Count = 10
 	}
 
+	wait := time.Duration(req.WaitMs) * time.Millisecond
+	if wait > maxPollWait {
+		wait = maxPollWait
+	}
+	if wait > 0 {
+		// the wait may take longer than the write timeout of the server, which
+		// would otherwise close the connection before the response is written
+		rpc.ExtendWriteDeadline(ctx, wait)
+	}
+
+	var offset uint64
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+
 	res, err := h.client.PollEvent(ctx, backend.PollEventRequest{
 		DiscardPrevious: req.DiscardPrevious,
 		Count:           req.Count,
-		Offset:          req.Offset,
+		Offset:          offset,
+		FromCursor:      req.Offset == nil,
+		Wait:            wait,
 		ID:              req.ID,
 		SessionKey:      session,
 	})
@@ -137,21 +258,7 @@ func (h EventHandler) PollEvent(ctx context.Context, v interface{}) (interface{}
 
 	events := make([]Event, 0, len(res.Events))
 	for _, r := range res.Events {
-		switch r := r.(type) {
-		case backend.ErrorEvent:
-			events = append(events, ErrorEvent{
-				ID:    r.ID,
-				Cause: r.Cause,
-			})
-		case backend.DataEvent:
-			events = append(events, DataEvent{
-				ID:     r.ID,
-				Data:   r.Data,
-				Topics: r.Topics,
-			})
-		default:
-			panic("received unexpected event type from polling service")
-		}
+		events = append(events, mapEvent(r))
 	}
 
 	return PollEventResponse{
@@ -160,6 +267,153 @@ func (h EventHandler) PollEvent(ctx context.Context, v interface{}) (interface{}
 	}, nil
 }
 
+// AckEvent acknowledges the events of a subscription up to the
+// provided event ID
+func (h EventHandler) AckEvent(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*AckEventRequest)
+
+	err := h.client.AckEvent(ctx, backend.AckEventRequest{
+		ID:         req.ID,
+		EventID:    req.EventID,
+		SessionKey: session,
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "failed to acknowledge events", log.MapFields{
+			"call_type": "AckEventFailure",
+			"id":        req.ID,
+			"eventId":   req.EventID,
+		}, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// StreamEvent delivers the events of a subscription to the client as they
+// become available starting from the provided offset, or after the last
+// event received if the client resumes the stream. The stream remains
+// open until the client closes the connection or the subscription is
+// destroyed
+func (h EventHandler) StreamEvent(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*StreamEventRequest)
+
+	return rpc.StreamFunc(func(ctx context.Context, w rpc.StreamWriter) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c := make(chan backend.Event, 16)
+		errC := make(chan errors.Err, 1)
+		offset := rpc.ResumeStream(ctx, w, req.Offset)
+
+		go func() {
+			errC <- h.client.StreamEvent(ctx, backend.StreamEventRequest{
+				Offset:     offset,
+				ID:         req.ID,
+				SessionKey: session,
+			}, c)
+		}()
+
+		write := func(ev backend.Event) bool {
+			if err := w.Write(mapEvent(ev)); err != nil {
+				h.logger.Debug(ctx, "failed to write event to stream", log.MapFields{
+					"call_type": "StreamEventFailure",
+					"id":        req.ID,
+					"err":       err.Error(),
+				})
+				return false
+			}
+
+			return true
+		}
+
+		for {
+			select {
+			case ev := <-c:
+				if !write(ev) {
+					return nil
+				}
+			case err := <-errC:
+				// deliver the events that were sent before the stream
+				// terminated
+				for len(c) > 0 {
+					if !write(<-c) {
+						return nil
+					}
+				}
+
+				if err != nil {
+					h.logger.Debug(ctx, "stream terminated with error", log.MapFields{
+						"call_type": "StreamEventFailure",
+						"id":        req.ID,
+					}, err)
+					return err
+				}
+
+				return nil
+			}
+		}
+	}), nil
+}
+
+func mapEvent(event backend.Event) Event {
+	switch r := event.(type) {
+	case backend.ErrorEvent:
+		return ErrorEvent{
+			ID:    r.ID,
+			Cause: r.Cause,
+		}
+	case backend.DataEvent:
+		return DataEvent{
+			ID:      r.ID,
+			Address: r.Address,
+			Data:    r.Data,
+			Topics:  r.Topics,
+			Event:   r.Event,
+			Args:    r.Args,
+		}
+	case backend.BlockEvent:
+		return BlockEvent{
+			ID:         r.ID,
+			Number:     r.Number,
+			Hash:       r.Hash,
+			ParentHash: r.ParentHash,
+			Timestamp:  r.Timestamp,
+		}
+	case backend.ReceiptEvent:
+		return ReceiptEvent{
+			ID:          r.ID,
+			Address:     r.Address,
+			Status:      r.Status,
+			Transaction: mapTransaction(r.Transaction),
+		}
+	default:
+		panic("received unexpected event type from polling service")
+	}
+}
+
+func mapTransaction(tx backend.Transaction) Transaction {
+	logs := make([]Log, 0, len(tx.Logs))
+	for _, log := range tx.Logs {
+		logs = append(logs, Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+			Index:   log.Index,
+		})
+	}
+
+	return Transaction{
+		Hash:        tx.Hash,
+		BlockNumber: tx.BlockNumber,
+		BlockHash:   tx.BlockHash,
+		GasUsed:     tx.GasUsed,
+		From:        tx.From,
+		Logs:        logs,
+	}
+}
+
 func NewEventHandler(services Services) EventHandler {
 	if services.Client == nil {
 		panic("Request must be provided as a service")
@@ -174,15 +428,36 @@ func NewEventHandler(services Services) EventHandler {
 	}
 }
 
-// BindHandler binds the service handler to the provided
-// HandlerBinder
+// BindHandler binds the event handler to the provided
+// HandlerBinder under the v0 routes
 func BindHandler(services Services, binder rpc.HandlerBinder) {
+	BindRoutes(services, rpc.NewPrefixBinder("/v0/api", binder))
+}
+
+// BindRoutes binds the event handler routes to the provided
+// HandlerBinder relative to the prefix of a version of the API
+func BindRoutes(services Services, binder rpc.HandlerBinder) {
 	handler := NewEventHandler(services)
 
-	binder.Bind("POST", "/v0/api/event/subscribe", rpc.HandlerFunc(handler.Subscribe),
+	binder.Bind("POST", "/event/subscribe",
+		rpc.Describe(rpc.HandlerFunc(handler.Subscribe), SubscribeResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &SubscribeRequest{} }))
-	binder.Bind("POST", "/v0/api/event/unsubscribe", rpc.HandlerFunc(handler.Unsubscribe),
+	binder.Bind("POST", "/event/unsubscribe",
+		rpc.Describe(rpc.HandlerFunc(handler.Unsubscribe), nil),
 		rpc.EntityFactoryFunc(func() interface{} { return &UnsubscribeRequest{} }))
-	binder.Bind("POST", "/v0/api/event/poll", rpc.HandlerFunc(handler.PollEvent),
+	binder.Bind("POST", "/event/list",
+		rpc.Describe(rpc.HandlerFunc(handler.ListSubscriptions), ListSubscriptionsResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &ListSubscriptionsRequest{} }))
+	binder.Bind("POST", "/event/registerAbi",
+		rpc.Describe(rpc.HandlerFunc(handler.RegisterABI), nil),
+		rpc.EntityFactoryFunc(func() interface{} { return &RegisterABIRequest{} }))
+	binder.Bind("POST", "/event/poll",
+		rpc.Describe(rpc.HandlerFunc(handler.PollEvent), PollEventResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &PollEventRequest{} }))
+	binder.Bind("POST", "/event/ack",
+		rpc.Describe(rpc.HandlerFunc(handler.AckEvent), nil),
+		rpc.EntityFactoryFunc(func() interface{} { return &AckEventRequest{} }))
+	binder.Bind("GET", "/event/stream",
+		rpc.DescribeStream(rpc.HandlerFunc(handler.StreamEvent)),
+		rpc.EntityFactoryFunc(func() interface{} { return &StreamEventRequest{} }))
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	auth "github.com/oasislabs/oasis-gateway/auth/core"
 	backend "github.com/oasislabs/oasis-gateway/backend/core"
@@ -48,6 +49,30 @@ func (c *MockClient) Unsubscribe(
 	return nil
 }
 
+func (c *MockClient) ListSubscriptions(
+	ctx context.Context,
+	req backend.ListSubscriptionsRequest,
+) (backend.ListSubscriptionsResponse, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.ListSubscriptionsResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.ListSubscriptionsResponse), nil
+}
+
+func (c *MockClient) RegisterABI(
+	ctx context.Context,
+	req backend.RegisterABIRequest,
+) errors.Err {
+	args := c.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
 func (c *MockClient) PollEvent(
 	ctx context.Context,
 	req backend.PollEventRequest,
@@ -60,6 +85,40 @@ func (c *MockClient) PollEvent(
 	return args.Get(0).(backend.Events), nil
 }
 
+func (c *MockClient) AckEvent(
+	ctx context.Context,
+	req backend.AckEventRequest,
+) errors.Err {
+	args := c.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+func (c *MockClient) StreamEvent(
+	ctx context.Context,
+	req backend.StreamEventRequest,
+	ch chan<- backend.Event,
+) errors.Err {
+	args := c.Called(ctx, req, ch)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+type StreamWriterRecorder struct {
+	Events []rpc.Event
+}
+
+func (w *StreamWriterRecorder) Write(ev rpc.Event) error {
+	w.Events = append(w.Events, ev)
+	return nil
+}
+
 type InvalidEvent struct{}
 
 func (e InvalidEvent) EventID() uint64 {
@@ -102,7 +161,7 @@ func TestSubscribeErrTooManyEvents(t *testing.T) {
 		Filter: "",
 	})
 
-	assert.Equal(t, "[2007] error code InputError with desc Input cannot be empty. with cause only one event supported", err.Error())
+	assert.Equal(t, "[2023] error code InputError with desc A subscription can only be created for a single event type.", err.Error())
 }
 
 func TestSubscribeErrInvalidQueryParams(t *testing.T) {
@@ -158,9 +217,9 @@ func TestSubscribeOKWithTopics(t *testing.T) {
 	}, res)
 	handler.client.(*MockClient).AssertCalled(t, "Subscribe", ctx, backend.SubscribeRequest{
 		Event:      "event",
-		Address:    "myaddress",
+		Addresses:  []string{"myaddress"},
 		SessionKey: "sessionKey",
-		Topics:     []string{"topic1", "topic2"},
+		Topics:     [][]string{{"topic1"}, {"topic2"}},
 	})
 }
 
@@ -184,12 +243,100 @@ func TestSubscribeOKNoTopic(t *testing.T) {
 	}, res)
 	handler.client.(*MockClient).AssertCalled(t, "Subscribe", ctx, backend.SubscribeRequest{
 		Event:      "event",
-		Address:    "myaddress",
+		Addresses:  []string{"myaddress"},
 		SessionKey: "sessionKey",
 		Topics:     nil,
 	})
 }
 
+func TestSubscribeOKMultipleAddressesAndTopicSets(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("Subscribe", mock.Anything, mock.Anything).
+		Return(uint64(1), nil)
+
+	res, err := handler.Subscribe(ctx, &SubscribeRequest{
+		Events: []string{"event", "event"},
+		Filter: "address=address1&address=address2&topic=topic1,topic2&topic=&topic=topic3",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, SubscribeResponse{
+		ID: 1,
+	}, res)
+	handler.client.(*MockClient).AssertCalled(t, "Subscribe", ctx, backend.SubscribeRequest{
+		Event:      "event",
+		Addresses:  []string{"address1", "address2"},
+		SessionKey: "sessionKey",
+		Topics:     [][]string{{"topic1", "topic2"}, nil, {"topic3"}},
+	})
+}
+
+func TestSubscribeOKCallbackURL(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("Subscribe", mock.Anything, mock.Anything).
+		Return(uint64(1), nil)
+
+	_, err := handler.Subscribe(ctx, &SubscribeRequest{
+		Events:      []string{"logs"},
+		Filter:      "address=address",
+		CallbackURL: "http://localhost:1234/events",
+	})
+
+	assert.Nil(t, err)
+	handler.client.(*MockClient).AssertCalled(t, "Subscribe", ctx, backend.SubscribeRequest{
+		Event:       "logs",
+		Addresses:   []string{"address"},
+		SessionKey:  "sessionKey",
+		CallbackURL: "http://localhost:1234/events",
+	})
+}
+
+func TestSubscribeOKNoAddress(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("Subscribe", mock.Anything, mock.Anything).
+		Return(uint64(1), nil)
+
+	res, err := handler.Subscribe(ctx, &SubscribeRequest{
+		Events: []string{"newHeads"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, SubscribeResponse{
+		ID: 1,
+	}, res)
+	handler.client.(*MockClient).AssertCalled(t, "Subscribe", ctx, backend.SubscribeRequest{
+		Event:      "newHeads",
+		SessionKey: "sessionKey",
+	})
+}
+
+func TestSubscribeErrEmptyAddress(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	_, err := handler.Subscribe(ctx, &SubscribeRequest{
+		Events: []string{"event"},
+		Filter: "address=address1&address=",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrParseQueryParams, err.(errors.Err).ErrorCode())
+}
+
 func TestUnsubscribeOK(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -223,6 +370,106 @@ func TestUnsubscribeErrReturn(t *testing.T) {
 	assert.Equal(t, "[1000] error code InternalError with desc Internal Error. Please check the status of the service.", err.Error())
 }
 
+func TestListSubscriptionsOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("ListSubscriptions", mock.Anything, mock.Anything).
+		Return(backend.ListSubscriptionsResponse{Subscriptions: []backend.Subscription{{
+			ID:        1,
+			Event:     "logs",
+			Addresses: []string{"address"},
+			Topics:    [][]string{{"topic"}},
+			CreatedAt: time.Unix(1, 500000000),
+			Offset:    2,
+		}}}, nil)
+
+	res, err := handler.ListSubscriptions(ctx, &ListSubscriptionsRequest{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, ListSubscriptionsResponse{Subscriptions: []Subscription{{
+		ID:          1,
+		Event:       "logs",
+		Addresses:   []string{"address"},
+		Topics:      [][]string{{"topic"}},
+		CreatedAtMs: 1500,
+		Offset:      2,
+	}}}, res)
+	handler.client.(*MockClient).AssertCalled(t, "ListSubscriptions", ctx, backend.ListSubscriptionsRequest{
+		SessionKey: "sessionKey",
+	})
+}
+
+func TestListSubscriptionsOKEmpty(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("ListSubscriptions", mock.Anything, mock.Anything).
+		Return(backend.ListSubscriptionsResponse{}, nil)
+
+	res, err := handler.ListSubscriptions(ctx, &ListSubscriptionsRequest{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, ListSubscriptionsResponse{Subscriptions: []Subscription{}}, res)
+}
+
+func TestListSubscriptionsErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("ListSubscriptions", mock.Anything, mock.Anything).
+		Return(backend.ListSubscriptionsResponse{}, errors.New(errors.ErrInternalError, nil))
+
+	_, err := handler.ListSubscriptions(ctx, &ListSubscriptionsRequest{})
+
+	assert.Equal(t, "[1000] error code InternalError with desc Internal Error. Please check the status of the service.", err.Error())
+}
+
+func TestRegisterABIOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("RegisterABI", mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := handler.RegisterABI(ctx, &RegisterABIRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+		ABI:     []byte(`[]`),
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	handler.client.(*MockClient).AssertCalled(t, "RegisterABI", ctx, backend.RegisterABIRequest{
+		SessionKey: "sessionKey",
+		Address:    "0x0000000000000000000000000000000000000001",
+		ABI:        "[]",
+	})
+}
+
+func TestRegisterABIErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("RegisterABI", mock.Anything, mock.Anything).
+		Return(errors.New(errors.ErrInvalidABI, nil))
+
+	_, err := handler.RegisterABI(ctx, &RegisterABIRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+	})
+
+	assert.Equal(t, "[2017] error code InputError with desc Provided invalid contract ABI.", err.Error())
+}
+
 func TestPollEventOKEmpty(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -233,7 +480,38 @@ func TestPollEventOKEmpty(t *testing.T) {
 		Return(backend.Events{}, nil)
 
 	res, err := handler.PollEvent(ctx, &PollEventRequest{
+		Offset: offset(0),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, PollEventResponse{
 		Offset: 0,
+		Events: []Event{},
+	}, res)
+}
+
+func TestPollEventWaitMaxWait(t *testing.T) {
+	var waits []time.Duration
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	ctx = context.WithValue(ctx, rpc.WriteDeadline{}, func(wait time.Duration) {
+		waits = append(waits, wait)
+	})
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("PollEvent", mock.Anything, backend.PollEventRequest{
+		Count:      10,
+		Offset:     1,
+		Wait:       30 * time.Second,
+		ID:         2,
+		SessionKey: "sessionKey",
+	}).Return(backend.Events{}, nil)
+
+	res, err := handler.PollEvent(ctx, &PollEventRequest{
+		ID:     2,
+		Offset: offset(1),
+		WaitMs: 60000,
 	})
 
 	assert.Nil(t, err)
@@ -241,6 +519,7 @@ func TestPollEventOKEmpty(t *testing.T) {
 		Offset: 0,
 		Events: []Event{},
 	}, res)
+	assert.Equal(t, []time.Duration{maxPollWait}, waits)
 }
 
 func TestPollEventOKMultiple(t *testing.T) {
@@ -264,7 +543,7 @@ func TestPollEventOKMultiple(t *testing.T) {
 			}}, nil)
 
 	res, err := handler.PollEvent(ctx, &PollEventRequest{
-		Offset: 0,
+		Offset: offset(0),
 	})
 
 	assert.Nil(t, err)
@@ -281,6 +560,73 @@ func TestPollEventOKMultiple(t *testing.T) {
 		}}, res)
 }
 
+func TestPollEventOKBlockAndReceipt(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("PollEvent", mock.Anything, mock.Anything).
+		Return(backend.Events{
+			Offset: 0,
+			Events: []backend.Event{
+				backend.BlockEvent{
+					ID:         0,
+					Number:     1,
+					Hash:       "0x01",
+					ParentHash: "0x00",
+					Timestamp:  2,
+				},
+				backend.ReceiptEvent{
+					ID:      1,
+					Address: "0x02",
+					Status:  1,
+					Transaction: backend.Transaction{
+						Hash:        "0x03",
+						BlockNumber: 1,
+						BlockHash:   "0x01",
+						GasUsed:     21000,
+						From:        "0x04",
+						Logs: []backend.Log{
+							{Address: "0x02", Topics: []string{"0x05"}, Data: "0x06", Index: 0},
+						},
+					},
+				},
+			}}, nil)
+
+	res, err := handler.PollEvent(ctx, &PollEventRequest{
+		Offset: offset(0),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, PollEventResponse{
+		Offset: 0,
+		Events: []Event{
+			BlockEvent{
+				ID:         0,
+				Number:     1,
+				Hash:       "0x01",
+				ParentHash: "0x00",
+				Timestamp:  2,
+			},
+			ReceiptEvent{
+				ID:      1,
+				Address: "0x02",
+				Status:  1,
+				Transaction: Transaction{
+					Hash:        "0x03",
+					BlockNumber: 1,
+					BlockHash:   "0x01",
+					GasUsed:     21000,
+					From:        "0x04",
+					Logs: []Log{
+						{Address: "0x02", Topics: []string{"0x05"}, Data: "0x06", Index: 0},
+					},
+				},
+			},
+		}}, res)
+}
+
 func TestPollEventErrUnknown(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -294,7 +640,7 @@ func TestPollEventErrUnknown(t *testing.T) {
 
 	assert.Panics(t, func() {
 		_, _ = handler.PollEvent(ctx, &PollEventRequest{
-			Offset: 0,
+			Offset: offset(0),
 		})
 	})
 }
@@ -312,12 +658,111 @@ func TestPollEventErrReturn(t *testing.T) {
 		}, errors.New(errors.ErrInternalError, nil))
 
 	_, err := handler.PollEvent(ctx, &PollEventRequest{
-		Offset: 0,
+		Offset: offset(0),
 	})
 
 	assert.Error(t, err)
 }
 
+func TestPollEventFromCursor(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("PollEvent", mock.Anything, mock.Anything).
+		Return(backend.Events{Offset: 2}, nil)
+
+	_, err := handler.PollEvent(ctx, &PollEventRequest{ID: 1})
+
+	assert.Nil(t, err)
+	handler.client.(*MockClient).AssertCalled(t, "PollEvent", ctx, backend.PollEventRequest{
+		Count:      10,
+		FromCursor: true,
+		ID:         1,
+		SessionKey: "sessionKey",
+	})
+}
+
+func TestAckEventOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("AckEvent", mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := handler.AckEvent(ctx, &AckEventRequest{ID: 1, EventID: 2})
+
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	handler.client.(*MockClient).AssertCalled(t, "AckEvent", ctx, backend.AckEventRequest{
+		ID:         1,
+		EventID:    2,
+		SessionKey: "sessionKey",
+	})
+}
+
+func TestAckEventErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("AckEvent", mock.Anything, mock.Anything).
+		Return(errors.New(errors.ErrSubscriptionNotFound, nil))
+
+	_, err := handler.AckEvent(ctx, &AckEventRequest{ID: 1, EventID: 2})
+
+	assert.Equal(t, errors.ErrSubscriptionNotFound, err.(errors.Err).ErrorCode())
+}
+
+func TestStreamEventOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("StreamEvent", mock.Anything, backend.StreamEventRequest{
+		Offset:     1,
+		ID:         2,
+		SessionKey: "sessionKey",
+	}, mock.Anything).Run(func(args mock.Arguments) {
+		c := args.Get(2).(chan<- backend.Event)
+		c <- backend.DataEvent{ID: 1, Address: "address", Data: "data", Topics: []string{"topic"}}
+	}).Return(nil)
+
+	res, err := handler.StreamEvent(ctx, &StreamEventRequest{ID: 2, Offset: 1})
+	assert.Nil(t, err)
+
+	w := &StreamWriterRecorder{}
+	err = res.(rpc.Stream).Stream(ctx, w)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []rpc.Event{
+		DataEvent{ID: 1, Address: "address", Data: "data", Topics: []string{"topic"}},
+	}, w.Events)
+}
+
+func TestStreamEventErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createEventHandler()
+
+	handler.client.(*MockClient).On("StreamEvent", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New(errors.ErrSubscriptionNotFound, nil))
+
+	res, err := handler.StreamEvent(ctx, &StreamEventRequest{ID: 2})
+	assert.Nil(t, err)
+
+	err = res.(rpc.Stream).Stream(ctx, &StreamWriterRecorder{})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrSubscriptionNotFound, err.(errors.Err).ErrorCode())
+}
+
 func TestNewEventHandlerNoClient(t *testing.T) {
 	assert.Panics(t, func() {
 		NewEventHandler(Services{
@@ -368,5 +813,12 @@ func TestBindHandlerOK(t *testing.T) {
 
 	assert.True(t, router.HasHandler("/v0/api/event/subscribe", "POST"))
 	assert.True(t, router.HasHandler("/v0/api/event/unsubscribe", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/event/list", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/event/registerAbi", "POST"))
 	assert.True(t, router.HasHandler("/v0/api/event/poll", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/event/stream", "GET"))
+}
+
+func offset(v uint64) *uint64 {
+	return &v
 }
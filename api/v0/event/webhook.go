@@ -0,0 +1,50 @@
+package event
+
+import (
+	"context"
+
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+)
+
+// URLValidator validates the URLs that requests can be sent to
+type URLValidator interface {
+	ValidateURL(ctx context.Context, url string) error
+}
+
+// JSONPoster sends bodies encoded as JSON in POST requests
+type JSONPoster interface {
+	PostJSON(ctx context.Context, url string, body interface{}) (int, error)
+}
+
+// WebhookClient delivers the events of subscriptions to their
+// callback URLs in the same format in which they are polled
+type WebhookClient struct {
+	poster    JSONPoster
+	validator URLValidator
+}
+
+// NewWebhookClient creates a new WebhookClient that uses the provided
+// poster to send the requests to the URLs accepted by the validator
+func NewWebhookClient(poster JSONPoster, validator URLValidator) *WebhookClient {
+	if poster == nil {
+		panic("poster must be set")
+	}
+
+	if validator == nil {
+		panic("validator must be set")
+	}
+
+	return &WebhookClient{poster: poster, validator: validator}
+}
+
+// DeliverEvent is the implementation of backend.WebhookClient
+// for WebhookClient
+func (c *WebhookClient) DeliverEvent(ctx context.Context, url string, ev backend.Event) (int, error) {
+	return c.poster.PostJSON(ctx, url, mapEvent(ev))
+}
+
+// ValidateURL is the implementation of backend.WebhookClient
+// for WebhookClient
+func (c *WebhookClient) ValidateURL(ctx context.Context, url string) error {
+	return c.validator.ValidateURL(ctx, url)
+}
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"context"
+	stderr "errors"
+	"net/http"
+	"testing"
+
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJSONPoster struct {
+	mock.Mock
+}
+
+func (p *MockJSONPoster) PostJSON(ctx context.Context, url string, body interface{}) (int, error) {
+	args := p.Called(ctx, url, body)
+	return args.Int(0), args.Error(1)
+}
+
+type MockURLValidator struct {
+	mock.Mock
+}
+
+func (v *MockURLValidator) ValidateURL(ctx context.Context, url string) error {
+	args := v.Called(ctx, url)
+	return args.Error(0)
+}
+
+func TestNewWebhookClientNoValidator(t *testing.T) {
+	assert.Panics(t, func() {
+		NewWebhookClient(&MockJSONPoster{}, nil)
+	})
+}
+
+func TestWebhookClientValidateURL(t *testing.T) {
+	validator := &MockURLValidator{}
+	client := NewWebhookClient(&MockJSONPoster{}, validator)
+
+	validator.On("ValidateURL", mock.Anything, "http://10.0.0.1/events").
+		Return(stderr.New("host 10.0.0.1 resolves to non public address 10.0.0.1"))
+
+	assert.Error(t, client.ValidateURL(Context, "http://10.0.0.1/events"))
+}
+
+func TestWebhookClientDeliverEvent(t *testing.T) {
+	poster := &MockJSONPoster{}
+	client := NewWebhookClient(poster, &MockURLValidator{})
+
+	poster.On("PostJSON", mock.Anything, mock.Anything, mock.Anything).
+		Return(http.StatusOK, nil)
+
+	code, err := client.DeliverEvent(Context, "http://localhost:1234/events", backend.DataEvent{
+		ID:      1,
+		Address: "address",
+		Data:    "0x00",
+		Topics:  []string{"topic"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	poster.AssertCalled(t, "PostJSON", Context, "http://localhost:1234/events", DataEvent{
+		ID:      1,
+		Address: "address",
+		Data:    "0x00",
+		Topics:  []string{"topic"},
+	})
+}
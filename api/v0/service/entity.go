@@ -12,6 +12,13 @@ const (
 	Poll         RequestType = 2
 	GetCode      RequestType = 3
 	GetPublicKey RequestType = 4
+	Stream       RequestType = 5
+	ExecuteBatch RequestType = 6
+	GetStatus    RequestType = 7
+	Call         RequestType = 8
+	Cancel       RequestType = 9
+	EstimateGas  RequestType = 10
+	Ack          RequestType = 11
 )
 
 // Request is the type implemented by requests expected
@@ -41,6 +48,17 @@ type ExecuteServiceRequest struct {
 
 	// Address where the service can be found
 	Address string `json:"address"`
+
+	// Wait if set makes the request block until the response is
+	// available, in which case the event is returned inline instead of
+	// the ID of the asynchronous response. The event is still delivered
+	// through the polling mechanisms
+	Wait bool `json:"wait"`
+
+	// TimeoutMs is the maximum amount of time in milliseconds the request
+	// blocks when Wait is set. If the response is not available by then,
+	// the ID of the asynchronous response is returned instead
+	TimeoutMs uint64 `json:"timeoutMs"`
 }
 
 // Type implementation of Request for ExecuteServiceRequest
@@ -52,6 +70,117 @@ func (r ExecuteServiceRequest) Type() RequestType {
 // using the polling mechanisms
 type ExecuteServiceResponse AsyncResponse
 
+// CallServiceRequest is used by the user to call a service without
+// sending a transaction. The call does not modify the state of the
+// service and its output is returned synchronously
+type CallServiceRequest struct {
+	// Data is a blob of data that the user wants to pass to the service
+	// as argument
+	Data string `json:"data"`
+
+	// Address where the service can be found
+	Address string `json:"address"`
+
+	// Block at which the call is executed. It can be either "latest"
+	// or a block number. If not set the latest block is used
+	Block string `json:"block,omitempty"`
+}
+
+// Type implementation of Request for CallServiceRequest
+func (r CallServiceRequest) Type() RequestType {
+	return Call
+}
+
+// CallServiceResponse is the response to a CallServiceRequest
+type CallServiceResponse struct {
+	// Address is the unique address that identifies the service
+	Address string `json:"address"`
+
+	// Output generated by the service at the end of its execution
+	Output string `json:"output" bytes:"hex"`
+}
+
+// EstimateGasRequest is used by the user to estimate the cost of
+// executing a service or, if no address is provided, of deploying one
+type EstimateGasRequest struct {
+	// Data is a blob of data that the user wants to pass to the service
+	// as argument, or the code of the service to deploy
+	Data string `json:"data"`
+
+	// Address where the service can be found. It must be empty
+	// to estimate the cost of a deployment
+	Address string `json:"address,omitempty"`
+}
+
+// Type implementation of Request for EstimateGasRequest
+func (r EstimateGasRequest) Type() RequestType {
+	return EstimateGas
+}
+
+// EstimateGasResponse is the response to an EstimateGasRequest
+type EstimateGasResponse struct {
+	// Gas is the estimated number of gas units the transaction requires
+	Gas uint64 `json:"gas"`
+
+	// GasPrice is the price of a gas unit the gateway uses when
+	// sending the transaction
+	GasPrice uint64 `json:"gasPrice"`
+
+	// Fallback is true if the gas of an execution of a confidential
+	// service could not be estimated, in which case Gas is the gas
+	// the gateway provides to the transactions sent to confidential
+	// services
+	Fallback bool `json:"fallback"`
+}
+
+// ExecuteServiceBatchItem is a single service execution that is part
+// of an ExecuteServiceBatchRequest
+type ExecuteServiceBatchItem struct {
+	// Data is a blob of data that the user wants to pass to the service
+	// as argument
+	Data string `json:"data"`
+
+	// Address where the service can be found
+	Address string `json:"address"`
+}
+
+// ExecuteServiceBatchRequest is used by the user to trigger multiple
+// service executions with a single request. Each execution is handled
+// as if it was submitted with an ExecuteServiceRequest
+type ExecuteServiceBatchRequest struct {
+	// Requests are the service executions to be triggered
+	Requests []ExecuteServiceBatchItem `json:"requests"`
+}
+
+// OperationCount is the implementation of rpc.OperationCounter for
+// ExecuteServiceBatchRequest, so that each of the executions of the
+// batch is rate limited as if it was sent on its own
+func (r ExecuteServiceBatchRequest) OperationCount() int {
+	return len(r.Requests)
+}
+
+// Type implementation of Request for ExecuteServiceBatchRequest
+func (r ExecuteServiceBatchRequest) Type() RequestType {
+	return ExecuteBatch
+}
+
+// ExecuteServiceBatchItemResponse is the response to a single item of
+// an ExecuteServiceBatchRequest
+type ExecuteServiceBatchItemResponse struct {
+	// ID to identify the asynchronous response to the execution. It is
+	// only meaningful if Cause is not set
+	ID uint64 `json:"id"`
+
+	// Cause is set if the execution could not be submitted
+	Cause *rpc.Error `json:"cause,omitempty"`
+}
+
+// ExecuteServiceBatchResponse is the response to an ExecuteServiceBatchRequest
+type ExecuteServiceBatchResponse struct {
+	// Responses to each of the items of the request in the same order
+	Responses []ExecuteServiceBatchItemResponse `json:"responses"`
+}
+
 // DeployServiceRequest is issued by the user to trigger a service
 // execution. A client is always subscribed to a subscription with
 // topic "service" from which the client can retrieve the asynchronous
@@ -60,6 +189,17 @@ type DeployServiceRequest struct {
 	// Data is a blob of data that the user wants to pass as argument for
 	// the deployment of a service
 	Data string `json:"data"`
+
+	// Wait if set makes the request block until the response is
+	// available, in which case the event is returned inline instead of
+	// the ID of the asynchronous response. The event is still delivered
+	// through the polling mechanisms
+	Wait bool `json:"wait"`
+
+	// TimeoutMs is the maximum amount of time in milliseconds the request
+	// blocks when Wait is set. If the response is not available by then,
+	// the ID of the asynchronous response is returned instead
+	TimeoutMs uint64 `json:"timeoutMs"`
 }
 
 // Type implementation of Request for DeployServiceRequest
@@ -94,7 +234,7 @@ type GetCodeResponse struct {
 	Address string `json:"address"`
 
 	// Code associated to the service
-	Code string `json:"code"`
+	Code string `json:"code" bytes:"hex"`
 }
 
 // GetPublicKeyRequest is a request to retrieve the public key
@@ -122,11 +262,11 @@ type GetPublicKeyResponse struct {
 	Address string `json:"address"`
 
 	// PublicKey associated to the service
-	PublicKey string `json:"publicKey"`
+	PublicKey string `json:"publicKey" bytes:"hex"`
 
 	// Signature generated by the key manager for authentication of the
 	// public key
-	Signature string `json:"signature"`
+	Signature string `json:"signature" bytes:"hex"`
 }
 
 // PollServiceRequest is a request that allows the user to
@@ -134,8 +274,10 @@ type GetPublicKeyResponse struct {
 type PollServiceRequest struct {
 	// Offset at which events need to be provided. Events are all ordered
 	// with sequence numbers and it is up to the client to specify which
-	// events it wants to receive from an offset in the sequence
-	Offset uint64 `json:"offset"`
+	// events it wants to receive from an offset in the sequence. If it
+	// is not set, the events are provided from the cursor committed with
+	// an AckServiceRequest
+	Offset *uint64 `json:"offset,omitempty"`
 
 	// Count for the number of items the client would prefer to receive
 	// at most from a single response
@@ -144,6 +286,11 @@ type PollServiceRequest struct {
 	// DiscardPrevious allows the client to define whether the server should
 	// discard all the events that have a sequence number lower than the offer
 	DiscardPrevious bool `json:"discardPrevious"`
+
+	// WaitMs is the maximum amount of time in milliseconds the request
+	// waits for events to be available when there are none at or after
+	// Offset. If not set the request returns immediately
+	WaitMs uint64 `json:"waitMs"`
 }
 
 // Type implementation of Request for PollServiceRequest
@@ -151,6 +298,77 @@ func (r PollServiceRequest) Type() RequestType {
 	return Poll
 }
 
+// GetServiceStatusRequest is a request to retrieve the status of
+// an asynchronous request
+type GetServiceStatusRequest struct {
+	// ID of the asynchronous request as returned by the AsyncResponse
+	ID uint64 `json:"id"`
+}
+
+// Type implementation of Request for GetServiceStatusRequest
+func (r GetServiceStatusRequest) Type() RequestType {
+	return GetStatus
+}
+
+// GetServiceStatusResponse is the status of an asynchronous request
+type GetServiceStatusResponse struct {
+	// ID of the asynchronous request
+	ID uint64 `json:"id"`
+
+	// Status of the request which is one of pending, completed, failed
+	// or unknown. A request is unknown if it has never been issued, or
+	// if its response has already been discarded or has expired
+	Status string `json:"status"`
+
+	// Event is the response to the request and it is only set
+	// when the request has completed or failed
+	Event Event `json:"event,omitempty"`
+}
+
+// CancelServiceRequest is a request to cancel an asynchronous
+// request that has not sent its transaction yet
+type CancelServiceRequest struct {
+	// ID of the asynchronous request as returned by the AsyncResponse
+	ID uint64 `json:"id"`
+}
+
+// Type implementation of Request for CancelServiceRequest
+func (r CancelServiceRequest) Type() RequestType {
+	return Cancel
+}
+
+// AckServiceRequest is a request to acknowledge the responses to the
+// asynchronous requests that the client has already processed
+type AckServiceRequest struct {
+	// ID of the last event the client has processed. The events with
+	// this ID or a lower one are discarded, and polling without an
+	// offset continues after it
+	ID uint64 `json:"id"`
+}
+
+// Type implementation of Request for AckServiceRequest
+func (r AckServiceRequest) Type() RequestType {
+	return Ack
+}
+
+// StreamServiceRequest is a request that allows the user to receive
+// the events from asynchronous responses as they become available
+type StreamServiceRequest struct {
+	// Offset at which events need to be provided. Events are all ordered
+	// with sequence numbers and it is up to the client to specify from
+	// which offset in the sequence it wants to receive events. When
+	// resuming a stream, the offset should be the lowest ID of the
+	// events the client has not received yet, unless the client sets
+	// the Last-Event-ID header, in which case the stream continues
+	// after the last event received
+	Offset uint64 `json:"offset"`
+}
+
+// Type implementation of Request for StreamServiceRequest
+func (r StreamServiceRequest) Type() RequestType {
+	return Stream
+}
+
 // Event is an interface for types that can be fetched by polling on
 // a service
 type Event interface {
@@ -183,7 +401,11 @@ type ExecuteServiceEvent struct {
 	Address string `json:"address"`
 
 	// Output generated by the service at the end of its execution
-	Output string `json:"output"`
+	Output string `json:"output" bytes:"hex"`
+
+	// Transaction is the metadata of the transaction sent to
+	// execute the service
+	Transaction *Transaction `json:"transaction,omitempty"`
 }
 
 // DeployServiceEvent is the event that can be polled by the user
@@ -197,6 +419,49 @@ type DeployServiceEvent struct {
 	// is generated when a service is deployed and it can be used
 	// for service execution
 	Address string `json:"address"`
+
+	// Transaction is the metadata of the transaction sent to
+	// deploy the service
+	Transaction *Transaction `json:"transaction,omitempty"`
+}
+
+// Transaction is the metadata of the transaction sent to the
+// blockchain to fulfill a request
+type Transaction struct {
+	// Hash of the transaction
+	Hash string `json:"hash"`
+
+	// BlockNumber is the number of the block in which the
+	// transaction was included
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// BlockHash is the hash of the block in which the
+	// transaction was included
+	BlockHash string `json:"blockHash"`
+
+	// GasUsed is the amount of gas used by the transaction
+	GasUsed uint64 `json:"gasUsed"`
+
+	// From is the address of the wallet that paid for the transaction
+	From string `json:"from"`
+
+	// Logs are the logs generated by the transaction
+	Logs []Log `json:"logs"`
+}
+
+// Log is a log generated by the execution of a transaction
+type Log struct {
+	// Address of the service that generated the log
+	Address string `json:"address"`
+
+	// Topics of the log
+	Topics []string `json:"topics"`
+
+	// Data of the log
+	Data string `json:"data" bytes:"hex"`
+
+	// Index of the log within the block
+	Index uint `json:"index"`
 }
 
 // ErrorEvent is the event that can be polled by the user
@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	stderr "errors"
+	"fmt"
+	"time"
 
 	auth "github.com/oasislabs/oasis-gateway/auth/core"
 	backend "github.com/oasislabs/oasis-gateway/backend/core"
@@ -13,6 +15,20 @@ import (
 	"github.com/oasislabs/oasis-gateway/rpc"
 )
 
+const (
+	// defaultWaitTimeout is the amount of time a request waits for its
+	// response when the client does not specify a timeout
+	defaultWaitTimeout = 5 * time.Second
+
+	// maxWaitTimeout is the maximum amount of time a request can wait
+	// for its response or for events to be available
+	maxWaitTimeout = 30 * time.Second
+
+	// maxExecuteBatchSize is the maximum number of executions that
+	// can be submitted with a single batch request
+	maxExecuteBatchSize = 256
+)
+
 // Client interface for the underlying operations needed for the API
 // implementation
 type Client interface {
@@ -24,9 +40,34 @@ type Client interface {
 	// the response can be later retrieved with a PollService request
 	ExecuteServiceAsync(context.Context, backend.ExecuteServiceRequest) (uint64, errors.Err)
 
+	// CallService calls a service without sending a transaction and
+	// returns the output synchronously
+	CallService(context.Context, backend.CallServiceRequest) (backend.CallServiceResponse, errors.Err)
+
+	// EstimateGas estimates the gas required to execute or deploy a service
+	EstimateGas(context.Context, backend.EstimateGasRequest) (backend.EstimateGasResponse, errors.Err)
+
 	// PollService allows the client to poll for asynchronous responses
 	PollService(context.Context, backend.PollServiceRequest) (backend.Events, errors.Err)
 
+	// WaitService blocks until the response to an asynchronous request is
+	// available or the context is done, in which case a nil event is returned
+	WaitService(context.Context, backend.WaitServiceRequest) (backend.Event, errors.Err)
+
+	// GetServiceStatus returns the status of an asynchronous request
+	GetServiceStatus(context.Context, backend.GetServiceStatusRequest) (backend.GetServiceStatusResponse, errors.Err)
+
+	// CancelService cancels an asynchronous request that is still in progress
+	CancelService(context.Context, backend.CancelServiceRequest) errors.Err
+
+	// AckService acknowledges the asynchronous responses the client has
+	// already processed so that polling can continue after them
+	AckService(context.Context, backend.AckServiceRequest) errors.Err
+
+	// StreamService delivers the asynchronous responses to the provided channel
+	// as they become available until the context is cancelled
+	StreamService(context.Context, backend.StreamServiceRequest, chan<- backend.Event) errors.Err
+
 	// GetCode retrieves the code associated with a service.
 	GetCode(context.Context, backend.GetCodeRequest) (backend.GetCodeResponse, errors.Err)
 
@@ -74,9 +115,10 @@ func (h ServiceHandler) DeployService(ctx context.Context, v interface{}) (inter
 	// a context from an http request is cancelled after the response to the request is returned,
 	// so a new context is needed to handle the asynchronous request
 	id, err := h.client.DeployServiceAsync(context.Background(), backend.DeployServiceRequest{
-		AAD:        aad,
-		Data:       req.Data,
-		SessionKey: session,
+		AAD:            aad,
+		Data:           req.Data,
+		SessionKey:     session,
+		IdempotencyKey: rpc.GetIdempotencyKey(ctx),
 	})
 	if err != nil {
 		h.logger.Debug(ctx, "failed to start request", log.MapFields{
@@ -86,18 +128,22 @@ func (h ServiceHandler) DeployService(ctx context.Context, v interface{}) (inter
 		return nil, err
 	}
 
+	if req.Wait {
+		return h.waitService(ctx, session, id, req.TimeoutMs), nil
+	}
+
 	return AsyncResponse{ID: id}, nil
 }
 
-// parseExecuteMessage attempts to extract the AAD and PK from a standard confidential message format.
-func (h ServiceHandler) parseExecuteMessage(v *ExecuteServiceRequest) (authReq auth.AuthRequest) {
-	authReq.API = "Execute"
-	authReq.Address = v.Address
-	authReq.Data = v.Data
+// parseMessage attempts to extract the AAD and PK from a standard confidential message format.
+func (h ServiceHandler) parseMessage(api, address, data string) (authReq auth.AuthRequest) {
+	authReq.API = api
+	authReq.Address = address
+	authReq.Data = data
 
 	// Attempt to parse data as hex-encoded bytes.
-	dst := make([]byte, hex.DecodedLen(len(v.Data)))
-	_, err := hex.Decode(dst, []byte(v.Data))
+	dst := make([]byte, hex.DecodedLen(len(data)))
+	_, err := hex.Decode(dst, []byte(data))
 	if err != nil || len(dst) < 32 {
 		return
 	}
@@ -121,21 +167,41 @@ func (h ServiceHandler) parseExecuteMessage(v *ExecuteServiceRequest) (authReq a
 
 // ExecuteService handle the execution of deployed services
 func (h ServiceHandler) ExecuteService(ctx context.Context, v interface{}) (interface{}, error) {
-	aad := ctx.Value(auth.AAD{}).(string)
 	session := ctx.Value(auth.Session{}).(string)
-
 	req := v.(*ExecuteServiceRequest)
 
+	id, err := h.executeService(ctx, req, rpc.GetIdempotencyKey(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Wait {
+		return h.waitService(ctx, session, id, req.TimeoutMs), nil
+	}
+
+	return AsyncResponse{ID: id}, nil
+}
+
+// executeService verifies the execution request and submits it
+// returning the ID of the asynchronous response
+func (h ServiceHandler) executeService(
+	ctx context.Context,
+	req *ExecuteServiceRequest,
+	idempotencyKey string,
+) (uint64, errors.Err) {
+	aad := ctx.Value(auth.AAD{}).(string)
+	session := ctx.Value(auth.Session{}).(string)
+
 	if len(req.Address) == 0 {
 		e := errors.New(errors.ErrInvalidAddress, nil)
 		h.logger.Debug(ctx, "received empty address", log.MapFields{
 			"call_type": "ExecuteServiceFailure",
 			"session":   session,
 		}, e)
-		return nil, e
+		return 0, e
 	}
 
-	authReq := h.parseExecuteMessage(req)
+	authReq := h.parseMessage("Execute", req.Address, req.Data)
 	if err := h.verifier.Verify(ctx, authReq); err != nil {
 		e := errors.New(errors.ErrFailedAADVerification, err)
 		h.logger.Debug(ctx, "failed to verify AAD", log.MapFields{
@@ -143,16 +209,17 @@ func (h ServiceHandler) ExecuteService(ctx context.Context, v interface{}) (inte
 			"session":   session,
 			"err":       e,
 		})
-		return nil, e
+		return 0, e
 	}
 
 	// a context from an http request is cancelled after the response to the request is returned,
 	// so a new context is needed to handle the asynchronous request
 	id, err := h.client.ExecuteServiceAsync(context.Background(), backend.ExecuteServiceRequest{
-		AAD:        aad,
-		Address:    req.Address,
-		Data:       req.Data,
-		SessionKey: session,
+		AAD:            aad,
+		Address:        req.Address,
+		Data:           req.Data,
+		SessionKey:     session,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		h.logger.Debug(ctx, "failed to start request", log.MapFields{
@@ -160,10 +227,205 @@ func (h ServiceHandler) ExecuteService(ctx context.Context, v interface{}) (inte
 			"address":   req.Address,
 			"session":   session,
 		}, err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CallService calls a service without sending a transaction. The call
+// is verified in the same way as an execution, but its output is
+// returned in the response
+func (h ServiceHandler) CallService(ctx context.Context, v interface{}) (interface{}, error) {
+	aad := ctx.Value(auth.AAD{}).(string)
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*CallServiceRequest)
+
+	if len(req.Address) == 0 {
+		e := errors.New(errors.ErrInvalidAddress, nil)
+		h.logger.Debug(ctx, "received empty address", log.MapFields{
+			"call_type": "CallServiceFailure",
+			"session":   session,
+		}, e)
+		return nil, e
+	}
+
+	authReq := h.parseMessage("Call", req.Address, req.Data)
+	if err := h.verifier.Verify(ctx, authReq); err != nil {
+		e := errors.New(errors.ErrFailedAADVerification, err)
+		h.logger.Debug(ctx, "failed to verify AAD", log.MapFields{
+			"call_type": "CallServiceFailure",
+			"session":   session,
+			"err":       e,
+		})
+		return nil, e
+	}
+
+	res, err := h.client.CallService(ctx, backend.CallServiceRequest{
+		AAD:     aad,
+		Address: req.Address,
+		Data:    req.Data,
+		Block:   req.Block,
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "CallServiceFailure",
+			"address":   req.Address,
+			"session":   session,
+		}, err)
 		return nil, err
 	}
 
-	return AsyncResponse{ID: id}, nil
+	return CallServiceResponse{
+		Address: res.Address,
+		Output:  res.Output,
+	}, nil
+}
+
+// EstimateGas estimates the gas required to execute a service or, if
+// the request has no address, to deploy a service. The request is
+// authorized as the operation it estimates would be
+func (h ServiceHandler) EstimateGas(ctx context.Context, v interface{}) (interface{}, error) {
+	aad := ctx.Value(auth.AAD{}).(string)
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*EstimateGasRequest)
+
+	authReq := auth.AuthRequest{
+		API:  "Deploy",
+		Data: req.Data,
+	}
+	if len(req.Address) > 0 {
+		authReq = h.parseMessage("Execute", req.Address, req.Data)
+	}
+
+	if err := h.verifier.Verify(ctx, authReq); err != nil {
+		e := errors.New(errors.ErrFailedAADVerification, err)
+		h.logger.Debug(ctx, "failed to verify AAD", log.MapFields{
+			"call_type": "EstimateGasFailure",
+			"session":   session,
+			"err":       e,
+		})
+		return nil, e
+	}
+
+	res, err := h.client.EstimateGas(ctx, backend.EstimateGasRequest{
+		AAD:     aad,
+		Address: req.Address,
+		Data:    req.Data,
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "EstimateGasFailure",
+			"address":   req.Address,
+			"session":   session,
+		}, err)
+		return nil, err
+	}
+
+	return EstimateGasResponse{
+		Gas:      res.Gas,
+		GasPrice: res.GasPrice,
+		Fallback: res.Fallback,
+	}, nil
+}
+
+// ExecuteServiceBatch handles the execution of multiple services with a
+// single request. Each item is verified and submitted independently, so
+// the failure of an item does not prevent the rest from being executed
+func (h ServiceHandler) ExecuteServiceBatch(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*ExecuteServiceBatchRequest)
+
+	if len(req.Requests) == 0 {
+		e := errors.New(errors.ErrEmptyInput, stderr.New("no requests set on batch"))
+		h.logger.Debug(ctx, "received empty batch", log.MapFields{
+			"call_type": "ExecuteServiceBatchFailure",
+			"session":   session,
+		}, e)
+		return nil, e
+	}
+
+	if len(req.Requests) > maxExecuteBatchSize {
+		e := errors.New(errors.ErrBatchTooLarge, nil)
+		h.logger.Debug(ctx, "received batch that exceeds the maximum size", log.MapFields{
+			"call_type": "ExecuteServiceBatchFailure",
+			"session":   session,
+			"size":      len(req.Requests),
+		}, e)
+		return nil, e
+	}
+
+	// each item of the batch gets its own idempotency key derived from
+	// the key of the batch, so that a retried batch is only executed once
+	batchIdempotencyKey := rpc.GetIdempotencyKey(ctx)
+
+	responses := make([]ExecuteServiceBatchItemResponse, 0, len(req.Requests))
+	for i, item := range req.Requests {
+		var idempotencyKey string
+		if len(batchIdempotencyKey) > 0 {
+			idempotencyKey = fmt.Sprintf("%s:%d", batchIdempotencyKey, i)
+		}
+
+		id, err := h.executeService(ctx, &ExecuteServiceRequest{
+			Data:    item.Data,
+			Address: item.Address,
+		}, idempotencyKey)
+		if err != nil {
+			responses = append(responses, ExecuteServiceBatchItemResponse{
+				Cause: &rpc.Error{
+					ErrorCode:   err.ErrorCode().Code(),
+					Description: err.ErrorCode().Desc(),
+				},
+			})
+			continue
+		}
+
+		responses = append(responses, ExecuteServiceBatchItemResponse{ID: id})
+	}
+
+	return ExecuteServiceBatchResponse{Responses: responses}, nil
+}
+
+// waitService waits for the response to the asynchronous request identified
+// by id and returns it. If the response is not available before the timeout
+// elapses, the AsyncResponse with the ID is returned instead so that the
+// client can retrieve the response later on
+func (h ServiceHandler) waitService(ctx context.Context, session string, id uint64, timeoutMs uint64) interface{} {
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	if timeout == 0 {
+		timeout = defaultWaitTimeout
+	}
+	if timeout > maxWaitTimeout {
+		timeout = maxWaitTimeout
+	}
+
+	// the wait may take longer than the write timeout of the server, which
+	// would otherwise close the connection before the response is written
+	rpc.ExtendWriteDeadline(ctx, timeout)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ev, err := h.client.WaitService(ctx, backend.WaitServiceRequest{
+		ID:         id,
+		SessionKey: session,
+	})
+	if err != nil {
+		// the request has already been accepted, so the client can still
+		// retrieve the response through the polling mechanisms
+		h.logger.Debug(ctx, "failed to wait for response", log.MapFields{
+			"call_type": "WaitServiceFailure",
+			"session":   session,
+			"id":        id,
+		}, err)
+		return AsyncResponse{ID: id}
+	}
+
+	if ev == nil {
+		return AsyncResponse{ID: id}
+	}
+
+	return h.mapEvent(ev)
 }
 
 func (h ServiceHandler) mapEvent(event backend.Event) Event {
@@ -175,20 +437,47 @@ func (h ServiceHandler) mapEvent(event backend.Event) Event {
 		}
 	case backend.ExecuteServiceResponse:
 		return ExecuteServiceEvent{
-			ID:      r.ID,
-			Address: r.Address,
-			Output:  r.Output,
+			ID:          r.ID,
+			Address:     r.Address,
+			Output:      r.Output,
+			Transaction: mapTransaction(r.Transaction),
 		}
 	case backend.DeployServiceResponse:
 		return DeployServiceEvent{
-			ID:      r.ID,
-			Address: r.Address,
+			ID:          r.ID,
+			Address:     r.Address,
+			Transaction: mapTransaction(r.Transaction),
 		}
 	default:
 		panic("received unexpected event type from polling service")
 	}
 }
 
+func mapTransaction(tx *backend.Transaction) *Transaction {
+	if tx == nil {
+		return nil
+	}
+
+	logs := make([]Log, 0, len(tx.Logs))
+	for _, log := range tx.Logs {
+		logs = append(logs, Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+			Index:   log.Index,
+		})
+	}
+
+	return &Transaction{
+		Hash:        tx.Hash,
+		BlockNumber: tx.BlockNumber,
+		BlockHash:   tx.BlockHash,
+		GasUsed:     tx.GasUsed,
+		From:        tx.From,
+		Logs:        logs,
+	}
+}
+
 // PollService polls the service response queue to retrieve available responses
 func (h ServiceHandler) PollService(ctx context.Context, v interface{}) (interface{}, error) {
 	session := ctx.Value(auth.Session{}).(string)
@@ -197,10 +486,25 @@ func (h ServiceHandler) PollService(ctx context.Context, v interface{}) (interfa
 		req.Count = 10
 	}
 
+	wait := time.Duration(req.WaitMs) * time.Millisecond
+	if wait > maxWaitTimeout {
+		wait = maxWaitTimeout
+	}
+	if wait > 0 {
+		rpc.ExtendWriteDeadline(ctx, wait)
+	}
+
+	var offset uint64
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+
 	res, err := h.client.PollService(ctx, backend.PollServiceRequest{
-		Offset:          req.Offset,
+		Offset:          offset,
 		Count:           req.Count,
 		DiscardPrevious: req.DiscardPrevious,
+		FromCursor:      req.Offset == nil,
+		Wait:            wait,
 		SessionKey:      session,
 	})
 	if err != nil {
@@ -215,6 +519,145 @@ func (h ServiceHandler) PollService(ctx context.Context, v interface{}) (interfa
 	return PollServiceResponse{Offset: res.Offset, Events: events}, nil
 }
 
+// GetServiceStatus returns the status of an asynchronous request along
+// with its response if it is available
+func (h ServiceHandler) GetServiceStatus(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*GetServiceStatusRequest)
+
+	res, err := h.client.GetServiceStatus(ctx, backend.GetServiceStatusRequest{
+		ID:         req.ID,
+		SessionKey: session,
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "GetServiceStatusFailure",
+			"session":   session,
+			"id":        req.ID,
+		}, err)
+		return nil, err
+	}
+
+	var event Event
+	if res.Event != nil {
+		event = h.mapEvent(res.Event)
+	}
+
+	return GetServiceStatusResponse{
+		ID:     req.ID,
+		Status: string(res.Status),
+		Event:  event,
+	}, nil
+}
+
+// CancelService cancels an asynchronous request. If the request has not
+// sent its transaction yet, its response is an error event reporting
+// that the request has been cancelled
+func (h ServiceHandler) CancelService(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*CancelServiceRequest)
+
+	if err := h.client.CancelService(ctx, backend.CancelServiceRequest{
+		ID:         req.ID,
+		SessionKey: session,
+	}); err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "CancelServiceFailure",
+			"session":   session,
+			"id":        req.ID,
+		}, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// AckService acknowledges the responses to the asynchronous requests
+// of the session up to the provided ID
+func (h ServiceHandler) AckService(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*AckServiceRequest)
+
+	if err := h.client.AckService(ctx, backend.AckServiceRequest{
+		ID:         req.ID,
+		SessionKey: session,
+	}); err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "AckServiceFailure",
+			"session":   session,
+			"id":        req.ID,
+		}, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// StreamService delivers the service events to the client as they become
+// available starting from the provided offset, or after the last event
+// received if the client resumes the stream. The stream remains open until
+// the client closes the connection
+func (h ServiceHandler) StreamService(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*StreamServiceRequest)
+
+	return rpc.StreamFunc(func(ctx context.Context, w rpc.StreamWriter) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c := make(chan backend.Event, 16)
+		errC := make(chan errors.Err, 1)
+		offset := rpc.ResumeStream(ctx, w, req.Offset)
+
+		go func() {
+			errC <- h.client.StreamService(ctx, backend.StreamServiceRequest{
+				Offset:     offset,
+				SessionKey: session,
+			}, c)
+		}()
+
+		write := func(ev backend.Event) bool {
+			if err := w.Write(h.mapEvent(ev)); err != nil {
+				h.logger.Debug(ctx, "failed to write event to stream", log.MapFields{
+					"call_type": "StreamServiceFailure",
+					"session":   session,
+					"err":       err.Error(),
+				})
+				return false
+			}
+
+			return true
+		}
+
+		for {
+			select {
+			case ev := <-c:
+				if !write(ev) {
+					return nil
+				}
+			case err := <-errC:
+				// deliver the events that were sent before the stream
+				// terminated
+				for len(c) > 0 {
+					if !write(<-c) {
+						return nil
+					}
+				}
+
+				if err != nil {
+					h.logger.Debug(ctx, "stream terminated with error", log.MapFields{
+						"call_type": "StreamServiceFailure",
+						"session":   session,
+					}, err)
+					return err
+				}
+
+				return nil
+			}
+		}
+	}), nil
+}
+
 // GetCode retrieves the source code associated with a service.
 func (h ServiceHandler) GetCode(ctx context.Context, v interface{}) (interface{}, error) {
 	req := v.(*GetCodeRequest)
@@ -297,22 +740,58 @@ func NewServiceHandler(services Services) ServiceHandler {
 }
 
 // BindHandler binds the service handler to the provided
-// HandlerBinder
+// HandlerBinder under the v0 routes
 func BindHandler(services Services, binder rpc.HandlerBinder) {
+	BindRoutes(services, rpc.NewPrefixBinder("/v0/api", binder))
+}
+
+// BindRoutes binds the service handler routes to the provided
+// HandlerBinder relative to the prefix of a version of the API
+func BindRoutes(services Services, binder rpc.HandlerBinder) {
 	handler := NewServiceHandler(services)
 
-	binder.Bind("POST", "/v0/api/service/deploy", rpc.HandlerFunc(handler.DeployService),
+	binder.Bind("POST", "/service/deploy",
+		rpc.DescribeOneOf(rpc.HandlerFunc(handler.DeployService),
+			AsyncResponse{}, DeployServiceEvent{}, ErrorEvent{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &DeployServiceRequest{} }))
-	binder.Bind("POST", "/v0/api/service/execute", rpc.HandlerFunc(handler.ExecuteService),
+	binder.Bind("POST", "/service/execute",
+		rpc.DescribeOneOf(rpc.HandlerFunc(handler.ExecuteService),
+			AsyncResponse{}, ExecuteServiceEvent{}, ErrorEvent{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &ExecuteServiceRequest{} }))
-	binder.Bind("POST", "/v0/api/service/poll", rpc.HandlerFunc(handler.PollService),
+	binder.Bind("POST", "/service/call",
+		rpc.Describe(rpc.HandlerFunc(handler.CallService), CallServiceResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &CallServiceRequest{} }))
+	binder.Bind("POST", "/service/estimateGas",
+		rpc.Describe(rpc.HandlerFunc(handler.EstimateGas), EstimateGasResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &EstimateGasRequest{} }))
+	binder.Bind("POST", "/service/executeBatch",
+		rpc.Describe(rpc.HandlerFunc(handler.ExecuteServiceBatch), ExecuteServiceBatchResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &ExecuteServiceBatchRequest{} }))
+	binder.Bind("POST", "/service/poll",
+		rpc.Describe(rpc.HandlerFunc(handler.PollService), PollServiceResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &PollServiceRequest{} }))
-	binder.Bind("GET", "/v0/api/service/getCode", rpc.HandlerFunc(handler.GetCode),
+	binder.Bind("POST", "/service/status",
+		rpc.Describe(rpc.HandlerFunc(handler.GetServiceStatus), GetServiceStatusResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &GetServiceStatusRequest{} }))
+	binder.Bind("POST", "/service/cancel",
+		rpc.Describe(rpc.HandlerFunc(handler.CancelService), nil),
+		rpc.EntityFactoryFunc(func() interface{} { return &CancelServiceRequest{} }))
+	binder.Bind("POST", "/service/ack",
+		rpc.Describe(rpc.HandlerFunc(handler.AckService), nil),
+		rpc.EntityFactoryFunc(func() interface{} { return &AckServiceRequest{} }))
+	binder.Bind("GET", "/service/stream",
+		rpc.DescribeStream(rpc.HandlerFunc(handler.StreamService)),
+		rpc.EntityFactoryFunc(func() interface{} { return &StreamServiceRequest{} }))
+	binder.Bind("GET", "/service/getCode",
+		rpc.Describe(rpc.HandlerFunc(handler.GetCode), GetCodeResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &GetCodeRequest{} }))
-	binder.Bind("GET", "/v0/api/service/getPublicKey", rpc.HandlerFunc(handler.GetPublicKey),
+	binder.Bind("GET", "/service/getPublicKey",
+		rpc.Describe(rpc.HandlerFunc(handler.GetPublicKey), GetPublicKeyResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &GetPublicKeyRequest{} }))
-	binder.Bind("POST", "/v0/api/service/getCode", rpc.HandlerFunc(handler.GetCode),
+	binder.Bind("POST", "/service/getCode",
+		rpc.Describe(rpc.HandlerFunc(handler.GetCode), GetCodeResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &GetCodeRequest{} }))
-	binder.Bind("POST", "/v0/api/service/getPublicKey", rpc.HandlerFunc(handler.GetPublicKey),
+	binder.Bind("POST", "/service/getPublicKey",
+		rpc.Describe(rpc.HandlerFunc(handler.GetPublicKey), GetPublicKeyResponse{}),
 		rpc.EntityFactoryFunc(func() interface{} { return &GetPublicKeyRequest{} }))
 }
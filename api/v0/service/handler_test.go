@@ -5,6 +5,7 @@ import (
 	stderr "errors"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	auth "github.com/oasislabs/oasis-gateway/auth/core"
 	insecureauth "github.com/oasislabs/oasis-gateway/auth/insecure"
@@ -72,6 +73,74 @@ func (c *MockClient) PollService(
 	return args.Get(0).(backend.Events), nil
 }
 
+func (c *MockClient) StreamService(
+	ctx context.Context,
+	req backend.StreamServiceRequest,
+	ch chan<- backend.Event,
+) errors.Err {
+	args := c.Mock.Called(ctx, req, ch)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+func (c *MockClient) WaitService(
+	ctx context.Context,
+	req backend.WaitServiceRequest,
+) (backend.Event, errors.Err) {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(1) != nil {
+		return nil, args.Get(1).(errors.Err)
+	}
+
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+
+	return args.Get(0).(backend.Event), nil
+}
+
+func (c *MockClient) GetServiceStatus(
+	ctx context.Context,
+	req backend.GetServiceStatusRequest,
+) (backend.GetServiceStatusResponse, errors.Err) {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.GetServiceStatusResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.GetServiceStatusResponse), nil
+}
+
+func (c *MockClient) CancelService(ctx context.Context, req backend.CancelServiceRequest) errors.Err {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+func (c *MockClient) AckService(ctx context.Context, req backend.AckServiceRequest) errors.Err {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+type StreamWriterRecorder struct {
+	Events []rpc.Event
+}
+
+func (w *StreamWriterRecorder) Write(ev rpc.Event) error {
+	w.Events = append(w.Events, ev)
+	return nil
+}
+
 func (c *MockClient) GetCode(
 	ctx context.Context,
 	req backend.GetCodeRequest,
@@ -96,6 +165,30 @@ func (c *MockClient) GetPublicKey(
 	return args.Get(0).(backend.GetPublicKeyResponse), nil
 }
 
+func (c *MockClient) CallService(
+	ctx context.Context,
+	req backend.CallServiceRequest,
+) (backend.CallServiceResponse, errors.Err) {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.CallServiceResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.CallServiceResponse), nil
+}
+
+func (c *MockClient) EstimateGas(
+	ctx context.Context,
+	req backend.EstimateGasRequest,
+) (backend.EstimateGasResponse, errors.Err) {
+	args := c.Mock.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.EstimateGasResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.EstimateGasResponse), nil
+}
+
 func createServiceHandler() ServiceHandler {
 	return NewServiceHandler(Services{
 		Logger:   Logger,
@@ -165,6 +258,70 @@ func TestDeployServiceOK(t *testing.T) {
 	assert.Equal(t, uint64(0), res.(AsyncResponse).ID)
 }
 
+func TestDeployServiceWaitOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("DeployServiceAsync",
+		mock.Anything, mock.Anything).Return(1, nil)
+	handler.client.(*MockClient).On("WaitService",
+		mock.Anything,
+		backend.WaitServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(backend.DeployServiceResponse{ID: 1, Address: "0x01"}, nil)
+
+	res, err := handler.DeployService(ctx, &DeployServiceRequest{Data: "0x00", Wait: true})
+	assert.Nil(t, err)
+	assert.Equal(t, DeployServiceEvent{ID: 1, Address: "0x01"}, res)
+}
+
+func TestDeployServiceWaitTimeout(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("DeployServiceAsync",
+		mock.Anything, mock.Anything).Return(1, nil)
+	handler.client.(*MockClient).On("WaitService",
+		mock.Anything, mock.Anything).Return(nil, nil)
+
+	res, err := handler.DeployService(ctx, &DeployServiceRequest{
+		Data:      "0x00",
+		Wait:      true,
+		TimeoutMs: 10,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, AsyncResponse{ID: 1}, res)
+}
+
+func TestDeployServiceWaitExtendsWriteDeadline(t *testing.T) {
+	var waits []time.Duration
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	ctx = context.WithValue(ctx, rpc.WriteDeadline{}, func(wait time.Duration) {
+		waits = append(waits, wait)
+	})
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("DeployServiceAsync",
+		mock.Anything, mock.Anything).Return(1, nil)
+	handler.client.(*MockClient).On("WaitService",
+		mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := handler.DeployService(ctx, &DeployServiceRequest{
+		Data:      "0x00",
+		Wait:      true,
+		TimeoutMs: 60000,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{maxWaitTimeout}, waits)
+}
+
 func TestExecuteServiceEmptyData(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -257,6 +414,225 @@ func TestExecuteServiceOK(t *testing.T) {
 	assert.Equal(t, uint64(0), res.(AsyncResponse).ID)
 }
 
+func TestCallServiceEmptyAddress(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	_, err := handler.CallService(ctx, &CallServiceRequest{Data: "0x00"})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrInvalidAddress, err.(errors.Err).ErrorCode())
+}
+
+func TestCallServiceErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("CallService",
+		mock.Anything,
+		backend.CallServiceRequest{
+			AAD:     "aad",
+			Data:    "0x00",
+			Address: "0x00",
+			Block:   "latest",
+		}).Return(nil, errors.New(errors.ErrInvalidBlock, nil))
+
+	_, err := handler.CallService(ctx, &CallServiceRequest{
+		Data:    "0x00",
+		Address: "0x00",
+		Block:   "latest",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrInvalidBlock, err.(errors.Err).ErrorCode())
+}
+
+func TestCallServiceOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("CallService",
+		mock.Anything,
+		backend.CallServiceRequest{
+			AAD:     "aad",
+			Data:    "0x00",
+			Address: "0x00",
+		}).Return(backend.CallServiceResponse{Address: "0x00", Output: "0x01"}, nil)
+
+	res, err := handler.CallService(ctx, &CallServiceRequest{
+		Data:    "0x00",
+		Address: "0x00",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, CallServiceResponse{Address: "0x00", Output: "0x01"}, res)
+}
+
+func TestEstimateGasErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("EstimateGas",
+		mock.Anything,
+		backend.EstimateGasRequest{
+			AAD:     "aad",
+			Data:    "0x00",
+			Address: "0x00",
+		}).Return(nil, errors.New(errors.ErrEstimateGasExecution, nil))
+
+	_, err := handler.EstimateGas(ctx, &EstimateGasRequest{
+		Data:    "0x00",
+		Address: "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrEstimateGasExecution, err.(errors.Err).ErrorCode())
+}
+
+func TestEstimateGasDeployOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("EstimateGas",
+		mock.Anything,
+		backend.EstimateGasRequest{
+			AAD:  "aad",
+			Data: "0x00",
+		}).Return(backend.EstimateGasResponse{Gas: 21000, GasPrice: 1000000000}, nil)
+
+	res, err := handler.EstimateGas(ctx, &EstimateGasRequest{Data: "0x00"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, EstimateGasResponse{Gas: 21000, GasPrice: 1000000000}, res)
+}
+
+func TestExecuteServiceWaitOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("ExecuteServiceAsync",
+		mock.Anything, mock.Anything).Return(1, nil)
+	handler.client.(*MockClient).On("WaitService",
+		mock.Anything,
+		backend.WaitServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(backend.ExecuteServiceResponse{ID: 1, Address: "0x00", Output: "0x01"}, nil)
+
+	res, err := handler.ExecuteService(ctx, &ExecuteServiceRequest{
+		Data:    "0x00",
+		Address: "0x00",
+		Wait:    true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ExecuteServiceEvent{ID: 1, Address: "0x00", Output: "0x01"}, res)
+}
+
+func TestExecuteServiceWaitErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("ExecuteServiceAsync",
+		mock.Anything, mock.Anything).Return(1, nil)
+	handler.client.(*MockClient).On("WaitService",
+		mock.Anything, mock.Anything).Return(nil, errors.New(errors.ErrQueueRetrieve, nil))
+
+	res, err := handler.ExecuteService(ctx, &ExecuteServiceRequest{
+		Data:    "0x00",
+		Address: "0x00",
+		Wait:    true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, AsyncResponse{ID: 1}, res)
+}
+
+func TestExecuteServiceBatchEmpty(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	_, err := handler.ExecuteServiceBatch(ctx, &ExecuteServiceBatchRequest{})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrEmptyInput, err.(errors.Err).ErrorCode())
+}
+
+func TestExecuteServiceBatchTooLarge(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	_, err := handler.ExecuteServiceBatch(ctx, &ExecuteServiceBatchRequest{
+		Requests: make([]ExecuteServiceBatchItem, maxExecuteBatchSize+1),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrBatchTooLarge, err.(errors.Err).ErrorCode())
+}
+
+func TestExecuteServiceBatchPartialFailure(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("ExecuteServiceAsync",
+		mock.Anything,
+		backend.ExecuteServiceRequest{
+			AAD:        "aad",
+			Data:       "0x00",
+			Address:    "0x00",
+			SessionKey: "sessionKey",
+		}).Return(1, nil)
+	handler.client.(*MockClient).On("ExecuteServiceAsync",
+		mock.Anything,
+		backend.ExecuteServiceRequest{
+			AAD:        "aad",
+			Data:       "0x01",
+			Address:    "0x00",
+			SessionKey: "sessionKey",
+		}).Return(0, errors.New(errors.ErrQueueNext, nil))
+
+	res, err := handler.ExecuteServiceBatch(ctx, &ExecuteServiceBatchRequest{
+		Requests: []ExecuteServiceBatchItem{
+			{Data: "0x00", Address: "0x00"},
+			{Data: "0x00", Address: ""},
+			{Data: "0x01", Address: "0x00"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, ExecuteServiceBatchResponse{
+		Responses: []ExecuteServiceBatchItemResponse{
+			{ID: 1},
+			{Cause: &rpc.Error{
+				ErrorCode:   errors.ErrInvalidAddress.Code(),
+				Description: errors.ErrInvalidAddress.Desc(),
+			}},
+			{Cause: &rpc.Error{
+				ErrorCode:   errors.ErrQueueNext.Code(),
+				Description: errors.ErrQueueNext.Desc(),
+			}},
+		},
+	}, res)
+}
+
 func TestPollServiceErr(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -273,7 +649,7 @@ func TestPollServiceErr(t *testing.T) {
 		}).Return(nil, errors.New(errors.ErrInternalError, stderr.New("made up error")))
 
 	_, err := handler.PollService(ctx, &PollServiceRequest{
-		Offset:          0,
+		Offset:          offset(0),
 		Count:           10,
 		DiscardPrevious: false,
 	})
@@ -303,7 +679,7 @@ func TestPollServiceDeployOK(t *testing.T) {
 		Events: []backend.Event{backend.DeployServiceResponse{ID: 0, Address: "0x00"}}}, nil)
 
 	res, err := handler.PollService(ctx, &PollServiceRequest{
-		Offset:          0,
+		Offset:          offset(0),
 		Count:           10,
 		DiscardPrevious: false,
 	})
@@ -318,6 +694,50 @@ func TestPollServiceDeployOK(t *testing.T) {
 	}, evs.Events[0])
 }
 
+func TestPollServiceWaitOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("PollService",
+		mock.Anything,
+		backend.PollServiceRequest{
+			Offset:     1,
+			Count:      10,
+			Wait:       500 * time.Millisecond,
+			SessionKey: "sessionKey",
+		}).Return(backend.Events{Offset: 1}, nil)
+
+	res, err := handler.PollService(ctx, &PollServiceRequest{
+		Offset: offset(1),
+		WaitMs: 500,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, PollServiceResponse{Offset: 1, Events: []Event{}}, res)
+}
+
+func TestPollServiceWaitExtendsWriteDeadline(t *testing.T) {
+	var waits []time.Duration
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	ctx = context.WithValue(ctx, rpc.WriteDeadline{}, func(wait time.Duration) {
+		waits = append(waits, wait)
+	})
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("PollService",
+		mock.Anything, mock.Anything).Return(backend.Events{Offset: 1}, nil)
+
+	_, err := handler.PollService(ctx, &PollServiceRequest{Offset: offset(1), WaitMs: 500})
+	assert.Nil(t, err)
+
+	_, err = handler.PollService(ctx, &PollServiceRequest{Offset: offset(1)})
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, waits)
+}
+
 func TestPollServiceExecuteOK(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -336,7 +756,7 @@ func TestPollServiceExecuteOK(t *testing.T) {
 		Events: []backend.Event{backend.ExecuteServiceResponse{ID: 0, Address: "0x00", Output: "0x00"}}}, nil)
 
 	res, err := handler.PollService(ctx, &PollServiceRequest{
-		Offset:          0,
+		Offset:          offset(0),
 		Count:           0,
 		DiscardPrevious: false,
 	})
@@ -352,6 +772,63 @@ func TestPollServiceExecuteOK(t *testing.T) {
 	}, evs.Events[0])
 }
 
+func TestPollServiceExecuteTransactionOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("PollService",
+		mock.Anything,
+		backend.PollServiceRequest{
+			Offset:          0,
+			Count:           10,
+			DiscardPrevious: false,
+			SessionKey:      "sessionKey",
+		}).Return(backend.Events{
+		Offset: 0,
+		Events: []backend.Event{backend.ExecuteServiceResponse{
+			ID:      0,
+			Address: "0x00",
+			Output:  "0x00",
+			Transaction: &backend.Transaction{
+				Hash:        "0x01",
+				BlockNumber: 2,
+				BlockHash:   "0x03",
+				GasUsed:     4,
+				From:        "0x05",
+				Logs: []backend.Log{
+					{Address: "0x00", Topics: []string{"0x06"}, Data: "0x07", Index: 8},
+				},
+			},
+		}}}, nil)
+
+	res, err := handler.PollService(ctx, &PollServiceRequest{
+		Offset:          offset(0),
+		Count:           0,
+		DiscardPrevious: false,
+	})
+	assert.Nil(t, err)
+
+	evs := res.(PollServiceResponse)
+	assert.Equal(t, 1, len(evs.Events))
+	assert.Equal(t, ExecuteServiceEvent{
+		ID:      0,
+		Address: "0x00",
+		Output:  "0x00",
+		Transaction: &Transaction{
+			Hash:        "0x01",
+			BlockNumber: 2,
+			BlockHash:   "0x03",
+			GasUsed:     4,
+			From:        "0x05",
+			Logs: []Log{
+				{Address: "0x00", Topics: []string{"0x06"}, Data: "0x07", Index: 8},
+			},
+		},
+	}, evs.Events[0])
+}
+
 func TestPollServiceErrorOK(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -370,7 +847,7 @@ func TestPollServiceErrorOK(t *testing.T) {
 		Events: []backend.Event{backend.ErrorEvent{ID: 0, Cause: rpc.Error{}}}}, nil)
 
 	res, err := handler.PollService(ctx, &PollServiceRequest{
-		Offset:          0,
+		Offset:          offset(0),
 		Count:           10,
 		DiscardPrevious: false,
 	})
@@ -385,6 +862,248 @@ func TestPollServiceErrorOK(t *testing.T) {
 	}, evs.Events[0])
 }
 
+func TestGetServiceStatusErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("GetServiceStatus",
+		mock.Anything,
+		backend.GetServiceStatusRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(nil, errors.New(errors.ErrQueueGet, stderr.New("made up error")))
+
+	_, err := handler.GetServiceStatus(ctx, &GetServiceStatusRequest{ID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrQueueGet, err.(errors.Err).ErrorCode())
+}
+
+func TestGetServiceStatusPending(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("GetServiceStatus",
+		mock.Anything,
+		backend.GetServiceStatusRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(backend.GetServiceStatusResponse{Status: backend.RequestStatusPending}, nil)
+
+	res, err := handler.GetServiceStatus(ctx, &GetServiceStatusRequest{ID: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, GetServiceStatusResponse{ID: 1, Status: "pending"}, res)
+}
+
+func TestGetServiceStatusCompleted(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("GetServiceStatus",
+		mock.Anything,
+		backend.GetServiceStatusRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(backend.GetServiceStatusResponse{
+		Status: backend.RequestStatusCompleted,
+		Event:  backend.ExecuteServiceResponse{ID: 1, Address: "0x00", Output: "0x01"},
+	}, nil)
+
+	res, err := handler.GetServiceStatus(ctx, &GetServiceStatusRequest{ID: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, GetServiceStatusResponse{
+		ID:     1,
+		Status: "completed",
+		Event:  ExecuteServiceEvent{ID: 1, Address: "0x00", Output: "0x01"},
+	}, res)
+}
+
+func TestCancelServiceErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("CancelService",
+		mock.Anything,
+		backend.CancelServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(errors.New(errors.ErrRequestNotFound, nil))
+
+	_, err := handler.CancelService(ctx, &CancelServiceRequest{ID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrRequestNotFound, err.(errors.Err).ErrorCode())
+}
+
+func TestCancelServiceOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("CancelService",
+		mock.Anything,
+		backend.CancelServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(nil)
+
+	res, err := handler.CancelService(ctx, &CancelServiceRequest{ID: 1})
+
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func TestPollServiceFromCursor(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("PollService",
+		mock.Anything,
+		backend.PollServiceRequest{
+			Count:      10,
+			FromCursor: true,
+			SessionKey: "sessionKey",
+		}).Return(backend.Events{Offset: 2}, nil)
+
+	res, err := handler.PollService(ctx, &PollServiceRequest{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, PollServiceResponse{Offset: 2, Events: []Event{}}, res)
+}
+
+func TestAckServiceErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("AckService",
+		mock.Anything,
+		backend.AckServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(errors.New(errors.ErrQueueDiscard, stderr.New("made up error")))
+
+	_, err := handler.AckService(ctx, &AckServiceRequest{ID: 1})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrQueueDiscard, err.(errors.Err).ErrorCode())
+}
+
+func TestAckServiceOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("AckService",
+		mock.Anything,
+		backend.AckServiceRequest{
+			ID:         1,
+			SessionKey: "sessionKey",
+		}).Return(nil)
+
+	res, err := handler.AckService(ctx, &AckServiceRequest{ID: 1})
+
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+func TestStreamServiceErr(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("StreamService",
+		mock.Anything,
+		backend.StreamServiceRequest{
+			Offset:     1,
+			SessionKey: "sessionKey",
+		}, mock.Anything).Return(errors.New(errors.ErrQueueRetrieve, stderr.New("made up error")))
+
+	res, err := handler.StreamService(ctx, &StreamServiceRequest{Offset: 1})
+	assert.Nil(t, err)
+
+	w := &StreamWriterRecorder{}
+	err = res.(rpc.Stream).Stream(ctx, w)
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrQueueRetrieve, err.(errors.Err).ErrorCode())
+	assert.Equal(t, 0, len(w.Events))
+}
+
+func TestStreamServiceOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("StreamService",
+		mock.Anything,
+		backend.StreamServiceRequest{
+			Offset:     0,
+			SessionKey: "sessionKey",
+		}, mock.Anything).Run(func(args mock.Arguments) {
+		c := args.Get(2).(chan<- backend.Event)
+		c <- backend.DeployServiceResponse{ID: 0, Address: "0x00"}
+		c <- backend.ExecuteServiceResponse{ID: 1, Address: "0x00", Output: "0x01"}
+	}).Return(nil)
+
+	res, err := handler.StreamService(ctx, &StreamServiceRequest{Offset: 0})
+	assert.Nil(t, err)
+
+	w := &StreamWriterRecorder{}
+	err = res.(rpc.Stream).Stream(ctx, w)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []rpc.Event{
+		DeployServiceEvent{ID: 0, Address: "0x00"},
+		ExecuteServiceEvent{ID: 1, Address: "0x00", Output: "0x01"},
+	}, w.Events)
+}
+
+func TestStreamServiceLastEventID(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+
+	handler := createServiceHandler()
+
+	handler.client.(*MockClient).On("StreamService",
+		mock.Anything,
+		backend.StreamServiceRequest{
+			Offset:     5,
+			SessionKey: "sessionKey",
+		}, mock.Anything).Run(func(args mock.Arguments) {
+		c := args.Get(2).(chan<- backend.Event)
+		c <- backend.ExecuteServiceResponse{ID: 5, Address: "0x00", Output: "0x01"}
+	}).Return(nil)
+
+	res, err := handler.StreamService(ctx, &StreamServiceRequest{Offset: 0})
+	assert.Nil(t, err)
+
+	w := &StreamWriterRecorder{}
+	err = res.(rpc.Stream).Stream(context.WithValue(ctx, rpc.LastEventID{}, uint64(4)), w)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []rpc.Event{
+		ExecuteServiceEvent{ID: 5, Address: "0x00", Output: "0x01"},
+	}, w.Events)
+}
+
 func TestGetCodeEmptyAddress(t *testing.T) {
 	ctx := context.WithValue(Context, auth.AAD{}, "aad")
 	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
@@ -589,6 +1308,16 @@ func TestBindHandlerOK(t *testing.T) {
 
 	assert.True(t, router.HasHandler("/v0/api/service/deploy", "POST"))
 	assert.True(t, router.HasHandler("/v0/api/service/execute", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/executeBatch", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/call", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/estimateGas", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/status", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/cancel", "POST"))
 	assert.True(t, router.HasHandler("/v0/api/service/poll", "POST"))
+	assert.True(t, router.HasHandler("/v0/api/service/stream", "GET"))
 	assert.True(t, router.HasHandler("/v0/api/service/getPublicKey", "GET"))
 }
+
+func offset(v uint64) *uint64 {
+	return &v
+}
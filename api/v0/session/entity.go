@@ -0,0 +1,40 @@
+package session
+
+// OpenSessionRequest is used by the user to open a session with a
+// key issued by the gateway
+type OpenSessionRequest struct {
+	// TTLMs is the time in milliseconds the session is kept open
+	// without being refreshed. If it is not set the default TTL
+	// is used
+	TTLMs uint64 `json:"ttlMs"`
+}
+
+// OpenSessionResponse is the response to an OpenSessionRequest
+type OpenSessionResponse struct {
+	// SessionKey is the key of the session that the user provides in
+	// the X-OASIS-SESSION-KEY header of the requests to the session
+	SessionKey string `json:"sessionKey"`
+
+	// ExpiresAtMs is the time at which the session is closed unless it
+	// is refreshed in milliseconds since the unix epoch
+	ExpiresAtMs int64 `json:"expiresAtMs"`
+}
+
+// RefreshSessionRequest is used by the user to extend the TTL of
+// the session
+type RefreshSessionRequest struct {
+	// TTLMs is the time in milliseconds the session is kept open from
+	// the time of the refresh. If it is not set the default TTL is used
+	TTLMs uint64 `json:"ttlMs"`
+}
+
+// RefreshSessionResponse is the response to a RefreshSessionRequest
+type RefreshSessionResponse struct {
+	// ExpiresAtMs is the time at which the session is closed unless it
+	// is refreshed again in milliseconds since the unix epoch
+	ExpiresAtMs int64 `json:"expiresAtMs"`
+}
+
+// CloseSessionRequest is used by the user to close the session and
+// free all its resources
+type CloseSessionRequest struct{}
@@ -0,0 +1,151 @@
+package session
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	auth "github.com/oasislabs/oasis-gateway/auth/core"
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// Client interface for the underlying operations needed for the API
+// implementation
+type Client interface {
+	OpenSession(context.Context, backend.OpenSessionRequest) (backend.OpenSessionResponse, errors.Err)
+	RefreshSession(context.Context, backend.RefreshSessionRequest) (backend.RefreshSessionResponse, errors.Err)
+	CloseSession(context.Context, backend.CloseSessionRequest) errors.Err
+}
+
+type Services struct {
+	Logger log.Logger
+	Client Client
+}
+
+// SessionHandler implements the handlers associated with the
+// management of sessions
+type SessionHandler struct {
+	logger log.Logger
+	client Client
+}
+
+// OpenSession opens a session with a key issued by the gateway. The
+// request does not need to provide a session key
+func (h SessionHandler) OpenSession(ctx context.Context, v interface{}) (interface{}, error) {
+	req := v.(*OpenSessionRequest)
+
+	key := uuid.New().String()
+	session, derr := auth.SessionKey(auth.MustGetAAD(ctx), key)
+	if derr != nil {
+		err := errors.New(errors.ErrInvalidAAD, derr)
+		h.logger.Debug(ctx, "failed to derive session key", log.MapFields{
+			"call_type": "OpenSessionFailure",
+		}, err)
+		return nil, err
+	}
+
+	res, err := h.client.OpenSession(ctx, backend.OpenSessionRequest{
+		SessionKey: session,
+		TTL:        ttl(req.TTLMs),
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "failed to open session", log.MapFields{
+			"call_type": "OpenSessionFailure",
+		}, err)
+		return nil, err
+	}
+
+	return OpenSessionResponse{
+		SessionKey:  key,
+		ExpiresAtMs: res.ExpiresAt.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
+
+// RefreshSession extends the TTL of the client's session
+func (h SessionHandler) RefreshSession(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+	req := v.(*RefreshSessionRequest)
+
+	res, err := h.client.RefreshSession(ctx, backend.RefreshSessionRequest{
+		SessionKey: session,
+		TTL:        ttl(req.TTLMs),
+	})
+	if err != nil {
+		h.logger.Debug(ctx, "failed to refresh session", log.MapFields{
+			"call_type": "RefreshSessionFailure",
+		}, err)
+		return nil, err
+	}
+
+	return RefreshSessionResponse{
+		ExpiresAtMs: res.ExpiresAt.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
+
+// CloseSession closes the client's session, destroying its
+// subscriptions and freeing all its resources
+func (h SessionHandler) CloseSession(ctx context.Context, v interface{}) (interface{}, error) {
+	session := ctx.Value(auth.Session{}).(string)
+
+	if err := h.client.CloseSession(ctx, backend.CloseSessionRequest{
+		SessionKey: session,
+	}); err != nil {
+		h.logger.Debug(ctx, "failed to close session", log.MapFields{
+			"call_type": "CloseSessionFailure",
+		}, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ttl converts a TTL in milliseconds to a time.Duration. TTLs that
+// do not fit in a time.Duration are capped, so that the backend
+// rejects them as too long
+func ttl(ms uint64) time.Duration {
+	if ms > uint64(math.MaxInt64/int64(time.Millisecond)) {
+		return math.MaxInt64
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+func NewSessionHandler(services Services) SessionHandler {
+	if services.Client == nil {
+		panic("Client must be provided as a service")
+	}
+	if services.Logger == nil {
+		panic("Logger must be provided as a service")
+	}
+
+	return SessionHandler{
+		logger: services.Logger.ForClass("session", "handler"),
+		client: services.Client,
+	}
+}
+
+// BindHandler binds the session handler to the provided
+// HandlerBinder under the v0 routes
+func BindHandler(services Services, binder rpc.HandlerBinder) {
+	BindRoutes(services, rpc.NewPrefixBinder("/v0/api", binder))
+}
+
+// BindRoutes binds the session handler routes to the provided
+// HandlerBinder relative to the prefix of a version of the API
+func BindRoutes(services Services, binder rpc.HandlerBinder) {
+	handler := NewSessionHandler(services)
+
+	binder.Bind("POST", "/session/open",
+		rpc.Describe(auth.SessionOptional(rpc.HandlerFunc(handler.OpenSession)), OpenSessionResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &OpenSessionRequest{} }))
+	binder.Bind("POST", "/session/refresh",
+		rpc.Describe(rpc.HandlerFunc(handler.RefreshSession), RefreshSessionResponse{}),
+		rpc.EntityFactoryFunc(func() interface{} { return &RefreshSessionRequest{} }))
+	binder.Bind("POST", "/session/close",
+		rpc.Describe(rpc.HandlerFunc(handler.CloseSession), nil),
+		rpc.EntityFactoryFunc(func() interface{} { return &CloseSessionRequest{} }))
+}
@@ -0,0 +1,189 @@
+package session
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"testing"
+	"time"
+
+	auth "github.com/oasislabs/oasis-gateway/auth/core"
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var Context = context.TODO()
+
+var Logger = log.NewLogrus(log.LogrusLoggerProperties{
+	Output: ioutil.Discard,
+})
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (c *MockClient) OpenSession(
+	ctx context.Context,
+	req backend.OpenSessionRequest,
+) (backend.OpenSessionResponse, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.OpenSessionResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.OpenSessionResponse), nil
+}
+
+func (c *MockClient) RefreshSession(
+	ctx context.Context,
+	req backend.RefreshSessionRequest,
+) (backend.RefreshSessionResponse, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return backend.RefreshSessionResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(backend.RefreshSessionResponse), nil
+}
+
+func (c *MockClient) CloseSession(
+	ctx context.Context,
+	req backend.CloseSessionRequest,
+) errors.Err {
+	args := c.Called(ctx, req)
+	if args.Get(0) != nil {
+		return args.Get(0).(errors.Err)
+	}
+
+	return nil
+}
+
+func createSessionHandler() SessionHandler {
+	return NewSessionHandler(Services{
+		Logger: Logger,
+		Client: &MockClient{},
+	})
+}
+
+func TestOpenSessionOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	handler := createSessionHandler()
+	expiresAt := time.Unix(1, 0)
+
+	handler.client.(*MockClient).On("OpenSession", mock.Anything, mock.Anything).
+		Return(backend.OpenSessionResponse{ExpiresAt: expiresAt}, nil)
+
+	v, err := handler.OpenSession(ctx, &OpenSessionRequest{TTLMs: 1000})
+	assert.Nil(t, err)
+
+	res := v.(OpenSessionResponse)
+	assert.NotEmpty(t, res.SessionKey)
+	assert.Equal(t, int64(1000), res.ExpiresAtMs)
+
+	session, err := auth.SessionKey("aad", res.SessionKey)
+	assert.Nil(t, err)
+	handler.client.(*MockClient).AssertCalled(t, "OpenSession", ctx, backend.OpenSessionRequest{
+		SessionKey: session,
+		TTL:        time.Second,
+	})
+}
+
+func TestOpenSessionIssuesDifferentKeys(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	handler := createSessionHandler()
+
+	handler.client.(*MockClient).On("OpenSession", mock.Anything, mock.Anything).
+		Return(backend.OpenSessionResponse{}, nil)
+
+	first, err := handler.OpenSession(ctx, &OpenSessionRequest{})
+	assert.Nil(t, err)
+	second, err := handler.OpenSession(ctx, &OpenSessionRequest{})
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first.(OpenSessionResponse).SessionKey, second.(OpenSessionResponse).SessionKey)
+}
+
+func TestOpenSessionErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	handler := createSessionHandler()
+
+	handler.client.(*MockClient).On("OpenSession", mock.Anything, mock.Anything).
+		Return(backend.OpenSessionResponse{}, errors.New(errors.ErrInvalidSessionTTL, nil))
+
+	_, err := handler.OpenSession(ctx, &OpenSessionRequest{TTLMs: math.MaxUint64})
+
+	assert.Equal(t, errors.ErrInvalidSessionTTL, err.(errors.Err).ErrorCode())
+	handler.client.(*MockClient).AssertCalled(t, "OpenSession", ctx, mock.MatchedBy(
+		func(req backend.OpenSessionRequest) bool {
+			return req.TTL == math.MaxInt64
+		}))
+}
+
+func TestRefreshSessionOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	handler := createSessionHandler()
+
+	handler.client.(*MockClient).On("RefreshSession", mock.Anything, mock.Anything).
+		Return(backend.RefreshSessionResponse{ExpiresAt: time.Unix(2, 0)}, nil)
+
+	res, err := handler.RefreshSession(ctx, &RefreshSessionRequest{TTLMs: 2000})
+
+	assert.Nil(t, err)
+	assert.Equal(t, RefreshSessionResponse{ExpiresAtMs: 2000}, res)
+	handler.client.(*MockClient).AssertCalled(t, "RefreshSession", ctx, backend.RefreshSessionRequest{
+		SessionKey: "sessionKey",
+		TTL:        2 * time.Second,
+	})
+}
+
+func TestRefreshSessionErrReturn(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	handler := createSessionHandler()
+
+	handler.client.(*MockClient).On("RefreshSession", mock.Anything, mock.Anything).
+		Return(backend.RefreshSessionResponse{}, errors.New(errors.ErrSessionNotFound, nil))
+
+	_, err := handler.RefreshSession(ctx, &RefreshSessionRequest{})
+
+	assert.Equal(t, errors.ErrSessionNotFound, err.(errors.Err).ErrorCode())
+}
+
+func TestCloseSessionOK(t *testing.T) {
+	ctx := context.WithValue(Context, auth.AAD{}, "aad")
+	ctx = context.WithValue(ctx, auth.Session{}, "sessionKey")
+	handler := createSessionHandler()
+
+	handler.client.(*MockClient).On("CloseSession", mock.Anything, mock.Anything).
+		Return(nil)
+
+	res, err := handler.CloseSession(ctx, &CloseSessionRequest{})
+
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	handler.client.(*MockClient).AssertCalled(t, "CloseSession", ctx, backend.CloseSessionRequest{
+		SessionKey: "sessionKey",
+	})
+}
+
+func TestBindRoutesSessionOptional(t *testing.T) {
+	binder := &MockBinder{handlers: make(map[string]rpc.Handler)}
+	BindHandler(Services{Logger: Logger, Client: &MockClient{}}, binder)
+
+	assert.True(t, auth.IsSessionOptional(binder.handlers["/v0/api/session/open"]))
+	assert.False(t, auth.IsSessionOptional(binder.handlers["/v0/api/session/refresh"]))
+	assert.False(t, auth.IsSessionOptional(binder.handlers["/v0/api/session/close"]))
+}
+
+type MockBinder struct {
+	handlers map[string]rpc.Handler
+}
+
+func (b *MockBinder) Bind(method string, path string, handler rpc.Handler, factory rpc.EntityFactory) {
+	b.handlers[path] = handler
+}
@@ -0,0 +1,35 @@
+// Package v1 binds the routes of version 1 of the API.
+//
+// Version 1 starts with the same request and response shapes as
+// version 0, so the v0 handlers are bound under the v1 prefix. When the
+// shape of a route needs to change, its v1 handler and entities are
+// defined in this package and bound after the v0 routes, which replaces
+// the v0 handler for the v1 route only. The v0 routes stay frozen.
+package v1
+
+import (
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	"github.com/oasislabs/oasis-gateway/api/v0/session"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// Prefix is the prefix of all the routes of version 1 of the API
+const Prefix = "/v1/api"
+
+// Services required by the handlers of version 1 of the API
+type Services struct {
+	Service service.Services
+	Event   event.Services
+	Session session.Services
+}
+
+// BindHandler binds the handlers of version 1 of the API to the
+// provided HandlerBinder
+func BindHandler(services Services, binder rpc.HandlerBinder) {
+	prefixed := rpc.NewPrefixBinder(Prefix, binder)
+
+	service.BindRoutes(services.Service, prefixed)
+	event.BindRoutes(services.Event, prefixed)
+	session.BindRoutes(services.Session, prefixed)
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	"github.com/oasislabs/oasis-gateway/api/v0/session"
+	backend "github.com/oasislabs/oasis-gateway/backend/core"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+var Logger = log.NewLogrus(log.LogrusLoggerProperties{
+	Output: ioutil.Discard,
+})
+
+func TestBindHandlerOK(t *testing.T) {
+	binder := rpc.NewHttpBinder(rpc.HttpBinderProperties{
+		Encoder: rpc.JsonEncoder{},
+		Logger:  Logger,
+		HandlerFactory: rpc.HttpHandlerFactoryFunc(func(factory rpc.EntityFactory, handler rpc.Handler) rpc.HttpMiddleware {
+			return rpc.NewHttpJsonHandler(rpc.HttpJsonHandlerProperties{
+				Limit:   1 << 16,
+				Handler: handler,
+				Logger:  Logger,
+				Factory: factory,
+			})
+		}),
+	})
+
+	// the handlers are not called, so a client is only
+	// needed to construct them
+	client := &backend.RequestManager{}
+	BindHandler(Services{
+		Service: service.Services{Logger: Logger, Client: client},
+		Event:   event.Services{Logger: Logger, Client: client},
+		Session: session.Services{Logger: Logger, Client: client},
+	}, binder)
+
+	router := binder.Build()
+
+	assert.True(t, router.HasHandler("/v1/api/service/deploy", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/service/execute", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/service/poll", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/service/stream", "GET"))
+	assert.True(t, router.HasHandler("/v1/api/event/subscribe", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/event/list", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/event/stream", "GET"))
+	assert.True(t, router.HasHandler("/v1/api/session/open", "POST"))
+	assert.True(t, router.HasHandler("/v1/api/session/close", "POST"))
+	assert.False(t, router.HasRoute("/v0/api/service/deploy"))
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"google.golang.org/grpc/metadata"
+)
+
+// GrpcAuth authenticates gRPC calls with an Auth. The metadata of
+// the call is provided to the Auth as the headers of an http.Request,
+// so the same authentication plugins serve both the HTTP and the gRPC
+// APIs and the clients provide the same keys in both
+type GrpcAuth struct {
+	auth   Auth
+	logger log.Logger
+}
+
+// NewGrpcAuth creates a new GrpcAuth that authenticates the calls
+// with the provided Auth
+func NewGrpcAuth(auth Auth, logger log.Logger) *GrpcAuth {
+	if auth == nil {
+		panic("auth must be set")
+	}
+
+	if logger == nil {
+		panic("log must be set")
+	}
+
+	return &GrpcAuth{
+		auth:   auth,
+		logger: logger.ForClass("auth", "GrpcAuth"),
+	}
+}
+
+// Authenticate authenticates the call with the metadata of the incoming
+// context. On success it returns a context derived from ctx with the AAD
+// and the Session of the call set
+func (a *GrpcAuth) Authenticate(ctx context.Context) (context.Context, errors.Err) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		return nil, errors.New(errors.ErrInternalError, err)
+	}
+
+	for key, values := range md {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	req, err = a.auth.Authenticate(req.WithContext(ctx))
+	if err != nil {
+		e := errors.New(errors.ErrAuthenticateRequest, err)
+		a.logger.Debug(ctx, "failed to authenticate call", log.MapFields{
+			"call_type": "GrpcAuthenticateFailure",
+		}, e)
+		return nil, e
+	}
+
+	sessionKey := req.Header.Get(RequestHeaderSessionKey)
+	if len(sessionKey) == 0 {
+		return nil, errors.New(errors.ErrAuthenticateRequest,
+			fmt.Errorf("no %s metadata provided", RequestHeaderSessionKey))
+	}
+
+	aad, ok := req.Context().Value(AAD{}).(string)
+	if !ok {
+		return nil, errors.New(errors.ErrInvalidAAD, stderr.New("Authenticate method did not set AAD"))
+	}
+
+	session, err := SessionKey(aad, sessionKey)
+	if err != nil {
+		return nil, errors.New(errors.ErrInvalidAAD, err)
+	}
+
+	return context.WithValue(req.Context(), Session{}, session), nil
+}
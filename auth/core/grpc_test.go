@@ -0,0 +1,40 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGrpcAuthenticate(t *testing.T) {
+	auth := NewGrpcAuth(&NilAuth{}, Logger)
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("x-oasis-session-key", "session"))
+
+	ctx, err := auth.Authenticate(ctx)
+	assert.Nil(t, err)
+
+	session, derr := SessionKey("nil", "session")
+	assert.Nil(t, derr)
+	assert.Equal(t, "nil", ctx.Value(AAD{}))
+	assert.Equal(t, session, ctx.Value(Session{}))
+}
+
+func TestGrpcAuthenticateErrNoSessionKey(t *testing.T) {
+	auth := NewGrpcAuth(&NilAuth{}, Logger)
+
+	_, err := auth.Authenticate(metadata.NewIncomingContext(context.Background(), metadata.MD{}))
+
+	assert.Equal(t, errors.ErrAuthenticateRequest, err.ErrorCode())
+}
+
+func TestGrpcAuthenticateErrNoMetadata(t *testing.T) {
+	auth := NewGrpcAuth(&NilAuth{}, Logger)
+
+	_, err := auth.Authenticate(context.Background())
+
+	assert.Equal(t, errors.ErrAuthenticateRequest, err.ErrorCode())
+}
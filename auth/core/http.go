@@ -20,29 +20,133 @@ const (
 	RequestHeaderSessionKey string = "X-OASIS-SESSION-KEY"
 )
 
+// sessionOptionalHandler is a handler that serves requests that
+// do not provide a session key
+type sessionOptionalHandler struct {
+	rpc.Handler
+}
+
+// SessionOptional marks the handler so that the requests that do not
+// provide a session key are also authenticated and served. Those
+// requests do not have a Session set in their context
+func SessionOptional(handler rpc.Handler) rpc.Handler {
+	return sessionOptionalHandler{Handler: handler}
+}
+
+// IsSessionOptional returns true if the handler, or the handler
+// described by a DescribedHandler, is marked as SessionOptional
+func IsSessionOptional(handler rpc.Handler) bool {
+	if described, ok := handler.(rpc.DescribedHandler); ok {
+		handler = described.Handler
+	}
+
+	_, ok := handler.(sessionOptionalHandler)
+	return ok
+}
+
+// sessionRequiredHandler is a handler that fails the requests
+// that do not have a Session set in their context
+type sessionRequiredHandler struct {
+	rpc.Handler
+}
+
+// Handle is the implementation of rpc.Handler for sessionRequiredHandler
+func (h sessionRequiredHandler) Handle(ctx context.Context, v interface{}) (interface{}, error) {
+	if ctx.Value(Session{}) == nil {
+		return nil, errors.New(errors.ErrAuthenticateRequest,
+			fmt.Errorf("no %s header provided", RequestHeaderSessionKey))
+	}
+
+	return h.Handler.Handle(ctx, v)
+}
+
+// SessionBinder is an rpc.HandlerBinder that binds the handlers to
+// another binder so that the handlers that are not SessionOptional
+// fail the requests served without a session key. It is used for the
+// routes that dispatch requests to multiple handlers and are therefore
+// served without a session key themselves
+type SessionBinder struct {
+	binder rpc.HandlerBinder
+}
+
+// NewSessionBinder creates a new SessionBinder that binds
+// the handlers to the provided binder
+func NewSessionBinder(binder rpc.HandlerBinder) *SessionBinder {
+	if binder == nil {
+		panic("binder must be set")
+	}
+
+	return &SessionBinder{binder: binder}
+}
+
+// Bind is the implementation of rpc.HandlerBinder for SessionBinder
+func (b *SessionBinder) Bind(method string, path string, handler rpc.Handler, factory rpc.EntityFactory) {
+	if !IsSessionOptional(handler) {
+		handler = sessionRequiredHandler{Handler: handler}
+	}
+
+	b.binder.Bind(method, path, handler, factory)
+}
+
+// SessionKey returns the key that identifies the session with the
+// provided key for the client authenticated with the AAD, so that
+// clients cannot access each other's sessions
+func SessionKey(aad, key string) (string, error) {
+	hasher := sha256.New()
+	if _, err := hasher.Write([]byte(aad)); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(sessionKeyFormat, hex.EncodeToString(hasher.Sum(nil)), key), nil
+}
+
 type HttpMiddlewareAuth struct {
-	auth   Auth
-	logger log.Logger
-	next   rpc.HttpMiddleware
+	auth            Auth
+	logger          log.Logger
+	next            rpc.HttpMiddleware
+	sessionOptional bool
+}
+
+// HttpMiddlewareAuthProps are the properties used to create
+// an HttpMiddlewareAuth
+type HttpMiddlewareAuthProps struct {
+	Auth   Auth
+	Logger log.Logger
+	Next   rpc.HttpMiddleware
+
+	// SessionOptional is true if requests that do not provide
+	// a session key are served
+	SessionOptional bool
 }
 
 func NewHttpMiddlewareAuth(auth Auth, logger log.Logger, next rpc.HttpMiddleware) *HttpMiddlewareAuth {
-	if auth == nil {
+	return NewHttpMiddlewareAuthWithProps(HttpMiddlewareAuthProps{
+		Auth:   auth,
+		Logger: logger,
+		Next:   next,
+	})
+}
+
+// NewHttpMiddlewareAuthWithProps creates a new HttpMiddlewareAuth
+// from the provided properties
+func NewHttpMiddlewareAuthWithProps(props HttpMiddlewareAuthProps) *HttpMiddlewareAuth {
+	if props.Auth == nil {
 		panic("auth must be set")
 	}
 
-	if logger == nil {
+	if props.Logger == nil {
 		panic("log must be set")
 	}
 
-	if next == nil {
+	if props.Next == nil {
 		panic("next must be set")
 	}
 
 	return &HttpMiddlewareAuth{
-		auth:   auth,
-		logger: logger.ForClass("auth", "HttpMiddlewareAuth"),
-		next:   next,
+		auth:            props.Auth,
+		logger:          props.Logger.ForClass("auth", "HttpMiddlewareAuth"),
+		next:            props.Next,
+		sessionOptional: props.SessionOptional,
 	}
 }
 
@@ -66,6 +170,10 @@ func (m *HttpMiddlewareAuth) ServeHTTP(req *http.Request) (interface{}, error) {
 	}
 
 	sessionKey := req.Header.Get(RequestHeaderSessionKey)
+	if len(sessionKey) == 0 && m.sessionOptional {
+		return m.next.ServeHTTP(req)
+	}
+
 	if len(sessionKey) == 0 {
 		newErr := errors.New(errors.ErrAuthenticateRequest, fmt.Errorf("no %s header provided", RequestHeaderSessionKey))
 		return nil, &rpc.HttpError{
@@ -74,14 +182,11 @@ func (m *HttpMiddlewareAuth) ServeHTTP(req *http.Request) (interface{}, error) {
 		}
 	}
 
-	expectedAAD := MustGetAAD(req.Context())
-	hasher := sha256.New()
-	if _, err = hasher.Write([]byte(expectedAAD)); err != nil {
+	session, err := SessionKey(MustGetAAD(req.Context()), sessionKey)
+	if err != nil {
 		return nil, rpc.HttpForbidden(context.TODO(), errors.New(errors.ErrInvalidAAD, err))
 	}
 
-	aadHash := hex.EncodeToString(hasher.Sum(nil))
-
-	req = req.WithContext(context.WithValue(req.Context(), Session{}, fmt.Sprintf(sessionKeyFormat, aadHash, sessionKey)))
+	req = req.WithContext(context.WithValue(req.Context(), Session{}, session))
 	return m.next.ServeHTTP(req)
 }
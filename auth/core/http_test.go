@@ -1,10 +1,12 @@
 package core
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/log"
 	"github.com/oasislabs/oasis-gateway/rpc"
 	"github.com/sirupsen/logrus"
@@ -31,3 +33,82 @@ func TestServeHTTP(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, res)
 }
+
+func TestServeHTTPErrNoSessionKey(t *testing.T) {
+	handler := NewHttpMiddlewareAuth(&NilAuth{}, Logger, rpc.HttpMiddlewareFunc(func(req *http.Request) (interface{}, error) {
+		return 0, nil
+	}))
+
+	req, err := http.NewRequest("GET", "/", nil)
+	assert.Nil(t, err)
+
+	_, err = handler.ServeHTTP(req)
+	assert.Equal(t, http.StatusForbidden, err.(*rpc.HttpError).StatusCode)
+}
+
+func TestServeHTTPSessionOptional(t *testing.T) {
+	handler := NewHttpMiddlewareAuthWithProps(HttpMiddlewareAuthProps{
+		Auth:   &NilAuth{},
+		Logger: Logger,
+		Next: rpc.HttpMiddlewareFunc(func(req *http.Request) (interface{}, error) {
+			assert.Equal(t, "nil", req.Context().Value(AAD{}))
+			return req.Context().Value(Session{}), nil
+		}),
+		SessionOptional: true,
+	})
+
+	req, err := http.NewRequest("GET", "/", nil)
+	assert.Nil(t, err)
+
+	res, err := handler.ServeHTTP(req)
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	// the session is still set when the request provides a key
+	req.Header.Add(RequestHeaderSessionKey, "session")
+	res, err = handler.ServeHTTP(req)
+	assert.Nil(t, err)
+
+	session, err := SessionKey("nil", "session")
+	assert.Nil(t, err)
+	assert.Equal(t, session, res)
+}
+
+func TestIsSessionOptional(t *testing.T) {
+	handler := rpc.HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	assert.False(t, IsSessionOptional(handler))
+	assert.False(t, IsSessionOptional(rpc.Describe(handler, nil)))
+	assert.True(t, IsSessionOptional(SessionOptional(handler)))
+	assert.True(t, IsSessionOptional(rpc.Describe(SessionOptional(handler), nil)))
+}
+
+type handlerBinder map[string]rpc.Handler
+
+func (b handlerBinder) Bind(method string, path string, handler rpc.Handler, factory rpc.EntityFactory) {
+	b[path] = handler
+}
+
+func TestSessionBinderRequiresSession(t *testing.T) {
+	handler := rpc.HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return 0, nil
+	})
+
+	binder := make(handlerBinder)
+	sessionBinder := NewSessionBinder(binder)
+	sessionBinder.Bind("POST", "/required", handler, nil)
+	sessionBinder.Bind("POST", "/optional", SessionOptional(handler), nil)
+
+	_, err := binder["/required"].Handle(context.Background(), nil)
+	assert.Equal(t, errors.ErrAuthenticateRequest, err.(errors.Err).ErrorCode())
+
+	res, err := binder["/required"].Handle(context.WithValue(context.Background(), Session{}, "session"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res)
+
+	res, err = binder["/optional"].Handle(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res)
+}
@@ -0,0 +1,327 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+)
+
+const (
+	// maxRegisteredABIs is the maximum number of contract ABIs that
+	// a session can register
+	maxRegisteredABIs = 64
+
+	// maxABIElements is the maximum number of elements retrieved from
+	// the abi queue of a session. It matches the default maximum size
+	// of a queue
+	maxABIElements uint = 1024
+
+	// abiElementType is the type of the element that keeps the ABIs
+	// registered by a session in its abi queue
+	abiElementType = "abi"
+)
+
+// decodedLog is a log decoded with the ABI of the contract that
+// emitted it
+type decodedLog struct {
+	// Event is the name of the event that emitted the log
+	Event string
+
+	// Args are the indexed and non-indexed arguments of the event
+	// by name
+	Args map[string]interface{}
+}
+
+// logDecoder decodes the logs received by a subscription
+type logDecoder interface {
+	// DecodeLog decodes a log. It returns false if the log cannot
+	// be decoded
+	DecodeLog(context.Context, types.Log) (decodedLog, bool)
+}
+
+// abiRegistry keeps the contract ABIs registered by each session
+// so that the logs emitted by those contracts can be decoded. The
+// ABIs are kept in the abi queue of the session, so that all the
+// gateway instances that share the mqueue can decode the logs and
+// the ABIs expire along with the other queues of the session
+type abiRegistry struct {
+	mqueue mqueue.MQueue
+	logger log.Logger
+}
+
+func newABIRegistry(mqueue mqueue.MQueue, logger log.Logger) *abiRegistry {
+	return &abiRegistry{mqueue: mqueue, logger: logger}
+}
+
+// list returns the ABIs registered for the session identified by key
+// by the address of their contract. All the ABIs of a session are kept
+// in a single element, and if the previous elements could not be
+// discarded the one set last is used
+func (r *abiRegistry) list(ctx context.Context, key string) (map[common.Address]string, errors.Err) {
+	els, err := r.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{
+		Key:    ABIID(key),
+		Offset: 0,
+		Count:  maxABIElements,
+	})
+	if err != nil {
+		return nil, errors.New(errors.ErrQueueRetrieve, err)
+	}
+
+	var latest *mqueue.Element
+	for i, el := range els.Elements {
+		if el.Type == abiElementType && (latest == nil || el.Offset > latest.Offset) {
+			latest = &els.Elements[i]
+		}
+	}
+
+	abis := make(map[common.Address]string)
+	if latest == nil {
+		return abis, nil
+	}
+
+	if err := json.Unmarshal([]byte(latest.Value), &abis); err != nil {
+		return nil, errors.New(errors.ErrDeserializeEvent, err)
+	}
+
+	return abis, nil
+}
+
+// Register sets the ABI of the contract at address for the session
+// identified by key, replacing the ABI previously registered. It
+// fails with ErrABILimitReached if the session has reached the maximum
+// number of ABIs
+func (r *abiRegistry) Register(ctx context.Context, key string, address common.Address, contract string) errors.Err {
+	abis, err := r.list(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := abis[address]; !ok && len(abis) >= maxRegisteredABIs {
+		return errors.New(errors.ErrABILimitReached, nil)
+	}
+
+	abis[address] = contract
+	p, derr := json.Marshal(abis)
+	if derr != nil {
+		return errors.New(errors.ErrInternalError, derr)
+	}
+
+	// the ABIs are stored in a new element and the previous elements
+	// are discarded so that the queue does not grow with every
+	// registration
+	queue := ABIID(key)
+	id, derr := r.mqueue.Next(ctx, mqueue.NextRequest{Key: queue})
+	if derr != nil {
+		return errors.New(errors.ErrQueueNext, derr)
+	}
+
+	if derr := r.mqueue.Insert(ctx, mqueue.InsertRequest{Key: queue, Element: mqueue.Element{
+		Offset: id,
+		Type:   abiElementType,
+		Value:  string(p),
+	}}); derr != nil {
+		return errors.New(errors.ErrQueueInsert, derr)
+	}
+
+	if derr := r.mqueue.Discard(ctx, mqueue.DiscardRequest{Key: queue, Offset: id}); derr != nil {
+		return errors.New(errors.ErrQueueDiscard, derr)
+	}
+
+	return nil
+}
+
+// Decoder returns the logDecoder that uses the ABIs of the session
+// identified by key. ABIs registered after the decoder is created
+// are also used
+func (r *abiRegistry) Decoder(key string) logDecoder {
+	return &sessionDecoder{registry: r, key: key, abis: make(map[common.Address]parsedABI)}
+}
+
+// parsedABI is a contract ABI parsed from its JSON definition
+type parsedABI struct {
+	source   string
+	contract abi.ABI
+}
+
+// sessionDecoder decodes logs with the ABIs registered by a session.
+// Each ABI is parsed once and kept by the address of its contract
+// until a different ABI is registered for the contract
+type sessionDecoder struct {
+	registry *abiRegistry
+	key      string
+
+	mutex sync.Mutex
+	abis  map[common.Address]parsedABI
+}
+
+// contract returns the ABI registered for the contract at address
+func (d *sessionDecoder) contract(ctx context.Context, address common.Address) (abi.ABI, bool) {
+	registered, err := d.registry.list(ctx, d.key)
+	if err != nil {
+		d.registry.logger.Warn(ctx, "failed to retrieve registered abis", log.MapFields{
+			"call_type": "DecodeLogFailure",
+			"key":       d.key,
+		}, err)
+		return abi.ABI{}, false
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	source, ok := registered[address]
+	if !ok {
+		delete(d.abis, address)
+		return abi.ABI{}, false
+	}
+
+	if parsed, ok := d.abis[address]; ok && parsed.source == source {
+		return parsed.contract, true
+	}
+
+	contract, derr := abi.JSON(strings.NewReader(source))
+	if derr != nil {
+		delete(d.abis, address)
+		return abi.ABI{}, false
+	}
+
+	d.abis[address] = parsedABI{source: source, contract: contract}
+	return contract, true
+}
+
+// DecodeLog is the implementation of logDecoder for sessionDecoder
+func (d *sessionDecoder) DecodeLog(ctx context.Context, log types.Log) (decodedLog, bool) {
+	contract, ok := d.contract(ctx, log.Address)
+	if !ok {
+		return decodedLog{}, false
+	}
+
+	return decodeLog(contract, log)
+}
+
+// decodeLog decodes a log emitted by a contract with its ABI. The event is
+// identified by the first topic of the log, so anonymous events cannot be
+// decoded
+func decodeLog(contract abi.ABI, log types.Log) (decodedLog, bool) {
+	if len(log.Topics) == 0 {
+		return decodedLog{}, false
+	}
+
+	for _, event := range contract.Events {
+		if event.Anonymous || event.Id() != log.Topics[0] {
+			continue
+		}
+
+		values, err := event.Inputs.UnpackValues(log.Data)
+		if err != nil {
+			return decodedLog{}, false
+		}
+
+		args := make(map[string]interface{}, len(event.Inputs))
+		topics := log.Topics[1:]
+		for i, input := range event.Inputs {
+			name := input.Name
+			if len(name) == 0 {
+				name = strconv.Itoa(i)
+			}
+
+			if !input.Indexed {
+				args[name] = abiValue(reflect.ValueOf(values[0]))
+				values = values[1:]
+				continue
+			}
+
+			if len(topics) == 0 {
+				return decodedLog{}, false
+			}
+
+			value, ok := decodeTopic(input.Type, topics[0])
+			if !ok {
+				return decodedLog{}, false
+			}
+
+			args[name] = value
+			topics = topics[1:]
+		}
+
+		return decodedLog{Event: event.Name, Args: args}, true
+	}
+
+	return decodedLog{}, false
+}
+
+// decodeTopic decodes the value of an indexed argument. Values of dynamic
+// types are kept as the hash of the value, which is what the topic holds
+func decodeTopic(t abi.Type, topic common.Hash) (interface{}, bool) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy, abi.BoolTy, abi.AddressTy, abi.FixedBytesTy:
+		values, err := abi.Arguments{{Type: t}}.UnpackValues(topic.Bytes())
+		if err != nil {
+			return nil, false
+		}
+
+		return abiValue(reflect.ValueOf(values[0])), true
+	default:
+		return topic.Hex(), true
+	}
+}
+
+// abiValue converts a value unpacked from the ABI encoding into a value
+// that keeps its precision once it is serialized to JSON. Integers are
+// converted to decimal strings, and addresses and bytes to hex strings
+func abiValue(v reflect.Value) interface{} {
+	switch value := v.Interface().(type) {
+	case *big.Int:
+		return value.String()
+	case common.Address:
+		return value.Hex()
+	case []byte:
+		return hexutil.Encode(value)
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+
+		return abiValues(v)
+	case reflect.Slice:
+		return abiValues(v)
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			fields[v.Type().Field(i).Name] = abiValue(v.Field(i))
+		}
+
+		return fields
+	default:
+		return v.Interface()
+	}
+}
+
+func abiValues(v reflect.Value) []interface{} {
+	values := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, abiValue(v.Index(i)))
+	}
+
+	return values
+}
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasislabs/oasis-gateway/errors"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+	"github.com/oasislabs/oasis-gateway/mqueue/mem"
+	"github.com/stretchr/testify/assert"
+)
+
+const testABI = `[
+	{"type": "event", "name": "Transfer", "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "value", "type": "uint256", "indexed": false}
+	]},
+	{"type": "event", "name": "Message", "inputs": [
+		{"name": "topic", "type": "string", "indexed": true},
+		{"name": "", "type": "bytes4", "indexed": false},
+		{"name": "text", "type": "string", "indexed": false}
+	]}
+]`
+
+func parseTestABI(t *testing.T) abi.ABI {
+	contract, err := abi.JSON(strings.NewReader(testABI))
+	assert.Nil(t, err)
+	return contract
+}
+
+func TestDecodeLogIndexedArgs(t *testing.T) {
+	contract := parseTestABI(t)
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(1000))
+	assert.Nil(t, err)
+
+	decoded, ok := decodeLog(contract, types.Log{
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x01"),
+			common.HexToHash("0x02"),
+		},
+		Data: data,
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, decodedLog{
+		Event: "Transfer",
+		Args: map[string]interface{}{
+			"from":  common.HexToAddress("0x01").Hex(),
+			"to":    common.HexToAddress("0x02").Hex(),
+			"value": "1000",
+		},
+	}, decoded)
+}
+
+func TestDecodeLogDynamicArgs(t *testing.T) {
+	contract := parseTestABI(t)
+	data, err := contract.Events["Message"].Inputs.NonIndexed().Pack([4]byte{1, 2, 3, 4}, "hello")
+	assert.Nil(t, err)
+
+	decoded, ok := decodeLog(contract, types.Log{
+		Topics: []common.Hash{
+			contract.Events["Message"].Id(),
+			common.HexToHash("0x03"),
+		},
+		Data: data,
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, decodedLog{
+		Event: "Message",
+		Args: map[string]interface{}{
+			"topic": common.HexToHash("0x03").Hex(),
+			"1":     "0x01020304",
+			"text":  "hello",
+		},
+	}, decoded)
+}
+
+func TestDecodeLogUnknownEvent(t *testing.T) {
+	_, ok := decodeLog(parseTestABI(t), types.Log{
+		Topics: []common.Hash{common.HexToHash("0x01")},
+	})
+
+	assert.False(t, ok)
+}
+
+func TestDecodeLogNoTopics(t *testing.T) {
+	_, ok := decodeLog(parseTestABI(t), types.Log{})
+
+	assert.False(t, ok)
+}
+
+func TestDecodeLogMissingTopics(t *testing.T) {
+	contract := parseTestABI(t)
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(1000))
+	assert.Nil(t, err)
+
+	_, ok := decodeLog(contract, types.Log{
+		Topics: []common.Hash{contract.Events["Transfer"].Id()},
+		Data:   data,
+	})
+
+	assert.False(t, ok)
+}
+
+func TestDecodeLogInvalidData(t *testing.T) {
+	contract := parseTestABI(t)
+
+	_, ok := decodeLog(contract, types.Log{
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x01"),
+			common.HexToHash("0x02"),
+		},
+	})
+
+	assert.False(t, ok)
+}
+
+func newTestABIRegistry() *abiRegistry {
+	return newABIRegistry(mem.NewServer(context.Background(), mem.Services{Logger: Logger}), Logger)
+}
+
+func TestABIRegistryDecoder(t *testing.T) {
+	registry := newTestABIRegistry()
+	decoder := registry.Decoder("session")
+	contract := parseTestABI(t)
+	log := types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics:  []common.Hash{contract.Events["Message"].Id(), common.HexToHash("0x03")},
+	}
+
+	_, ok := decoder.DecodeLog(Context, log)
+	assert.False(t, ok)
+
+	// the decoder uses the ABIs registered after it is created
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+	_, ok = registry.Decoder("other").DecodeLog(Context, log)
+	assert.False(t, ok)
+
+	data, err := contract.Events["Message"].Inputs.NonIndexed().Pack([4]byte{}, "")
+	assert.Nil(t, err)
+	log.Data = data
+
+	decoded, ok := decoder.DecodeLog(Context, log)
+	assert.True(t, ok)
+	assert.Equal(t, "Message", decoded.Event)
+}
+
+func TestABIRegistryDecoderReplaced(t *testing.T) {
+	registry := newTestABIRegistry()
+	decoder := registry.Decoder("session")
+	contract := parseTestABI(t)
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5))
+	assert.Nil(t, err)
+	log := types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x02"),
+			common.HexToHash("0x03"),
+		},
+		Data: data,
+	}
+
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+	_, ok := decoder.DecodeLog(Context, log)
+	assert.True(t, ok)
+
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), "[]"))
+	_, ok = decoder.DecodeLog(Context, log)
+	assert.False(t, ok)
+
+	abis, err := registry.list(Context, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(abis))
+}
+
+func TestABIRegistrySharedMQueue(t *testing.T) {
+	// registries that share the mqueue, like gateway instances
+	// sharing a redis mqueue, see the ABIs registered by each other
+	server := mem.NewServer(context.Background(), mem.Services{Logger: Logger})
+	registry := newABIRegistry(server, Logger)
+	other := newABIRegistry(server, Logger)
+	contract := parseTestABI(t)
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5))
+	assert.Nil(t, err)
+
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+
+	decoded, ok := other.Decoder("session").DecodeLog(Context, types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x02"),
+			common.HexToHash("0x03"),
+		},
+		Data: data,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "Transfer", decoded.Event)
+}
+
+func TestABIRegistryQueueRemoved(t *testing.T) {
+	registry := newTestABIRegistry()
+
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+	assert.Nil(t, registry.mqueue.Remove(Context, mqueue.RemoveRequest{Key: ABIID("session")}))
+
+	abis, err := registry.list(Context, "session")
+	assert.Nil(t, err)
+	assert.Empty(t, abis)
+}
+
+func TestABIRegistryRegisterLimit(t *testing.T) {
+	registry := newTestABIRegistry()
+
+	for i := 0; i < maxRegisteredABIs; i++ {
+		assert.Nil(t, registry.Register(Context, "session", common.BigToAddress(big.NewInt(int64(i))), testABI))
+	}
+
+	err := registry.Register(Context, "session", common.BigToAddress(big.NewInt(maxRegisteredABIs)), testABI)
+	assert.Equal(t, errors.ErrABILimitReached, err.ErrorCode())
+
+	// replacing an ABI or registering one for a different
+	// session is still allowed
+	assert.Nil(t, registry.Register(Context, "session", common.BigToAddress(big.NewInt(0)), testABI))
+	assert.Nil(t, registry.Register(Context, "other", common.BigToAddress(big.NewInt(0)), testABI))
+}
+
+func TestABIRegistryRegisterReplacedMany(t *testing.T) {
+	registry := newTestABIRegistry()
+	decoder := registry.Decoder("session")
+	contract := parseTestABI(t)
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5))
+	assert.Nil(t, err)
+	log := types.Log{
+		Address: common.HexToAddress("0x02"),
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x02"),
+			common.HexToHash("0x03"),
+		},
+		Data: data,
+	}
+
+	// re-registering an ABI must not exhaust the window of the
+	// queue while the ABIs of other contracts stay registered
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x02"), testABI))
+	for i := 0; i < int(maxABIElements)+10; i++ {
+		assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+	}
+
+	abis, err := registry.list(Context, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, map[common.Address]string{
+		common.HexToAddress("0x01"): testABI,
+		common.HexToAddress("0x02"): testABI,
+	}, abis)
+
+	decoded, ok := decoder.DecodeLog(Context, log)
+	assert.True(t, ok)
+	assert.Equal(t, "Transfer", decoded.Event)
+}
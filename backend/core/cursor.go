@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	stderr "errors"
+	"strconv"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+)
+
+const (
+	// cursorElementType is the type of the element that keeps the
+	// cursor committed by a client
+	cursorElementType = "cursor"
+
+	// maxRetrievedCursors is the maximum number of elements retrieved
+	// from a cursor queue. Only the last committed cursor is kept, but
+	// concurrent commits may briefly leave more than one
+	maxRetrievedCursors uint = 16
+)
+
+// cursor returns the offset of the first event that has not been
+// acknowledged in the queue identified by key. It is 0 if no events
+// have been acknowledged
+func (m *RequestManager) cursor(ctx context.Context, key string) (uint64, errors.Err) {
+	els, err := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{
+		Key:    CursorID(key),
+		Offset: 0,
+		Count:  maxRetrievedCursors,
+	})
+	if err != nil {
+		return 0, errors.New(errors.ErrQueueRetrieve, err)
+	}
+
+	var cursor uint64
+	for _, el := range els.Elements {
+		if el.Type != cursorElementType {
+			continue
+		}
+
+		offset, err := strconv.ParseUint(el.Value, 10, 64)
+		if err != nil {
+			return 0, errors.New(errors.ErrDeserializeEvent, err)
+		}
+
+		if offset > cursor {
+			cursor = offset
+		}
+	}
+
+	return cursor, nil
+}
+
+// commit acknowledges the events of the queue identified by key with an
+// offset lower than the provided one. They are discarded from the queue
+// and the offset is kept as the queue's cursor. Cursors only move forward,
+// so committing a lower offset than the current cursor has no effect
+func (m *RequestManager) commit(ctx context.Context, key string, offset uint64) errors.Err {
+	if err := m.mqueue.Discard(ctx, mqueue.DiscardRequest{Key: key, Offset: offset}); err != nil {
+		return errors.New(errors.ErrQueueDiscard, err)
+	}
+
+	cursor, err := m.cursor(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if offset <= cursor {
+		return nil
+	}
+
+	// the cursor is stored in a new element and the previous elements
+	// are discarded so that the queue does not grow with every commit
+	id := CursorID(key)
+	next, derr := m.mqueue.Next(ctx, mqueue.NextRequest{Key: id})
+	if derr != nil {
+		return errors.New(errors.ErrQueueNext, derr)
+	}
+
+	if err := m.mqueue.Insert(ctx, mqueue.InsertRequest{Key: id, Element: mqueue.Element{
+		Offset: next,
+		Type:   cursorElementType,
+		Value:  strconv.FormatUint(offset, 10),
+	}}); err != nil {
+		return errors.New(errors.ErrQueueInsert, err)
+	}
+
+	if err := m.mqueue.Discard(ctx, mqueue.DiscardRequest{Key: id, Offset: next}); err != nil {
+		return errors.New(errors.ErrQueueDiscard, err)
+	}
+
+	return nil
+}
+
+// AckService acknowledges the responses to the asynchronous requests of
+// the session up to the provided ID. The acknowledged responses are
+// discarded, and polling without an offset continues after them
+func (m *RequestManager) AckService(ctx context.Context, req AckServiceRequest) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	return m.commit(ctx, req.SessionKey, req.ID+1)
+}
+
+// AckEvent acknowledges the events of a subscription up to the provided
+// event ID. The acknowledged events are discarded, and polling the
+// subscription without an offset continues after them
+func (m *RequestManager) AckEvent(ctx context.Context, req AckEventRequest) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	subID := SubID(req.SessionKey, req.ID)
+	if !m.subman.Exists(ctx, subID) {
+		return errors.New(errors.ErrSubscriptionNotFound, stderr.New("cannot acknowledge events of subscription that does not exist"))
+	}
+
+	return m.commit(ctx, subID, req.EventID+1)
+}
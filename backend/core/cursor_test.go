@@ -0,0 +1,99 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func insertErrorEvents(t *testing.T, manager *RequestManager, key string, count int) {
+	for i := 0; i < count; i++ {
+		id, err := manager.mqueue.Next(Context, mqueue.NextRequest{Key: key})
+		assert.Nil(t, err)
+
+		el, err := makeElement(ErrorEvent{ID: id}, id)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.mqueue.Insert(Context, mqueue.InsertRequest{Key: key, Element: el}))
+	}
+}
+
+func TestAckServiceErrNoSessionKey(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	err := manager.AckService(Context, AckServiceRequest{ID: 1})
+
+	assert.Equal(t, errors.ErrInvalidKey, err.ErrorCode())
+}
+
+func TestAckServiceOK(t *testing.T) {
+	manager := createSessionRequestManager()
+	insertErrorEvents(t, manager, "session", 3)
+
+	assert.Nil(t, manager.AckService(Context, AckServiceRequest{ID: 1, SessionKey: "session"}))
+
+	evs, err := manager.PollService(Context, PollServiceRequest{
+		Count:      10,
+		FromCursor: true,
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), evs.Offset)
+	assert.Equal(t, []Event{ErrorEvent{ID: 2}}, evs.Events)
+
+	// the acknowledged events are discarded
+	evs, err = manager.PollService(Context, PollServiceRequest{
+		Offset:     0,
+		Count:      10,
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Event{ErrorEvent{ID: 2}}, evs.Events)
+}
+
+func TestAckServiceCursorOnlyMovesForward(t *testing.T) {
+	manager := createSessionRequestManager()
+	insertErrorEvents(t, manager, "session", 3)
+
+	assert.Nil(t, manager.AckService(Context, AckServiceRequest{ID: 1, SessionKey: "session"}))
+	assert.Nil(t, manager.AckService(Context, AckServiceRequest{ID: 0, SessionKey: "session"}))
+
+	cursor, err := manager.cursor(Context, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), cursor)
+
+	assert.Nil(t, manager.AckService(Context, AckServiceRequest{ID: 2, SessionKey: "session"}))
+
+	cursor, err = manager.cursor(Context, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), cursor)
+
+	els, derr := manager.mqueue.Retrieve(Context, mqueue.RetrieveRequest{
+		Key:   CursorID("session"),
+		Count: maxRetrievedCursors,
+	})
+	assert.Nil(t, derr)
+	assert.Equal(t, 1, len(els.Elements))
+}
+
+func TestPollServiceFromCursorNoCommit(t *testing.T) {
+	manager := createSessionRequestManager()
+	insertErrorEvents(t, manager, "session", 2)
+
+	evs, err := manager.PollService(Context, PollServiceRequest{
+		Count:      10,
+		FromCursor: true,
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Event{ErrorEvent{ID: 0}, ErrorEvent{ID: 1}}, evs.Events)
+}
+
+func TestAckEventErrSubscriptionNotFound(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	err := manager.AckEvent(Context, AckEventRequest{ID: 0, EventID: 1, SessionKey: "session"})
+
+	assert.Equal(t, errors.ErrSubscriptionNotFound, err.ErrorCode())
+}
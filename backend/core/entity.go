@@ -3,6 +3,7 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/oasislabs/oasis-gateway/errors"
 	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
@@ -26,6 +27,8 @@ const (
 	ExecuteServiceEventType EventType = "executeServiceEventType"
 	ErrorEventType          EventType = "errorEventType"
 	DataEventType           EventType = "dataEventType"
+	BlockEventType          EventType = "blockEventType"
+	ReceiptEventType        EventType = "receiptEventType"
 )
 
 func (t EventType) String() string {
@@ -74,6 +77,20 @@ func deserializeElement(el mqueue.Element) (Event, errors.Err) {
 			return nil, errors.New(errors.ErrDeserializeEvent, err)
 		}
 
+		return ev, nil
+	case BlockEventType:
+		var ev BlockEvent
+		if err := json.Unmarshal([]byte(el.Value), &ev); err != nil {
+			return nil, errors.New(errors.ErrDeserializeEvent, err)
+		}
+
+		return ev, nil
+	case ReceiptEventType:
+		var ev ReceiptEvent
+		if err := json.Unmarshal([]byte(el.Value), &ev); err != nil {
+			return nil, errors.New(errors.ErrDeserializeEvent, err)
+		}
+
 		return ev, nil
 	default:
 		return nil, errors.New(errors.ErrUnkownEventType, nil)
@@ -86,12 +103,31 @@ func SubID(key string, id uint64) string {
 	return fmt.Sprintf("%s:sub:%d", key, id)
 }
 
+// IdempotencyID generates the ID of the queue that keeps the ID
+// assigned to the request identified by the idempotency key within
+// the session
+func IdempotencyID(key string, idempotencyKey string) string {
+	return fmt.Sprintf("%s:idem:%s", key, idempotencyKey)
+}
+
 // SubinfoID generates the ID that uniquely identifies
 // the managed subscriptions of a session
 func SubinfoID(key string) string {
 	return fmt.Sprintf("%s:subinfo", key)
 }
 
+// ABIID generates the ID of the queue that keeps the
+// contract ABIs registered by a session
+func ABIID(key string) string {
+	return fmt.Sprintf("%s:abi", key)
+}
+
+// CursorID generates the ID of the queue that keeps the cursor
+// committed by the client for the queue identified by key
+func CursorID(key string) string {
+	return fmt.Sprintf("%s:cursor", key)
+}
+
 // ExecuteServiceRequest is is used by the user to trigger a service
 // execution. A client is always subscribed to a subscription with
 // topic "service" from which the client can retrieve the asynchronous
@@ -109,6 +145,10 @@ type ExecuteServiceRequest struct {
 
 	// Key is the identifier of the session
 	SessionKey string
+
+	// IdempotencyKey if set identifies the request within the session,
+	// so that retries of the same request are only executed once
+	IdempotencyKey string
 }
 
 // DeployServiceRequest is issued by the user to trigger a service
@@ -125,6 +165,71 @@ type DeployServiceRequest struct {
 
 	// Key is the identifier of the session
 	SessionKey string
+
+	// IdempotencyKey if set identifies the request within the session,
+	// so that retries of the same request are only executed once
+	IdempotencyKey string
+}
+
+// CallServiceRequest is issued by the user to call a service
+// without sending a transaction. The call is executed synchronously
+// and it does not modify the state of the service
+type CallServiceRequest struct {
+	// AAD is the identifier of the issuer of the call data
+	AAD string
+
+	// Data is a blob of data that the user wants to pass to the service
+	// as argument
+	Data string
+
+	// Address where the service can be found
+	Address string
+
+	// Block is the block at which the call is executed. It can be
+	// either "latest" or a block number. If not set, the latest
+	// block is used
+	Block string
+}
+
+// CallServiceResponse is the response to a CallServiceRequest
+type CallServiceResponse struct {
+	// Address is the unique address that identifies the service
+	Address string
+
+	// Output generated by the service at the end of its execution
+	Output string
+}
+
+// EstimateGasRequest is issued by the user to estimate the gas
+// required by the execution of a service, or by the deployment
+// of a service if no address is provided
+type EstimateGasRequest struct {
+	// AAD is the identifier of the issuer of the request data
+	AAD string
+
+	// Data is a blob of data that the user wants to pass to the service
+	// as argument, or the code of the service to deploy
+	Data string
+
+	// Address where the service can be found. It is empty
+	// when estimating a deployment
+	Address string
+}
+
+// EstimateGasResponse is the response to an EstimateGasRequest
+type EstimateGasResponse struct {
+	// Gas is the estimated number of gas units required
+	Gas uint64
+
+	// GasPrice is the price of a gas unit used by the gateway
+	// when sending a transaction
+	GasPrice uint64
+
+	// Fallback is true if the gas of an execution of a confidential
+	// service could not be estimated, in which case Gas is the gas
+	// the gateway provides to the transactions sent to confidential
+	// services
+	Fallback bool
 }
 
 // GetCodeRequest is a request to retrieve the code
@@ -200,6 +305,10 @@ type ExecuteServiceResponse struct {
 
 	// Output generated by the service at the end of its execution
 	Output string
+
+	// Transaction is the metadata of the transaction sent to
+	// execute the service
+	Transaction *Transaction
 }
 
 // DeployServiceResponse is the event that can be polled by the user
@@ -213,6 +322,49 @@ type DeployServiceResponse struct {
 	// is generated when a service is deployed and it can be used
 	// for service execution
 	Address string
+
+	// Transaction is the metadata of the transaction sent to
+	// deploy the service
+	Transaction *Transaction
+}
+
+// Transaction is the metadata of a transaction that has been
+// included in a block
+type Transaction struct {
+	// Hash of the transaction
+	Hash string
+
+	// BlockNumber is the number of the block in which the
+	// transaction was included
+	BlockNumber uint64
+
+	// BlockHash is the hash of the block in which the
+	// transaction was included
+	BlockHash string
+
+	// GasUsed is the amount of gas used by the transaction
+	GasUsed uint64
+
+	// From is the address of the wallet that paid for the transaction
+	From string
+
+	// Logs are the logs generated by the transaction
+	Logs []Log
+}
+
+// Log is a log generated by the execution of a transaction
+type Log struct {
+	// Address of the service that generated the log
+	Address string
+
+	// Topics of the log
+	Topics []string
+
+	// Data of the log
+	Data string
+
+	// Index of the log within the block
+	Index uint
 }
 
 // DataEvent is that event that can be polled by the user to poll
@@ -222,11 +374,61 @@ type DataEvent struct {
 	// ID to identify the event itself within the sequence of events.
 	ID uint64
 
+	// Address of the service that emitted the event
+	Address string
+
 	// Data is the blob of data related to this event
 	Data string
 
 	// Topics is the list of topics to which this event refers
 	Topics []string
+
+	// Event is the name of the event that emitted the log. It is only
+	// set if the session registered the ABI of the service
+	Event string
+
+	// Args are the indexed and non-indexed arguments of the event by
+	// name. They are only set if the session registered the ABI of
+	// the service
+	Args map[string]interface{}
+}
+
+// BlockEvent is the event delivered by a subscription to new blocks
+// every time a block is added to the chain
+type BlockEvent struct {
+	// ID to identify the event itself within the sequence of events.
+	ID uint64
+
+	// Number of the block
+	Number uint64
+
+	// Hash of the block
+	Hash string
+
+	// ParentHash is the hash of the block's parent
+	ParentHash string
+
+	// Timestamp at which the block was created in seconds since
+	// the unix epoch
+	Timestamp uint64
+}
+
+// ReceiptEvent is the event delivered by a subscription to receipts
+// every time a transaction sent to one of the subscribed addresses is
+// included in a block
+type ReceiptEvent struct {
+	// ID to identify the event itself within the sequence of events.
+	ID uint64
+
+	// Address of the service the transaction was sent to
+	Address string
+
+	// Status of the transaction, which is 1 if the transaction
+	// succeeded and 0 otherwise
+	Status uint64
+
+	// Transaction is the metadata of the transaction
+	Transaction Transaction
 }
 
 // EventID is the implementation of Event for ExecuteServiceResponse
@@ -269,6 +471,26 @@ func (e DataEvent) EventType() EventType {
 	return DataEventType
 }
 
+// EventID is the implementation of rpc.Event for BlockEvent
+func (e BlockEvent) EventID() uint64 {
+	return e.ID
+}
+
+// EventType is the implementation of Event for BlockEvent
+func (e BlockEvent) EventType() EventType {
+	return BlockEventType
+}
+
+// EventID is the implementation of rpc.Event for ReceiptEvent
+func (e ReceiptEvent) EventID() uint64 {
+	return e.ID
+}
+
+// EventType is the implementation of Event for ReceiptEvent
+func (e ReceiptEvent) EventType() EventType {
+	return ReceiptEventType
+}
+
 // PollServiceRequest is a request issued by a client to
 // retrieve a window of responses generated by
 // asynchronous requests
@@ -286,6 +508,100 @@ type PollServiceRequest struct {
 	// discard all the events that have a sequence number lower than the offer
 	DiscardPrevious bool
 
+	// FromCursor is true if the events are provided from the cursor
+	// committed by the client instead of from Offset
+	FromCursor bool
+
+	// Wait is the maximum amount of time the request waits for events
+	// to be available if there are none at the time of the request
+	Wait time.Duration
+
+	// Key is the identifier of the request issuer
+	SessionKey string
+}
+
+// AckServiceRequest is a request issued by the client to acknowledge
+// the responses to the asynchronous requests it has already processed
+type AckServiceRequest struct {
+	// ID of the last event the client has processed. The events with
+	// this ID or a lower one are discarded
+	ID uint64
+
+	// Key is the identifier of the request issuer
+	SessionKey string
+}
+
+// WaitServiceRequest is a request to wait until the response
+// to an asynchronous request is available
+type WaitServiceRequest struct {
+	// ID of the asynchronous request
+	ID uint64
+
+	// Key is the identifier of the request issuer
+	SessionKey string
+}
+
+// GetServiceStatusRequest is a request to retrieve the status
+// of an asynchronous request
+type GetServiceStatusRequest struct {
+	// ID of the asynchronous request
+	ID uint64
+
+	// Key is the identifier of the request issuer
+	SessionKey string
+}
+
+// CancelServiceRequest is a request to cancel an asynchronous
+// request that is still being handled
+type CancelServiceRequest struct {
+	// ID of the asynchronous request
+	ID uint64
+
+	// Key is the identifier of the request issuer
+	SessionKey string
+}
+
+// RequestStatus is the status of an asynchronous request
+type RequestStatus string
+
+const (
+	// RequestStatusPending is the status of a request that has been
+	// accepted but whose response is not available yet
+	RequestStatusPending RequestStatus = "pending"
+
+	// RequestStatusCompleted is the status of a request that completed
+	// successfully
+	RequestStatusCompleted RequestStatus = "completed"
+
+	// RequestStatusFailed is the status of a request that completed
+	// with an error
+	RequestStatusFailed RequestStatus = "failed"
+
+	// RequestStatusUnknown is the status of a request that has never
+	// been issued or whose response has already been discarded or
+	// has expired
+	RequestStatusUnknown RequestStatus = "unknown"
+)
+
+// GetServiceStatusResponse is the status of an asynchronous request
+type GetServiceStatusResponse struct {
+	// Status of the request
+	Status RequestStatus
+
+	// Event is the response to the request. It is only set if the
+	// request has completed or failed
+	Event Event
+}
+
+// StreamServiceRequest is a request issued by a client to receive
+// the responses generated by asynchronous requests as they become
+// available
+type StreamServiceRequest struct {
+	// Offset at which events need to be provided. Events are all ordered
+	// with sequence numbers and it is up to the client to specify from
+	// which offset in the sequence it wants to receive events
+	Offset uint64
+
 	// Key is the identifier of the request issuer
 	SessionKey string
 }
@@ -297,16 +613,22 @@ type SubscribeRequest struct {
 	// Event is the subscription event to subscribe to
 	Event string
 
-	// Address will be used to filter events only issues by or to
-	// the address
-	Address string
+	// Addresses will be used to filter events only issued by
+	// any of the addresses
+	Addresses []string
 
 	// Key is the identifier of the session
 	SessionKey string
 
-	// Topics is the list of topics the subscription client is
-	// interested in
-	Topics []string
+	// Topics is the list of topic sets the subscription client is
+	// interested in. Each set matches the topic at the same position
+	// if it is any of the topics in the set. An empty set matches
+	// any topic
+	Topics [][]string
+
+	// CallbackURL is optional. If set, the events of the subscription
+	// are delivered to the URL in order and discarded once delivered
+	CallbackURL string
 }
 
 // PollEventRequest is a request issued by the client to
@@ -325,6 +647,46 @@ type PollEventRequest struct {
 	// discard all the events that have a sequence number lower than the offer
 	DiscardPrevious bool
 
+	// FromCursor is true if the events are provided from the cursor
+	// committed by the client instead of from Offset
+	FromCursor bool
+
+	// Wait is the maximum amount of time the request waits for events
+	// to be available if there are none at the time of the request
+	Wait time.Duration
+
+	// ID is the unique identifier for a subscription based on
+	// the user's key namespace
+	ID uint64
+
+	// Key is the identifier of the session
+	SessionKey string
+}
+
+// AckEventRequest is a request issued by the client to acknowledge
+// the events of a subscription it has already processed
+type AckEventRequest struct {
+	// ID is the unique identifier for a subscription based on
+	// the user's key namespace
+	ID uint64
+
+	// EventID is the ID of the last event the client has processed.
+	// The events with this ID or a lower one are discarded
+	EventID uint64
+
+	// Key is the identifier of the session
+	SessionKey string
+}
+
+// StreamEventRequest is a request issued by the client to receive
+// the events from an already created subscription as they become
+// available
+type StreamEventRequest struct {
+	// Offset at which events need to be provided. Events are all ordered
+	// with sequence numbers and it is up to the client to specify from
+	// which offset in the sequence it wants to receive events
+	Offset uint64
+
 	// ID is the unique identifier for a subscription based on
 	// the user's key namespace
 	ID uint64
@@ -345,21 +707,120 @@ type UnsubscribeRequest struct {
 	SessionKey string
 }
 
+// ListSubscriptionsRequest is a request issued by the client to find
+// out the subscriptions that are active for its session
+type ListSubscriptionsRequest struct {
+	// Key is the identifier of the session
+	SessionKey string
+}
+
+// Subscription describes an active subscription of a session
+type Subscription struct {
+	// ID is the unique identifier for a subscription based on
+	// the user's key namespace
+	ID uint64
+
+	// Event is the subscription event type
+	Event string
+
+	// Addresses the subscription filters events by
+	Addresses []string
+
+	// Topics is the list of topic sets the subscription filters
+	// events by
+	Topics [][]string
+
+	// CallbackURL is the URL the events of the subscription are
+	// delivered to, if any
+	CallbackURL string
+
+	// CreatedAt is the time at which the subscription was created
+	CreatedAt time.Time
+
+	// Offset is the offset of the first event the subscription
+	// still keeps. A client that has lost track of a subscription
+	// can poll the subscription from this offset
+	Offset uint64
+}
+
+// ListSubscriptionsResponse is the response to a ListSubscriptionsRequest
+type ListSubscriptionsResponse struct {
+	// Subscriptions are the active subscriptions ordered by ID
+	Subscriptions []Subscription
+}
+
+// RegisterABIRequest is a request issued by the client to register
+// the ABI of a service so that the logs the service emits are
+// decoded for the session's subscriptions
+type RegisterABIRequest struct {
+	// Key is the identifier of the session
+	SessionKey string
+
+	// Address of the service
+	Address string
+
+	// ABI is the JSON encoded ABI of the service
+	ABI string
+}
+
+// OpenSessionRequest is a request issued by the client to open a
+// session that is closed once its TTL elapses
+type OpenSessionRequest struct {
+	// Key is the identifier of the session
+	SessionKey string
+
+	// TTL is the amount of time the session is kept open without
+	// being refreshed. If it is not set the default TTL is used
+	TTL time.Duration
+}
+
+// OpenSessionResponse is the response to an OpenSessionRequest
+type OpenSessionResponse struct {
+	// ExpiresAt is the time at which the session is closed unless
+	// it is refreshed
+	ExpiresAt time.Time
+}
+
+// RefreshSessionRequest is a request issued by the client to extend
+// the TTL of an open session
+type RefreshSessionRequest struct {
+	// Key is the identifier of the session
+	SessionKey string
+
+	// TTL is the amount of time the session is kept open from the
+	// time of the refresh. If it is not set the default TTL is used
+	TTL time.Duration
+}
+
+// RefreshSessionResponse is the response to a RefreshSessionRequest
+type RefreshSessionResponse struct {
+	// ExpiresAt is the time at which the session is closed unless
+	// it is refreshed again
+	ExpiresAt time.Time
+}
+
+// CloseSessionRequest is a request issued by the client to close a
+// session and free all its resources
+type CloseSessionRequest struct {
+	// Key is the identifier of the session
+	SessionKey string
+}
+
 // CreateSubscriptionRequest is the request to subscribe to a specific
 // event type for a contract
 type CreateSubscriptionRequest struct {
 	// Event is the subscription event type
 	Event string
 
-	// Address will be used to filter events only issues by or to
-	// the address
-	Address string
+	// Addresses will be used to filter events only issued by
+	// any of the addresses
+	Addresses []string
 
 	// SubID is the unique subscription's identifier
 	SubID string
 
-	// Topics is the list of topics the client is interested in
-	Topics []string
+	// Topics is the list of topic sets the client is interested in
+	Topics [][]string
 }
 
 // UnsubscribeRequest is a request issued by the client to destroy
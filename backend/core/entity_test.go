@@ -23,3 +23,34 @@ func TestDeserializeDataElement(t *testing.T) {
 		Description: "Internal Error. Please check the status of the service.",
 	}, ev.Cause)
 }
+
+func TestDeserializeBlockElement(t *testing.T) {
+	el, err := makeElement(BlockEvent{ID: 1, Number: 2, Hash: "0x03", ParentHash: "0x04", Timestamp: 5}, 1)
+	assert.Nil(t, err)
+
+	ev, derr := deserializeElement(el)
+	assert.Nil(t, derr)
+	assert.Equal(t, BlockEvent{ID: 1, Number: 2, Hash: "0x03", ParentHash: "0x04", Timestamp: 5}, ev)
+}
+
+func TestDeserializeReceiptElement(t *testing.T) {
+	receipt := ReceiptEvent{
+		ID:      1,
+		Address: "0x02",
+		Status:  1,
+		Transaction: Transaction{
+			Hash:        "0x03",
+			BlockNumber: 4,
+			BlockHash:   "0x05",
+			GasUsed:     6,
+			From:        "0x07",
+			Logs:        []Log{{Address: "0x02", Topics: []string{"0x08"}, Data: "0x09", Index: 10}},
+		},
+	}
+	el, err := makeElement(receipt, 1)
+	assert.Nil(t, err)
+
+	ev, derr := deserializeElement(el)
+	assert.Nil(t, derr)
+	assert.Equal(t, receipt, ev)
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightRequests keeps track of the asynchronous requests that are
+// still being handled so that they can be cancelled
+type inflightRequests struct {
+	mutex    sync.Mutex
+	requests map[string]map[uint64]context.CancelFunc
+	len      int
+}
+
+func newInflightRequests() *inflightRequests {
+	return &inflightRequests{requests: make(map[string]map[uint64]context.CancelFunc)}
+}
+
+// Add registers the function that cancels the request identified
+// by the session key and the ID
+func (r *inflightRequests) Add(key string, id uint64, cancel context.CancelFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	requests, ok := r.requests[key]
+	if !ok {
+		requests = make(map[uint64]context.CancelFunc)
+		r.requests[key] = requests
+	}
+
+	if _, ok := requests[id]; !ok {
+		r.len++
+	}
+
+	requests[id] = cancel
+}
+
+// Remove stops tracking a request once it has been handled
+func (r *inflightRequests) Remove(key string, id uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.remove(key, id)
+}
+
+func (r *inflightRequests) remove(key string, id uint64) {
+	requests := r.requests[key]
+	if _, ok := requests[id]; !ok {
+		return
+	}
+
+	delete(requests, id)
+	r.len--
+	if len(requests) == 0 {
+		delete(r.requests, key)
+	}
+}
+
+// Cancel cancels the context of the request identified by the session
+// key and the ID. It returns false if the request is not being handled
+func (r *inflightRequests) Cancel(key string, id uint64) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cancel, ok := r.requests[key][id]
+	if !ok {
+		return false
+	}
+
+	cancel()
+	r.remove(key, id)
+	return true
+}
+
+// CancelSession cancels the contexts of all the requests of the
+// session identified by key
+func (r *inflightRequests) CancelSession(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for id, cancel := range r.requests[key] {
+		cancel()
+		r.remove(key, id)
+	}
+}
+
+// Len returns the number of requests being handled
+func (r *inflightRequests) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.len
+}
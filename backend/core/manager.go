@@ -2,9 +2,18 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	stderr "errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/log"
 	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
@@ -19,21 +28,58 @@ type Client interface {
 	Stats() stats.Metrics
 	GetCode(context.Context, GetCodeRequest) (GetCodeResponse, errors.Err)
 	GetPublicKey(context.Context, GetPublicKeyRequest) (GetPublicKeyResponse, errors.Err)
+	CallService(context.Context, CallServiceRequest) (CallServiceResponse, errors.Err)
+	EstimateGas(context.Context, EstimateGasRequest) (EstimateGasResponse, errors.Err)
 	ExecuteService(context.Context, uint64, ExecuteServiceRequest) (ExecuteServiceResponse, errors.Err)
 	DeployService(context.Context, uint64, DeployServiceRequest) (DeployServiceResponse, errors.Err)
 	SubscribeRequest(context.Context, CreateSubscriptionRequest, chan<- interface{}) errors.Err
 	UnsubscribeRequest(context.Context, DestroySubscriptionRequest) errors.Err
 }
 
+const (
+	// streamBatchSize is the maximum number of events retrieved from
+	// the queue at once when streaming events to a client
+	streamBatchSize uint = 64
+
+	// pollInterval is the maximum amount of time a request waits for
+	// a notification before checking the queue for new events, so that
+	// a lost notification does not stall a waiting request
+	pollInterval = 1 * time.Second
+
+	// idempotencyWaitTimeout is the maximum amount of time a retried
+	// request waits for the ID assigned to the original request when
+	// both are handled concurrently
+	idempotencyWaitTimeout = 5 * time.Second
+
+	// idempotencyElementType is the type of the element that keeps the
+	// ID assigned to a request with an idempotency key
+	idempotencyElementType = "idempotencyID"
+
+	// subinfoElementType is the type of the element that keeps the
+	// description of a subscription in the subinfo queue of a session
+	subinfoElementType = "subinfo"
+
+	// maxListedSubscriptions is the maximum number of subscriptions
+	// of a session that are retrieved when listing them. It matches
+	// the default maximum size of a queue
+	maxListedSubscriptions uint = 1024
+)
+
 // RequestManager handles the client RPC requests. Most requests
 // are asynchronous and they are handled by returning an identifier
 // that the caller can later on query to find out the outcome
 // of the request.
 type RequestManager struct {
-	mqueue mqueue.MQueue
-	client Client
-	logger log.Logger
-	subman *SubscriptionManager
+	mqueue   mqueue.MQueue
+	client   Client
+	logger   log.Logger
+	subman   *SubscriptionManager
+	inflight *inflightRequests
+	abis     *abiRegistry
+	webhooks *webhooks
+	sessions *sessions
+
+	webhookClient WebhookClient
 }
 
 func (r *RequestManager) Name() string {
@@ -43,6 +89,9 @@ func (r *RequestManager) Name() string {
 func (r *RequestManager) Stats() stats.Metrics {
 	return stats.Metrics{
 		"subscriptions": r.subman.Stats(),
+		"inflight":      r.inflight.Len(),
+		"webhooks":      r.webhooks.Len(),
+		"sessions":      r.sessions.Len(),
 	}
 }
 
@@ -50,6 +99,11 @@ type RequestManagerProperties struct {
 	MQueue mqueue.MQueue
 	Client Client
 	Logger log.Logger
+
+	// Webhooks is optional and it is used to deliver the events
+	// of subscriptions to their callback URLs. If it is not set,
+	// subscriptions with a callback URL are not supported
+	Webhooks WebhookClient
 }
 
 // NewRequestManager creates a new instance of a request manager
@@ -75,6 +129,12 @@ func NewRequestManager(properties RequestManagerProperties) *RequestManager {
 			Logger:  properties.Logger,
 			MQueue:  properties.MQueue,
 		}),
+		inflight: newInflightRequests(),
+		abis:     newABIRegistry(properties.MQueue, properties.Logger),
+		webhooks: newWebhooks(),
+		sessions: newSessions(),
+
+		webhookClient: properties.Webhooks,
 	}
 }
 
@@ -102,6 +162,28 @@ func (m *RequestManager) GetPublicKey(
 	return m.client.GetPublicKey(ctx, req)
 }
 
+// CallService calls a service without sending a transaction and
+// returns the output of the call
+func (m *RequestManager) CallService(
+	ctx context.Context,
+	req CallServiceRequest,
+) (CallServiceResponse, errors.Err) {
+	if len(req.Address) == 0 {
+		return CallServiceResponse{}, errors.New(errors.ErrInvalidAddress, nil)
+	}
+
+	return m.client.CallService(ctx, req)
+}
+
+// EstimateGas estimates the gas required to execute a service, or to
+// deploy a service if the request has no address
+func (m *RequestManager) EstimateGas(
+	ctx context.Context,
+	req EstimateGasRequest,
+) (EstimateGasResponse, errors.Err) {
+	return m.client.EstimateGas(ctx, req)
+}
+
 // RequestManager starts a request and provides an identifier for the caller to
 // find the request later on. Executes an operation on a service
 func (m *RequestManager) ExecuteServiceAsync(
@@ -112,12 +194,17 @@ func (m *RequestManager) ExecuteServiceAsync(
 		return 0, errors.New(errors.ErrInvalidAddress, nil)
 	}
 
-	id, err := m.mqueue.Next(ctx, mqueue.NextRequest{Key: req.SessionKey})
+	id, ok, err := m.next(ctx, req.SessionKey, req.IdempotencyKey,
+		requestFingerprint("Execute", req.Address, req.Data))
 	if err != nil {
-		return 0, errors.New(errors.ErrQueueNext, err)
+		return 0, err
 	}
 
-	go m.doRequest(ctx, req.SessionKey, id, func() (Event, errors.Err) { return m.client.ExecuteService(ctx, id, req) })
+	if ok {
+		m.startRequest(ctx, req.SessionKey, id, func(ctx context.Context) (Event, errors.Err) {
+			return m.client.ExecuteService(ctx, id, req)
+		})
+	}
 
 	return id, nil
 }
@@ -125,16 +212,180 @@ func (m *RequestManager) ExecuteServiceAsync(
 // RequestManager starts a request and provides an identifier for the caller to
 // find the request later on. Deploys a new service
 func (m *RequestManager) DeployServiceAsync(ctx context.Context, req DeployServiceRequest) (uint64, errors.Err) {
-	id, err := m.mqueue.Next(ctx, mqueue.NextRequest{Key: req.SessionKey})
+	id, ok, err := m.next(ctx, req.SessionKey, req.IdempotencyKey,
+		requestFingerprint("Deploy", req.Data))
 	if err != nil {
-		return 0, errors.New(errors.ErrQueueNext, err)
+		return 0, err
 	}
 
-	go m.doRequest(ctx, req.SessionKey, id, func() (Event, errors.Err) { return m.client.DeployService(ctx, id, req) })
+	if ok {
+		m.startRequest(ctx, req.SessionKey, id, func(ctx context.Context) (Event, errors.Err) {
+			return m.client.DeployService(ctx, id, req)
+		})
+	}
 
 	return id, nil
 }
 
+// next reserves the ID for a new asynchronous request in the session's
+// queue. If the request has an idempotency key and an ID has already been
+// assigned to a request with the same key, that ID is returned instead and
+// ok is false, so that the caller does not start the request again. The
+// fingerprint identifies the contents of the request, so that a key
+// cannot be reused for a different request
+func (m *RequestManager) next(
+	ctx context.Context,
+	sessionKey, idempotencyKey, fingerprint string,
+) (uint64, bool, errors.Err) {
+	if len(idempotencyKey) == 0 {
+		id, err := m.mqueue.Next(ctx, mqueue.NextRequest{Key: sessionKey})
+		if err != nil {
+			return 0, false, errors.New(errors.ErrQueueNext, err)
+		}
+
+		return id, true, nil
+	}
+
+	key := IdempotencyID(sessionKey, idempotencyKey)
+
+	// the queue is removed along with the session if the
+	// session is closed before it expires
+	m.sessions.AddIdempotencyKey(sessionKey, key)
+
+	// retries find the queue of the key and do not reserve any element
+	// in it, so that the queue does not grow with every retry
+	exists, derr := m.mqueue.Exists(ctx, mqueue.ExistsRequest{Key: key})
+	if derr != nil {
+		return 0, false, errors.New(errors.ErrQueueExists, derr)
+	}
+
+	if exists {
+		id, err := m.idempotentID(ctx, key, fingerprint)
+		return id, false, err
+	}
+
+	// the first request with the idempotency key is the one that reserves
+	// the first element of the queue, where the assigned ID is stored. The
+	// queue expires with the rest of the session's resources
+	offset, derr := m.mqueue.Next(ctx, mqueue.NextRequest{Key: key})
+	if derr != nil {
+		return 0, false, errors.New(errors.ErrQueueNext, derr)
+	}
+
+	if offset > 0 {
+		// a request with the same key reserved the first element
+		// concurrently. The reserved element is not needed, so it is
+		// discarded to keep the queue from growing
+		if err := m.mqueue.Discard(ctx, mqueue.DiscardRequest{
+			KeepPrevious: true,
+			Count:        1,
+			Offset:       offset,
+			Key:          key,
+		}); err != nil {
+			m.logger.Warn(ctx, "failed to discard idempotency element", log.MapFields{
+				"call_type": "IdempotencyDiscardFailure",
+				"key":       key,
+				"err":       err.Error(),
+			})
+		}
+
+		id, err := m.idempotentID(ctx, key, fingerprint)
+		return id, false, err
+	}
+
+	id, derr := m.mqueue.Next(ctx, mqueue.NextRequest{Key: sessionKey})
+	if derr != nil {
+		m.removeIdempotencyKey(ctx, key)
+		return 0, false, errors.New(errors.ErrQueueNext, derr)
+	}
+
+	if err := m.mqueue.Insert(ctx, mqueue.InsertRequest{Key: key, Element: mqueue.Element{
+		Offset: 0,
+		Type:   idempotencyElementType,
+		Value:  strconv.FormatUint(id, 10) + ":" + fingerprint,
+	}}); err != nil {
+		m.removeIdempotencyKey(ctx, key)
+		return 0, false, errors.New(errors.ErrQueueInsert, err)
+	}
+
+	return id, true, nil
+}
+
+// requestFingerprint returns a digest of the fields that identify the
+// contents of an asynchronous request
+func requestFingerprint(api string, fields ...string) string {
+	h := sha256.New()
+	for _, field := range append([]string{api}, fields...) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		_, _ = h.Write(length[:])
+		_, _ = h.Write([]byte(field))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// removeIdempotencyKey removes the queue for an idempotency key for which
+// no request could be started, so that a retry can start it
+func (m *RequestManager) removeIdempotencyKey(ctx context.Context, key string) {
+	if err := m.mqueue.Remove(ctx, mqueue.RemoveRequest{Key: key}); err != nil {
+		m.logger.Warn(ctx, "failed to remove idempotency key", log.MapFields{
+			"call_type": "IdempotencyRemoveFailure",
+			"key":       key,
+			"err":       err.Error(),
+		})
+	}
+}
+
+// idempotentID retrieves the ID assigned to the first request with the
+// idempotency key. If the first request is still being handled it waits
+// until the ID is available. It fails if the first request does not have
+// the same fingerprint
+func (m *RequestManager) idempotentID(ctx context.Context, key, fingerprint string) (uint64, errors.Err) {
+	ctx, cancelTimeout := context.WithTimeout(ctx, idempotencyWaitTimeout)
+	defer cancelTimeout()
+
+	for {
+		// start watching before retrieving so that no insertion
+		// is missed in between
+		notifyC, cancel, err := m.watch(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		els, derr := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{Key: key, Offset: 0, Count: 1})
+		if derr != nil {
+			cancel()
+			return 0, errors.New(errors.ErrQueueRetrieve, derr)
+		}
+
+		for _, el := range els.Elements {
+			if el.Offset != 0 || el.Type != idempotencyElementType {
+				continue
+			}
+
+			cancel()
+			value := strings.SplitN(el.Value, ":", 2)
+			id, derr := strconv.ParseUint(value[0], 10, 64)
+			if derr != nil {
+				return 0, errors.New(errors.ErrDeserializeEvent, derr)
+			}
+
+			if len(value) > 1 && value[1] != fingerprint {
+				return 0, errors.New(errors.ErrIdempotencyKeyReused, nil)
+			}
+
+			return id, nil
+		}
+
+		ok := wait(ctx, notifyC)
+		cancel()
+		if !ok {
+			return 0, errors.New(errors.ErrIdempotencyKeyInProgress, nil)
+		}
+	}
+}
+
 // Unsubscribe from an existing subscription freeing all the associated
 // resources. After this operation all events from the subscription stream
 // will be lost.
@@ -165,7 +416,12 @@ func (m *RequestManager) Unsubscribe(ctx context.Context, req UnsubscribeRequest
 		return err
 	}
 
-	return m.subman.Destroy(ctx, subID)
+	m.webhooks.Cancel(subID)
+	if err := m.subman.Destroy(ctx, subID); err != nil {
+		return err
+	}
+
+	return m.removeQueue(ctx, CursorID(subID))
 }
 
 // Subscribe creates a new subscription using the underlying backend and
@@ -175,6 +431,20 @@ func (m *RequestManager) Subscribe(ctx context.Context, req SubscribeRequest) (u
 		return 0, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
 	}
 
+	if len(req.CallbackURL) > 0 {
+		if m.webhookClient == nil {
+			return 0, errors.New(errors.ErrAPINotImplemented, stderr.New("callback urls are not supported"))
+		}
+
+		if !validateCallbackURL(req.CallbackURL) {
+			return 0, errors.New(errors.ErrInvalidCallbackURL, nil)
+		}
+
+		if err := m.webhookClient.ValidateURL(ctx, req.CallbackURL); err != nil {
+			return 0, errors.New(errors.ErrInvalidCallbackURL, err)
+		}
+	}
+
 	// use a queue per subscription to manage the number of queues created. This
 	// also helps us with managing the resources a specific client is using
 	key := SubinfoID(req.SessionKey)
@@ -183,27 +453,132 @@ func (m *RequestManager) Subscribe(ctx context.Context, req SubscribeRequest) (u
 		return 0, errors.New(errors.ErrQueueNext, err)
 	}
 
+	// the description of the subscription is kept at the subscription's
+	// offset so that the subscriptions of a session can be listed
+	p, derr := json.Marshal(Subscription{
+		ID:          id,
+		Event:       req.Event,
+		Addresses:   req.Addresses,
+		Topics:      req.Topics,
+		CallbackURL: req.CallbackURL,
+		CreatedAt:   time.Now(),
+	})
+	if derr != nil {
+		return 0, errors.New(errors.ErrInternalError, derr)
+	}
+
+	if err := m.mqueue.Insert(ctx, mqueue.InsertRequest{Key: key, Element: mqueue.Element{
+		Offset: id,
+		Type:   subinfoElementType,
+		Value:  string(p),
+	}}); err != nil {
+		return 0, errors.New(errors.ErrQueueInsert, err)
+	}
+
 	if err := m.subscribe(ctx, id, req); err != nil {
+		m.discardSubinfo(ctx, key, id)
 		return 0, err
 	}
 
+	if len(req.CallbackURL) > 0 {
+		m.startWebhook(SubID(req.SessionKey, id), req.CallbackURL)
+	}
+
 	return id, nil
 }
 
+// discardSubinfo discards the description of a subscription that
+// could not be created
+func (m *RequestManager) discardSubinfo(ctx context.Context, key string, id uint64) {
+	if err := m.mqueue.Discard(ctx, mqueue.DiscardRequest{
+		KeepPrevious: true,
+		Count:        1,
+		Offset:       id,
+		Key:          key,
+	}); err != nil {
+		m.logger.Warn(ctx, "failed to discard subscription info", log.MapFields{
+			"call_type": "SubinfoDiscardFailure",
+			"key":       key,
+			"id":        id,
+			"err":       err.Error(),
+		})
+	}
+}
+
+// ListSubscriptions returns the subscriptions that are active for
+// the session
+func (m *RequestManager) ListSubscriptions(
+	ctx context.Context,
+	req ListSubscriptionsRequest,
+) (ListSubscriptionsResponse, errors.Err) {
+	if len(req.SessionKey) == 0 {
+		return ListSubscriptionsResponse{}, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	els, err := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{
+		Key:    SubinfoID(req.SessionKey),
+		Offset: 0,
+		Count:  maxListedSubscriptions,
+	})
+	if err != nil {
+		return ListSubscriptionsResponse{}, errors.New(errors.ErrQueueRetrieve, err)
+	}
+
+	subscriptions := make([]Subscription, 0, len(els.Elements))
+	for _, el := range els.Elements {
+		if el.Type != subinfoElementType {
+			continue
+		}
+
+		var sub Subscription
+		if err := json.Unmarshal([]byte(el.Value), &sub); err != nil {
+			return ListSubscriptionsResponse{}, errors.New(errors.ErrDeserializeEvent, err)
+		}
+
+		// subscriptions that have already been closed are kept
+		// in the subinfo queue until they are polled
+		subID := SubID(req.SessionKey, sub.ID)
+		if !m.subman.Exists(ctx, subID) {
+			continue
+		}
+
+		offset, err := m.offset(ctx, subID)
+		if err != nil {
+			return ListSubscriptionsResponse{}, err
+		}
+
+		sub.Offset = offset
+		subscriptions = append(subscriptions, sub)
+	}
+
+	return ListSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+// offset returns the offset of the first element kept by the
+// queue identified by key
+func (m *RequestManager) offset(ctx context.Context, key string) (uint64, errors.Err) {
+	els, err := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{Key: key, Offset: 0, Count: 1})
+	if err != nil {
+		return 0, errors.New(errors.ErrQueueRetrieve, err)
+	}
+
+	return els.Offset, nil
+}
+
 func (m *RequestManager) subscribe(ctx context.Context, id uint64, req SubscribeRequest) errors.Err {
 	subID := SubID(req.SessionKey, id)
 	// TODO(stan): a request manager should have a context from which the subscription contexts
 	// should derive
 	c := make(chan interface{}, 64)
-	if err := m.subman.Create(ctx, subID, c); err != nil {
+	if err := m.subman.Create(ctx, subID, c, m.abis.Decoder(req.SessionKey)); err != nil {
 		return err
 	}
 
 	if err := m.client.SubscribeRequest(ctx, CreateSubscriptionRequest{
-		Event:   req.Event,
-		Address: req.Address,
-		SubID:   subID,
-		Topics:  req.Topics,
+		Event:     req.Event,
+		Addresses: req.Addresses,
+		SubID:     subID,
+		Topics:    req.Topics,
 	}, c); err != nil {
 		return err
 	}
@@ -211,6 +586,62 @@ func (m *RequestManager) subscribe(ctx context.Context, id uint64, req Subscribe
 	return nil
 }
 
+// RegisterABI registers the ABI of a service for the session. The logs
+// emitted by the service are then decoded for all the subscriptions of
+// the session, including those that already exist
+func (m *RequestManager) RegisterABI(ctx context.Context, req RegisterABIRequest) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	if !common.IsHexAddress(req.Address) {
+		return errors.New(errors.ErrInvalidAddress, nil)
+	}
+
+	if _, err := abi.JSON(strings.NewReader(req.ABI)); err != nil {
+		return errors.New(errors.ErrInvalidABI, err)
+	}
+
+	return m.abis.Register(ctx, req.SessionKey, common.HexToAddress(req.Address), req.ABI)
+}
+
+// CancelService cancels an asynchronous request that is still being
+// handled. Cancellation only takes effect if the request has not yet
+// sent its transaction, in which case the response to the request is
+// an ErrorEvent with ErrRequestCancelled. Otherwise the request
+// completes as it would have without the cancellation
+func (m *RequestManager) CancelService(ctx context.Context, req CancelServiceRequest) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	if !m.inflight.Cancel(req.SessionKey, req.ID) {
+		return errors.New(errors.ErrRequestNotFound, nil)
+	}
+
+	return nil
+}
+
+// startRequest registers the asynchronous request identified by the ID so
+// that it can be cancelled and starts handling it in the background
+func (m *RequestManager) startRequest(
+	ctx context.Context,
+	key string,
+	id uint64,
+	fn func(context.Context) (Event, errors.Err),
+) {
+	reqCtx, cancel := context.WithCancel(ctx)
+	m.inflight.Add(key, id, cancel)
+
+	go m.doRequest(ctx, key, id, func() (Event, errors.Err) {
+		// the request stops being tracked before its response is
+		// inserted so that it cannot be cancelled once it has completed
+		defer cancel()
+		defer m.inflight.Remove(key, id)
+		return fn(reqCtx)
+	})
+}
+
 func (m *RequestManager) doRequest(ctx context.Context, key string, id uint64, fn func() (Event, errors.Err)) {
 	// TODO(stan): we should handle the case in which the request takes too long
 	ev, err := fn()
@@ -229,25 +660,230 @@ func (m *RequestManager) doRequest(ctx context.Context, key string, id uint64, f
 		panic(fmt.Sprintf("failed to marshal event %s", derr.Error()))
 	}
 
+	// the insertion fails if the session has been closed or its queue
+	// has expired while the request was being handled, in which case
+	// there is no client left to deliver the response to
 	if err := m.mqueue.Insert(ctx, mqueue.InsertRequest{Key: key, Element: el}); err != nil {
-		panic(fmt.Sprintf("failed to insert event %s", err.Error()))
+		m.logger.Warn(ctx, "failed to insert event, response is dropped", log.MapFields{
+			"call_type": "InsertEventFailure",
+			"id":        id,
+			"err":       err.Error(),
+		})
 	}
 }
 
 // PollService retrieves the responses the RequestManager already got
 // from the asynchronous requests.
 func (m *RequestManager) PollService(ctx context.Context, req PollServiceRequest) (Events, errors.Err) {
-	events, err := m.poll(ctx, req.SessionKey, req.Offset, req.Count, req.DiscardPrevious)
+	offset := req.Offset
+	if req.FromCursor {
+		cursor, err := m.cursor(ctx, req.SessionKey)
+		if err != nil {
+			return Events{}, err
+		}
+
+		offset = cursor
+	}
+
+	events, err := m.pollWait(ctx, req.SessionKey, offset, req.Count, req.DiscardPrevious, req.Wait)
 	return events, err
 }
 
+// StreamService delivers to the provided channel the responses the
+// RequestManager got from the asynchronous requests starting at the
+// requested offset, and keeps delivering new responses as they become
+// available until the context is cancelled. Responses are delivered
+// in the order in which they become available, which may not follow
+// the order of their IDs
+func (m *RequestManager) StreamService(ctx context.Context, req StreamServiceRequest, c chan<- Event) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	return m.stream(ctx, req.SessionKey, req.Offset, c, nil)
+}
+
+// WaitService blocks until the response to the asynchronous request
+// identified by the ID is available and returns it. If the context is
+// done before the response is available, a nil event is returned so
+// that the caller can fall back to retrieve the response later on
+func (m *RequestManager) WaitService(ctx context.Context, req WaitServiceRequest) (Event, errors.Err) {
+	if len(req.SessionKey) == 0 {
+		return nil, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	for {
+		// start watching before polling so that no insertion
+		// is missed in between
+		notifyC, cancel, err := m.watch(ctx, req.SessionKey)
+		if err != nil {
+			return nil, err
+		}
+
+		evs, err := m.poll(ctx, req.SessionKey, req.ID, 1, false)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		for _, ev := range evs.Events {
+			if ev.EventID() == req.ID {
+				cancel()
+				return ev, nil
+			}
+		}
+
+		ok := wait(ctx, notifyC)
+		cancel()
+		if !ok {
+			return nil, nil
+		}
+	}
+}
+
+// GetServiceStatus returns the status of the asynchronous request
+// identified by the ID along with its response if it is available
+func (m *RequestManager) GetServiceStatus(ctx context.Context, req GetServiceStatusRequest) (GetServiceStatusResponse, errors.Err) {
+	if len(req.SessionKey) == 0 {
+		return GetServiceStatusResponse{}, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	state, err := m.mqueue.Get(ctx, mqueue.GetRequest{Key: req.SessionKey, Offset: req.ID})
+	if err != nil {
+		return GetServiceStatusResponse{}, errors.New(errors.ErrQueueGet, err)
+	}
+
+	switch state.Status {
+	case mqueue.ElementReserved:
+		return GetServiceStatusResponse{Status: RequestStatusPending}, nil
+	case mqueue.ElementSet:
+		ev, err := deserializeElement(state.Element)
+		if err != nil {
+			return GetServiceStatusResponse{}, err
+		}
+
+		if ev.EventType() == ErrorEventType {
+			return GetServiceStatusResponse{Status: RequestStatusFailed, Event: ev}, nil
+		}
+
+		return GetServiceStatusResponse{Status: RequestStatusCompleted, Event: ev}, nil
+	default:
+		return GetServiceStatusResponse{Status: RequestStatusUnknown}, nil
+	}
+}
+
+// StreamEvent delivers to the provided channel the events generated by
+// a subscription starting at the requested offset, and keeps delivering
+// new events as they become available until the context is cancelled or
+// the subscription is destroyed
+func (m *RequestManager) StreamEvent(ctx context.Context, req StreamEventRequest, c chan<- Event) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	subID := SubID(req.SessionKey, req.ID)
+	if !m.subman.Exists(ctx, subID) {
+		return errors.New(errors.ErrSubscriptionNotFound, stderr.New("cannot stream events from subscription that does not exist"))
+	}
+
+	return m.stream(ctx, subID, req.Offset, c, func() bool {
+		return m.subman.Exists(ctx, subID)
+	})
+}
+
+// stream delivers the events in the queue identified by key to the channel.
+// If alive is set, the stream terminates once alive returns false
+func (m *RequestManager) stream(
+	ctx context.Context,
+	key string,
+	offset uint64,
+	c chan<- Event,
+	alive func() bool,
+) errors.Err {
+	// delivered keeps track of the events with an ID higher than offset
+	// that have already been delivered, so that offset can always point
+	// to the lowest event that has not been delivered yet
+	delivered := make(map[uint64]struct{})
+
+	for {
+		// start watching before polling so that no insertion
+		// is missed in between
+		notifyC, cancel, err := m.watch(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		evs, err := m.poll(ctx, key, offset, streamBatchSize, false)
+		if err != nil {
+			cancel()
+			return err
+		}
+
+		if offset < evs.Offset {
+			offset = evs.Offset
+		}
+
+		sent := 0
+		for _, ev := range evs.Events {
+			id := ev.EventID()
+			if _, ok := delivered[id]; ok || id < offset {
+				continue
+			}
+
+			select {
+			case c <- ev:
+			case <-ctx.Done():
+				cancel()
+				return nil
+			}
+
+			delivered[id] = struct{}{}
+			sent++
+		}
+
+		for {
+			if _, ok := delivered[offset]; !ok {
+				break
+			}
+
+			delete(delivered, offset)
+			offset++
+		}
+
+		if sent > 0 {
+			cancel()
+			continue
+		}
+
+		ok := wait(ctx, notifyC)
+		cancel()
+		if !ok {
+			return nil
+		}
+
+		if alive != nil && !alive() {
+			return nil
+		}
+	}
+}
+
 // PollEvent retrieves the responses the RequestManager already got
 // from the asynchronous requests.
 func (m *RequestManager) PollEvent(ctx context.Context, req PollEventRequest) (Events, errors.Err) {
 	subID := SubID(req.SessionKey, req.ID)
 	subinfoID := SubinfoID(req.SessionKey)
 
-	evs, err := m.poll(ctx, subID, req.Offset, req.Count, req.DiscardPrevious)
+	offset := req.Offset
+	if req.FromCursor {
+		cursor, err := m.cursor(ctx, subID)
+		if err != nil {
+			return Events{}, err
+		}
+
+		offset = cursor
+	}
+
+	evs, err := m.pollWait(ctx, subID, offset, req.Count, req.DiscardPrevious, req.Wait)
 	if err != nil {
 		return Events{}, err
 	}
@@ -278,6 +914,82 @@ func (m *RequestManager) PollEvent(ctx context.Context, req PollEventRequest) (E
 	return evs, nil
 }
 
+// pollWait retrieves the events from the queue identified by key. If there
+// are no events available it waits up to the provided duration for events
+// to be inserted into the queue
+func (m *RequestManager) pollWait(
+	ctx context.Context,
+	key string,
+	offset uint64,
+	count uint,
+	discardPrevious bool,
+	timeout time.Duration,
+) (Events, errors.Err) {
+	evs, err := m.poll(ctx, key, offset, count, discardPrevious)
+	if err != nil || len(evs.Events) > 0 || timeout == 0 {
+		return evs, err
+	}
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
+	for {
+		// start watching before polling so that no insertion
+		// is missed in between
+		notifyC, cancel, err := m.watch(ctx, key)
+		if err != nil {
+			return Events{}, err
+		}
+
+		next, err := m.poll(ctx, key, offset, count, false)
+		if err != nil {
+			cancel()
+			return Events{}, err
+		}
+
+		evs = next
+		if len(evs.Events) > 0 {
+			cancel()
+			return evs, nil
+		}
+
+		ok := wait(ctx, notifyC)
+		cancel()
+		if !ok {
+			return evs, nil
+		}
+	}
+}
+
+// watch starts watching the queue identified by key. The returned function
+// must be called once the caller is no longer interested in the notification
+// to release the associated resources
+func (m *RequestManager) watch(ctx context.Context, key string) (<-chan struct{}, context.CancelFunc, errors.Err) {
+	ctx, cancel := context.WithCancel(ctx)
+	c, err := m.mqueue.Watch(ctx, mqueue.WatchRequest{Key: key})
+	if err != nil {
+		cancel()
+		return nil, nil, errors.New(errors.ErrQueueWatch, err)
+	}
+
+	return c, cancel, nil
+}
+
+// wait blocks until the watch channel is closed, the context is done
+// or pollInterval elapses. It returns false if the context is done
+func wait(ctx context.Context, c <-chan struct{}) bool {
+	timer := time.NewTimer(pollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-c:
+	case <-timer.C:
+	}
+
+	return ctx.Err() == nil
+}
+
 func (m *RequestManager) poll(ctx context.Context, key string, offset uint64, count uint, discardPrevious bool) (Events, errors.Err) {
 	els, err := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{Key: key, Offset: offset, Count: count})
 	if err != nil {
@@ -2,14 +2,18 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	stderr "errors"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/log"
 	"github.com/oasislabs/oasis-gateway/mqueue/core"
 	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
 	"github.com/oasislabs/oasis-gateway/mqueue/mailboxtest"
+	"github.com/oasislabs/oasis-gateway/rpc"
 	"github.com/oasislabs/oasis-gateway/stats"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -57,6 +61,30 @@ func (c *MockClient) GetPublicKey(
 	return args.Get(0).(GetPublicKeyResponse), nil
 }
 
+func (c *MockClient) CallService(
+	ctx context.Context,
+	req CallServiceRequest,
+) (CallServiceResponse, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return CallServiceResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(CallServiceResponse), nil
+}
+
+func (c *MockClient) EstimateGas(
+	ctx context.Context,
+	req EstimateGasRequest,
+) (EstimateGasResponse, errors.Err) {
+	args := c.Called(ctx, req)
+	if args.Get(1) != nil {
+		return EstimateGasResponse{}, args.Get(1).(errors.Err)
+	}
+
+	return args.Get(0).(EstimateGasResponse), nil
+}
+
 func (c *MockClient) ExecuteService(
 	ctx context.Context,
 	id uint64,
@@ -117,13 +145,47 @@ func createRequestManager() *RequestManager {
 
 }
 
+func TestCallServiceErrNoAddress(t *testing.T) {
+	manager := createRequestManager()
+
+	_, err := manager.CallService(Context, CallServiceRequest{Data: "0x00"})
+
+	assert.Equal(t, errors.ErrInvalidAddress, err.ErrorCode())
+}
+
+func TestCallServiceOK(t *testing.T) {
+	manager := createRequestManager()
+	req := CallServiceRequest{Address: "0x00", Data: "0x00"}
+
+	manager.client.(*MockClient).On("CallService", mock.Anything, req).
+		Return(CallServiceResponse{Address: "0x00", Output: "0x01"}, nil)
+
+	res, err := manager.CallService(Context, req)
+
+	assert.Nil(t, err)
+	assert.Equal(t, CallServiceResponse{Address: "0x00", Output: "0x01"}, res)
+}
+
+func TestEstimateGasOK(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.client.(*MockClient).On("EstimateGas",
+		mock.Anything, EstimateGasRequest{Data: "0x00"}).
+		Return(EstimateGasResponse{Gas: 1, GasPrice: 2}, nil)
+
+	res, err := manager.EstimateGas(Context, EstimateGasRequest{Data: "0x00"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, EstimateGasResponse{Gas: 1, GasPrice: 2}, res)
+}
+
 func TestSubscribeErrNoSessionKey(t *testing.T) {
 	manager := createRequestManager()
 
 	_, err := manager.Subscribe(Context, SubscribeRequest{
-		Event:   "event",
-		Address: "address",
-		Topics:  []string{"topic1", "topic2"},
+		Event:     "event",
+		Addresses: []string{"address"},
+		Topics:    [][]string{{"topic1"}, {"topic2"}},
 	})
 
 	assert.Equal(t, "[2011] error code InputError with desc Provided invalid key. with cause key cannot be empty", err.Error())
@@ -135,14 +197,17 @@ func TestSubscribeOK(t *testing.T) {
 	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
 		mock.Anything, mock.Anything).Return(uint64(0), nil)
 
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.Anything).Return(nil)
+
 	manager.client.(*MockClient).On("SubscribeRequest",
 		mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	id, err := manager.Subscribe(Context, SubscribeRequest{
 		Event:      "event",
-		Address:    "address",
+		Addresses:  []string{"address"},
 		SessionKey: "session",
-		Topics:     []string{"topic1", "topic2"},
+		Topics:     [][]string{{"topic1"}, {"topic2"}},
 	})
 
 	assert.Nil(t, err)
@@ -152,15 +217,356 @@ func TestSubscribeOK(t *testing.T) {
 		mock.Anything, mqueue.NextRequest{
 			Key: "session:subinfo",
 		})
+	manager.mqueue.(*mailboxtest.Mailbox).AssertCalled(t, "Insert",
+		mock.Anything, mock.MatchedBy(func(req mqueue.InsertRequest) bool {
+			return req.Key == "session:subinfo" &&
+				req.Element.Offset == 0 &&
+				req.Element.Type == subinfoElementType
+		}))
 	manager.client.(*MockClient).AssertCalled(t, "SubscribeRequest",
 		mock.Anything, CreateSubscriptionRequest{
-			Event:   "event",
-			Address: "address",
-			SubID:   "session:sub:0",
-			Topics:  []string{"topic1", "topic2"},
+			Event:     "event",
+			Addresses: []string{"address"},
+			SubID:     "session:sub:0",
+			Topics:    [][]string{{"topic1"}, {"topic2"}},
 		}, mock.Anything)
 }
 
+func TestSubscribeInsertErr(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mock.Anything).Return(uint64(0), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.Anything).Return(stderr.New("error"))
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:      "event",
+		Addresses:  []string{"address"},
+		SessionKey: "session",
+	})
+
+	assert.Equal(t, errors.ErrQueueInsert, err.ErrorCode())
+	manager.client.(*MockClient).AssertNotCalled(t, "SubscribeRequest",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubscribeErrDiscardsSubinfo(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mock.Anything).Return(uint64(2), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.Anything).Return(nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Discard",
+		mock.Anything, mock.Anything).Return(nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Remove",
+		mock.Anything, mock.Anything).Return(nil)
+	manager.client.(*MockClient).On("SubscribeRequest",
+		mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New(errors.ErrInternalError, nil))
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:      "event",
+		Addresses:  []string{"address"},
+		SessionKey: "session",
+	})
+
+	assert.Equal(t, errors.ErrInternalError, err.ErrorCode())
+	manager.mqueue.(*mailboxtest.Mailbox).AssertCalled(t, "Discard",
+		mock.Anything, mqueue.DiscardRequest{
+			KeepPrevious: true,
+			Count:        1,
+			Offset:       2,
+			Key:          "session:subinfo",
+		})
+}
+
+func TestRegisterABIErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.RegisterABI(Context, RegisterABIRequest{})
+
+	assert.Equal(t, "[2011] error code InputError with desc Provided invalid key. with cause key cannot be empty", err.Error())
+}
+
+func TestRegisterABIErrInvalidAddress(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.RegisterABI(Context, RegisterABIRequest{
+		SessionKey: "session",
+		Address:    "address",
+		ABI:        "[]",
+	})
+
+	assert.Equal(t, errors.ErrInvalidAddress, err.ErrorCode())
+}
+
+func TestRegisterABIErrInvalidABI(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.RegisterABI(Context, RegisterABIRequest{
+		SessionKey: "session",
+		Address:    "0x0000000000000000000000000000000000000001",
+		ABI:        "{",
+	})
+
+	assert.Equal(t, errors.ErrInvalidABI, err.ErrorCode())
+}
+
+func TestRegisterABIOK(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	err := manager.RegisterABI(Context, RegisterABIRequest{
+		SessionKey: "session",
+		Address:    "0x0000000000000000000000000000000000000001",
+		ABI:        testABI,
+	})
+
+	assert.Nil(t, err)
+	abis, err := manager.abis.list(Context, "session")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(abis))
+}
+
+func TestListSubscriptionsErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	_, err := manager.ListSubscriptions(Context, ListSubscriptionsRequest{})
+
+	assert.Equal(t, "[2011] error code InputError with desc Provided invalid key. with cause key cannot be empty", err.Error())
+}
+
+func TestListSubscriptionsErrRetrieve(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{}, stderr.New("error"))
+
+	_, err := manager.ListSubscriptions(Context, ListSubscriptionsRequest{SessionKey: "session"})
+
+	assert.Equal(t, errors.ErrQueueRetrieve, err.ErrorCode())
+}
+
+func TestListSubscriptionsOK(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mock.Anything).Return(uint64(0), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.Anything).Return(nil)
+	manager.client.(*MockClient).On("SubscribeRequest",
+		mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:      "event",
+		Addresses:  []string{"address"},
+		SessionKey: "session",
+		Topics:     [][]string{{"topic"}},
+	})
+	assert.Nil(t, err)
+
+	createdAt := time.Unix(1000, 0).UTC()
+	active, derr := json.Marshal(Subscription{
+		ID:        0,
+		Event:     "event",
+		Addresses: []string{"address"},
+		Topics:    [][]string{{"topic"}},
+		CreatedAt: createdAt,
+	})
+	assert.Nil(t, derr)
+	closed, derr := json.Marshal(Subscription{ID: 1, Event: "event"})
+	assert.Nil(t, derr)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{
+			Key:    "session:subinfo",
+			Offset: 0,
+			Count:  maxListedSubscriptions,
+		}).Return(mqueue.Elements{Offset: 0, Elements: []mqueue.Element{
+		{Offset: 0, Type: subinfoElementType, Value: string(active)},
+		{Offset: 1, Type: subinfoElementType, Value: string(closed)},
+	}}, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{
+			Key:    "session:sub:0",
+			Offset: 0,
+			Count:  1,
+		}).Return(mqueue.Elements{Offset: 5}, nil)
+
+	res, err := manager.ListSubscriptions(Context, ListSubscriptionsRequest{SessionKey: "session"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, ListSubscriptionsResponse{Subscriptions: []Subscription{{
+		ID:        0,
+		Event:     "event",
+		Addresses: []string{"address"},
+		Topics:    [][]string{{"topic"}},
+		CreatedAt: createdAt,
+		Offset:    5,
+	}}}, res)
+}
+
+func TestNextIdempotencyKeyFirst(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Exists",
+		mock.Anything, mqueue.ExistsRequest{Key: "session:idem:key"}).
+		Return(false, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session:idem:key"}).
+		Return(uint64(0), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session"}).
+		Return(uint64(3), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mqueue.InsertRequest{Key: "session:idem:key", Element: core.Element{
+			Offset: 0,
+			Type:   idempotencyElementType,
+			Value:  "3:fingerprint",
+		}}).Return(nil)
+
+	id, ok, err := manager.next(Context, "session", "key", "fingerprint")
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), id)
+}
+
+func TestNextIdempotencyKeyInsertErr(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Exists",
+		mock.Anything, mqueue.ExistsRequest{Key: "session:idem:key"}).
+		Return(false, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session:idem:key"}).
+		Return(uint64(0), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session"}).
+		Return(uint64(3), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.Anything).Return(stderr.New("error"))
+	manager.mqueue.(*mailboxtest.Mailbox).On("Remove",
+		mock.Anything, mqueue.RemoveRequest{Key: "session:idem:key"}).Return(nil)
+
+	_, _, err := manager.next(Context, "session", "key", "fingerprint")
+
+	assert.Equal(t, errors.ErrQueueInsert, err.ErrorCode())
+	manager.mqueue.(*mailboxtest.Mailbox).AssertCalled(t, "Remove",
+		mock.Anything, mqueue.RemoveRequest{Key: "session:idem:key"})
+}
+
+func TestNextIdempotencyKeyConcurrent(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Exists",
+		mock.Anything, mqueue.ExistsRequest{Key: "session:idem:key"}).
+		Return(false, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session:idem:key"}).
+		Return(uint64(1), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Discard",
+		mock.Anything, mqueue.DiscardRequest{
+			KeepPrevious: true,
+			Count:        1,
+			Offset:       1,
+			Key:          "session:idem:key",
+		}).Return(nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session:idem:key"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{Key: "session:idem:key", Offset: 0, Count: 1}).
+		Return(mqueue.Elements{
+			Offset: 0,
+			Elements: []core.Element{
+				{Offset: 0, Type: idempotencyElementType, Value: "3:fingerprint"},
+			},
+		}, nil)
+
+	id, ok, err := manager.next(Context, "session", "key", "fingerprint")
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(3), id)
+}
+
+func TestExecuteServiceAsyncIdempotencyKeyRetry(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Exists",
+		mock.Anything, mqueue.ExistsRequest{Key: "session:idem:key"}).
+		Return(true, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session:idem:key"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{Key: "session:idem:key", Offset: 0, Count: 1}).
+		Return(mqueue.Elements{
+			Offset: 0,
+			Elements: []core.Element{
+				{
+					Offset: 0,
+					Type:   idempotencyElementType,
+					Value:  "3:" + requestFingerprint("Execute", "0x00", "0x00"),
+				},
+			},
+		}, nil)
+
+	id, err := manager.ExecuteServiceAsync(Context, ExecuteServiceRequest{
+		Address:        "0x00",
+		Data:           "0x00",
+		SessionKey:     "session",
+		IdempotencyKey: "key",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), id)
+	manager.mqueue.(*mailboxtest.Mailbox).AssertNotCalled(t, "Next", mock.Anything, mock.Anything)
+}
+
+func TestExecuteServiceAsyncIdempotencyKeyReused(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Exists",
+		mock.Anything, mqueue.ExistsRequest{Key: "session:idem:key"}).
+		Return(true, nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session:idem:key"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{Key: "session:idem:key", Offset: 0, Count: 1}).
+		Return(mqueue.Elements{
+			Offset: 0,
+			Elements: []core.Element{
+				{
+					Offset: 0,
+					Type:   idempotencyElementType,
+					Value:  "3:" + requestFingerprint("Execute", "0x00", "0x00"),
+				},
+			},
+		}, nil)
+
+	_, err := manager.ExecuteServiceAsync(Context, ExecuteServiceRequest{
+		Address:        "0x00",
+		Data:           "0x01",
+		SessionKey:     "session",
+		IdempotencyKey: "key",
+	})
+
+	assert.Equal(t, errors.ErrIdempotencyKeyReused, err.ErrorCode())
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	assert.Equal(t, requestFingerprint("Execute", "0x00", "0x01"),
+		requestFingerprint("Execute", "0x00", "0x01"))
+	assert.NotEqual(t, requestFingerprint("Execute", "0x00", "0x01"),
+		requestFingerprint("Execute", "0x000", "x01"))
+	assert.NotEqual(t, requestFingerprint("Execute", "0x00"),
+		requestFingerprint("Deploy", "0x00"))
+}
+
 func TestPollEventOKNoDiscard(t *testing.T) {
 	manager := createRequestManager()
 
@@ -196,6 +602,64 @@ func TestPollEventOKNoDiscard(t *testing.T) {
 	}, evs.Events[0])
 }
 
+func TestPollServiceWaitOK(t *testing.T) {
+	manager := createRequestManager()
+
+	notifyC := make(chan struct{})
+	close(notifyC)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session"}).
+		Return((<-chan struct{})(notifyC), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{Offset: 0}, nil).Twice()
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{
+		Offset: 0,
+		Elements: []core.Element{
+			{
+				Offset: 0,
+				Value:  "{\"ID\": 0, \"Address\": \"0x00\"}",
+				Type:   DeployServiceEventType.String(),
+			},
+		},
+	}, nil)
+
+	evs, err := manager.PollService(Context, PollServiceRequest{
+		Offset:     0,
+		Count:      1,
+		Wait:       time.Second,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, Events{
+		Offset: 0,
+		Events: []Event{DeployServiceResponse{ID: 0, Address: "0x00"}},
+	}, evs)
+	manager.mqueue.(*mailboxtest.Mailbox).AssertNumberOfCalls(t, "Retrieve", 3)
+}
+
+func TestPollServiceWaitTimeout(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{Offset: 0}, nil)
+
+	evs, err := manager.PollService(Context, PollServiceRequest{
+		Offset:     0,
+		Count:      1,
+		Wait:       10 * time.Millisecond,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, Events{Offset: 0}, evs)
+}
+
 func TestPollEventOKDiscardSubinfo(t *testing.T) {
 	manager := createRequestManager()
 
@@ -239,3 +703,274 @@ func TestPollEventOKDiscardSubinfo(t *testing.T) {
 			Key:          "session:subinfo",
 		})
 }
+
+func TestStreamServiceErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.StreamService(Context, StreamServiceRequest{}, make(chan Event))
+
+	assert.Equal(t, "[2011] error code InputError with desc Provided invalid key. with cause key cannot be empty", err.Error())
+}
+
+func TestStreamServiceOK(t *testing.T) {
+	manager := createRequestManager()
+	ctx, cancel := context.WithCancel(Context)
+	defer cancel()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{
+		Offset: 0,
+		Elements: []core.Element{
+			{
+				Offset: 0,
+				Value:  "{\"ID\": 0, \"Address\": \"0x00\"}",
+				Type:   DeployServiceEventType.String(),
+			},
+		},
+	}, nil)
+
+	c := make(chan Event)
+	errC := make(chan errors.Err)
+	go func() {
+		errC <- manager.StreamService(ctx, StreamServiceRequest{
+			Offset:     0,
+			SessionKey: "session",
+		}, c)
+	}()
+
+	ev := <-c
+	cancel()
+
+	assert.Nil(t, <-errC)
+	assert.Equal(t, DeployServiceResponse{ID: 0, Address: "0x00"}, ev)
+	manager.mqueue.(*mailboxtest.Mailbox).AssertCalled(t, "Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{
+			Key:    "session",
+			Offset: 0,
+			Count:  64,
+		})
+}
+
+func TestWaitServiceErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	_, err := manager.WaitService(Context, WaitServiceRequest{ID: 1})
+
+	assert.Equal(t, "[2011] error code InputError with desc Provided invalid key. with cause key cannot be empty", err.Error())
+}
+
+func TestWaitServiceOK(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{
+		Offset: 1,
+		Elements: []core.Element{
+			{
+				Offset: 1,
+				Value:  "{\"ID\": 1, \"Address\": \"0x00\"}",
+				Type:   DeployServiceEventType.String(),
+			},
+		},
+	}, nil)
+
+	ev, err := manager.WaitService(Context, WaitServiceRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, DeployServiceResponse{ID: 1, Address: "0x00"}, ev)
+	manager.mqueue.(*mailboxtest.Mailbox).AssertCalled(t, "Retrieve",
+		mock.Anything, mqueue.RetrieveRequest{
+			Key:    "session",
+			Offset: 1,
+			Count:  1,
+		})
+}
+
+func TestWaitServiceTimeout(t *testing.T) {
+	manager := createRequestManager()
+	ctx, cancel := context.WithTimeout(Context, 10*time.Millisecond)
+	defer cancel()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Watch",
+		mock.Anything, mqueue.WatchRequest{Key: "session"}).
+		Return((<-chan struct{})(make(chan struct{})), nil)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Retrieve",
+		mock.Anything, mock.Anything).Return(mqueue.Elements{Offset: 1}, nil)
+
+	ev, err := manager.WaitService(ctx, WaitServiceRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, ev)
+}
+
+func TestGetServiceStatusErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	_, err := manager.GetServiceStatus(Context, GetServiceStatusRequest{ID: 1})
+
+	assert.Equal(t, errors.ErrInvalidKey, err.ErrorCode())
+}
+
+func TestGetServiceStatusPending(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Get",
+		mock.Anything, mqueue.GetRequest{Key: "session", Offset: 1}).
+		Return(mqueue.ElementState{Status: mqueue.ElementReserved}, nil)
+
+	res, err := manager.GetServiceStatus(Context, GetServiceStatusRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, GetServiceStatusResponse{Status: RequestStatusPending}, res)
+}
+
+func TestGetServiceStatusCompleted(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Get",
+		mock.Anything, mqueue.GetRequest{Key: "session", Offset: 1}).
+		Return(mqueue.ElementState{
+			Status: mqueue.ElementSet,
+			Element: core.Element{
+				Offset: 1,
+				Value:  "{\"ID\": 1, \"Address\": \"0x00\"}",
+				Type:   DeployServiceEventType.String(),
+			},
+		}, nil)
+
+	res, err := manager.GetServiceStatus(Context, GetServiceStatusRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, GetServiceStatusResponse{
+		Status: RequestStatusCompleted,
+		Event:  DeployServiceResponse{ID: 1, Address: "0x00"},
+	}, res)
+}
+
+func TestGetServiceStatusFailed(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Get",
+		mock.Anything, mqueue.GetRequest{Key: "session", Offset: 1}).
+		Return(mqueue.ElementState{
+			Status: mqueue.ElementSet,
+			Element: core.Element{
+				Offset: 1,
+				Value:  "{\"ID\": 1, \"Cause\": {\"errorCode\": 1000, \"description\": \"error\"}}",
+				Type:   ErrorEventType.String(),
+			},
+		}, nil)
+
+	res, err := manager.GetServiceStatus(Context, GetServiceStatusRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, RequestStatusFailed, res.Status)
+	assert.Equal(t, uint64(1), res.Event.EventID())
+}
+
+func TestGetServiceStatusUnknown(t *testing.T) {
+	manager := createRequestManager()
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Get",
+		mock.Anything, mqueue.GetRequest{Key: "session", Offset: 1}).
+		Return(mqueue.ElementState{Status: mqueue.ElementUnknown}, nil)
+
+	res, err := manager.GetServiceStatus(Context, GetServiceStatusRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, GetServiceStatusResponse{Status: RequestStatusUnknown}, res)
+}
+
+func TestCancelServiceErrNoSessionKey(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.CancelService(Context, CancelServiceRequest{ID: 1})
+
+	assert.Equal(t, errors.ErrInvalidKey, err.ErrorCode())
+}
+
+func TestCancelServiceErrNotFound(t *testing.T) {
+	manager := createRequestManager()
+
+	err := manager.CancelService(Context, CancelServiceRequest{
+		ID:         1,
+		SessionKey: "session",
+	})
+
+	assert.Equal(t, errors.ErrRequestNotFound, err.ErrorCode())
+}
+
+func TestCancelServiceOK(t *testing.T) {
+	manager := createRequestManager()
+	inserted := make(chan mqueue.Element, 1)
+
+	manager.mqueue.(*mailboxtest.Mailbox).On("Next",
+		mock.Anything, mqueue.NextRequest{Key: "session"}).
+		Return(uint64(1), nil)
+	manager.mqueue.(*mailboxtest.Mailbox).On("Insert",
+		mock.Anything, mock.AnythingOfType("core.InsertRequest")).
+		Run(func(args mock.Arguments) {
+			inserted <- args.Get(1).(mqueue.InsertRequest).Element
+		}).Return(nil)
+	manager.client.(*MockClient).On("ExecuteService",
+		mock.Anything, uint64(1), mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, errors.New(errors.ErrRequestCancelled, nil))
+
+	id, err := manager.ExecuteServiceAsync(Context, ExecuteServiceRequest{
+		Address:    "0x00",
+		Data:       "0x00",
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+
+	err = manager.CancelService(Context, CancelServiceRequest{
+		ID:         id,
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+
+	ev, err := deserializeElement(<-inserted)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrorEvent{
+		ID: 1,
+		Cause: rpc.Error{
+			ErrorCode:   errors.ErrRequestCancelled.Code(),
+			Description: errors.ErrRequestCancelled.Desc(),
+		},
+	}, ev)
+
+	err = manager.CancelService(Context, CancelServiceRequest{
+		ID:         id,
+		SessionKey: "session",
+	})
+	assert.Equal(t, errors.ErrRequestNotFound, err.ErrorCode())
+}
@@ -0,0 +1,284 @@
+package core
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+)
+
+const (
+	// maxSessionTTL is the maximum TTL of a session. It matches the
+	// time after which the queues of an inactive session expire, so
+	// a longer TTL would not keep the session's resources around
+	maxSessionTTL = 10 * time.Minute
+
+	// minSessionTTL is the minimum TTL of a session
+	minSessionTTL = 1 * time.Second
+
+	// defaultSessionTTL is the TTL of a session when the client
+	// does not provide one
+	defaultSessionTTL = maxSessionTTL
+)
+
+// session keeps the state of a session opened by a client
+type session struct {
+	timer     *time.Timer
+	expiresAt time.Time
+
+	// idempotencyKeys are the keys of the queues created for the
+	// idempotency keys used by the session
+	idempotencyKeys map[string]struct{}
+}
+
+// sessions keeps track of the sessions opened by the clients so that
+// they can be closed once their TTL elapses. Sessions that are used
+// without being opened are not tracked
+type sessions struct {
+	mutex    sync.Mutex
+	sessions map[string]*session
+}
+
+func newSessions() *sessions {
+	return &sessions{sessions: make(map[string]*session)}
+}
+
+// Open starts tracking the session identified by key. If the session
+// is already open its TTL is reset. expire is called once the TTL
+// elapses, and it returns the time at which that happens
+func (s *sessions) Open(key string, ttl time.Duration, expire func()) time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if sess, ok := s.sessions[key]; ok {
+		sess.expiresAt = expiresAt
+		sess.timer.Reset(ttl)
+		return expiresAt
+	}
+
+	s.sessions[key] = &session{
+		timer:           time.AfterFunc(ttl, expire),
+		expiresAt:       expiresAt,
+		idempotencyKeys: make(map[string]struct{}),
+	}
+	return expiresAt
+}
+
+// Refresh resets the TTL of the session identified by key and returns
+// the time at which it elapses. It returns false if the session is
+// not open
+func (s *sessions) Refresh(key string, ttl time.Duration) (time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sess, ok := s.sessions[key]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	sess.expiresAt = time.Now().Add(ttl)
+	sess.timer.Reset(ttl)
+	return sess.expiresAt, true
+}
+
+// AddIdempotencyKey records the key of the queue created for an
+// idempotency key of the session identified by key, so that the queue
+// is removed when the session is closed. Nothing is recorded if the
+// session is not open
+func (s *sessions) AddIdempotencyKey(key, idempotencyKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if sess, ok := s.sessions[key]; ok {
+		sess.idempotencyKeys[idempotencyKey] = struct{}{}
+	}
+}
+
+// Close stops tracking the session identified by key and returns the
+// keys of the queues created for its idempotency keys. It returns false
+// if the session is not open
+func (s *sessions) Close(key string) ([]string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.close(key, false)
+}
+
+// Expire stops tracking the session identified by key once its TTL has
+// elapsed and returns the keys of the queues created for its idempotency
+// keys. It returns false if the session is not open or it has been
+// refreshed since the TTL elapsed
+func (s *sessions) Expire(key string) ([]string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.close(key, true)
+}
+
+func (s *sessions) close(key string, expired bool) ([]string, bool) {
+	sess, ok := s.sessions[key]
+	if !ok || (expired && time.Now().Before(sess.expiresAt)) {
+		return nil, false
+	}
+
+	sess.timer.Stop()
+	delete(s.sessions, key)
+
+	keys := make([]string, 0, len(sess.idempotencyKeys))
+	for key := range sess.idempotencyKeys {
+		keys = append(keys, key)
+	}
+
+	return keys, true
+}
+
+// Len returns the number of open sessions
+func (s *sessions) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.sessions)
+}
+
+// sessionTTL validates the TTL requested for a session and returns
+// the default TTL if none is requested
+func sessionTTL(ttl time.Duration) (time.Duration, errors.Err) {
+	if ttl == 0 {
+		return defaultSessionTTL, nil
+	}
+
+	if ttl < minSessionTTL || ttl > maxSessionTTL {
+		return 0, errors.New(errors.ErrInvalidSessionTTL,
+			fmt.Errorf("ttl must be between %s and %s", minSessionTTL, maxSessionTTL))
+	}
+
+	return ttl, nil
+}
+
+// OpenSession opens a session that is closed once its TTL elapses
+// unless it is refreshed
+func (m *RequestManager) OpenSession(ctx context.Context, req OpenSessionRequest) (OpenSessionResponse, errors.Err) {
+	if len(req.SessionKey) == 0 {
+		return OpenSessionResponse{}, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	ttl, err := sessionTTL(req.TTL)
+	if err != nil {
+		return OpenSessionResponse{}, err
+	}
+
+	expiresAt := m.sessions.Open(req.SessionKey, ttl, func() {
+		m.expireSession(req.SessionKey)
+	})
+
+	return OpenSessionResponse{ExpiresAt: expiresAt}, nil
+}
+
+// RefreshSession resets the TTL of an open session
+func (m *RequestManager) RefreshSession(ctx context.Context, req RefreshSessionRequest) (RefreshSessionResponse, errors.Err) {
+	if len(req.SessionKey) == 0 {
+		return RefreshSessionResponse{}, errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	ttl, err := sessionTTL(req.TTL)
+	if err != nil {
+		return RefreshSessionResponse{}, err
+	}
+
+	expiresAt, ok := m.sessions.Refresh(req.SessionKey, ttl)
+	if !ok {
+		return RefreshSessionResponse{}, errors.New(errors.ErrSessionNotFound, nil)
+	}
+
+	return RefreshSessionResponse{ExpiresAt: expiresAt}, nil
+}
+
+// CloseSession closes a session and frees all its resources right away.
+// All its subscriptions are destroyed, the requests that have not sent
+// their transaction yet are cancelled and its queues are removed. Sessions
+// that were used without being opened can also be closed
+func (m *RequestManager) CloseSession(ctx context.Context, req CloseSessionRequest) errors.Err {
+	if len(req.SessionKey) == 0 {
+		return errors.New(errors.ErrInvalidKey, stderr.New("key cannot be empty"))
+	}
+
+	keys, _ := m.sessions.Close(req.SessionKey)
+	return m.closeSession(ctx, req.SessionKey, keys)
+}
+
+// expireSession closes the session identified by key once its TTL
+// has elapsed
+func (m *RequestManager) expireSession(key string) {
+	keys, ok := m.sessions.Expire(key)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := m.closeSession(ctx, key, keys); err != nil {
+		m.logger.Warn(ctx, "failed to close expired session", log.MapFields{
+			"call_type": "SessionExpireFailure",
+			"key":       key,
+		}, err)
+	}
+}
+
+// closeSession frees the resources of the session identified by key,
+// including the queues created for its idempotency keys
+func (m *RequestManager) closeSession(ctx context.Context, key string, idempotencyKeys []string) errors.Err {
+	m.inflight.CancelSession(key)
+
+	subinfo := SubinfoID(key)
+	els, err := m.mqueue.Retrieve(ctx, mqueue.RetrieveRequest{
+		Key:    subinfo,
+		Offset: 0,
+		Count:  maxListedSubscriptions,
+	})
+	if err != nil {
+		return errors.New(errors.ErrQueueRetrieve, err)
+	}
+
+	for _, el := range els.Elements {
+		if el.Type != subinfoElementType {
+			continue
+		}
+
+		// subscriptions that have already been closed are kept in
+		// the subinfo queue until they are polled
+		err := m.Unsubscribe(ctx, UnsubscribeRequest{ID: el.Offset, SessionKey: key})
+		if err != nil && err.ErrorCode() != errors.ErrSubscriptionNotFound {
+			return err
+		}
+	}
+
+	for _, key := range append(idempotencyKeys, key, subinfo, CursorID(key), ABIID(key)) {
+		if err := m.removeQueue(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeQueue removes the queue identified by key if it exists
+func (m *RequestManager) removeQueue(ctx context.Context, key string) errors.Err {
+	ok, err := m.mqueue.Exists(ctx, mqueue.ExistsRequest{Key: key})
+	if err != nil {
+		return errors.New(errors.ErrQueueExists, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if err := m.mqueue.Remove(ctx, mqueue.RemoveRequest{Key: key}); err != nil {
+		return errors.New(errors.ErrQueueRemove, err)
+	}
+
+	return nil
+}
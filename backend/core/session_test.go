@@ -0,0 +1,251 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+	"github.com/oasislabs/oasis-gateway/mqueue/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createSessionRequestManager() *RequestManager {
+	return NewRequestManager(RequestManagerProperties{
+		MQueue: mem.NewServer(context.Background(), mem.Services{Logger: Logger}),
+		Client: &MockClient{},
+		Logger: Logger,
+	})
+}
+
+func TestSessionTTL(t *testing.T) {
+	ttl, err := sessionTTL(0)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultSessionTTL, ttl)
+
+	ttl, err = sessionTTL(time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, ttl)
+
+	_, err = sessionTTL(time.Millisecond)
+	assert.Equal(t, errors.ErrInvalidSessionTTL, err.ErrorCode())
+
+	_, err = sessionTTL(maxSessionTTL + 1)
+	assert.Equal(t, errors.ErrInvalidSessionTTL, err.ErrorCode())
+}
+
+func TestSessionsExpire(t *testing.T) {
+	s := newSessions()
+	expired := make(chan struct{})
+
+	s.Open("session", 10*time.Millisecond, func() {
+		if _, ok := s.Expire("session"); ok {
+			close(expired)
+		}
+	})
+	s.AddIdempotencyKey("session", "session:idem:key")
+	s.AddIdempotencyKey("other", "other:idem:key")
+
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		assert.Fail(t, "session did not expire")
+	}
+
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestSessionsExpireAfterRefresh(t *testing.T) {
+	s := newSessions()
+	s.Open("session", time.Minute, func() {})
+	s.AddIdempotencyKey("session", "session:idem:key")
+
+	_, ok := s.Expire("session")
+	assert.False(t, ok)
+
+	keys, ok := s.Close("session")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"session:idem:key"}, keys)
+
+	_, ok = s.Refresh("session", time.Minute)
+	assert.False(t, ok)
+}
+
+func TestOpenSessionErrNoSessionKey(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	_, err := manager.OpenSession(Context, OpenSessionRequest{})
+
+	assert.Equal(t, errors.ErrInvalidKey, err.ErrorCode())
+}
+
+func TestOpenSessionErrInvalidTTL(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	_, err := manager.OpenSession(Context, OpenSessionRequest{
+		SessionKey: "session",
+		TTL:        time.Hour,
+	})
+
+	assert.Equal(t, errors.ErrInvalidSessionTTL, err.ErrorCode())
+}
+
+func TestRefreshSessionErrNotFound(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	_, err := manager.RefreshSession(Context, RefreshSessionRequest{SessionKey: "session"})
+
+	assert.Equal(t, errors.ErrSessionNotFound, err.ErrorCode())
+}
+
+func TestRefreshSessionOK(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	opened, err := manager.OpenSession(Context, OpenSessionRequest{
+		SessionKey: "session",
+		TTL:        time.Second,
+	})
+	assert.Nil(t, err)
+
+	refreshed, err := manager.RefreshSession(Context, RefreshSessionRequest{
+		SessionKey: "session",
+		TTL:        time.Minute,
+	})
+	assert.Nil(t, err)
+	assert.True(t, refreshed.ExpiresAt.After(opened.ExpiresAt))
+	assert.Equal(t, 1, manager.sessions.Len())
+}
+
+func TestCloseSessionOK(t *testing.T) {
+	manager := createSessionRequestManager()
+	client := manager.client.(*MockClient)
+	client.On("SubscribeRequest", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client.On("UnsubscribeRequest", mock.Anything, mock.Anything).Return(nil)
+	client.On("DeployService", mock.Anything, mock.Anything, mock.Anything).
+		Return(DeployServiceResponse{ID: 0, Address: "0x01"}, nil)
+
+	_, err := manager.OpenSession(Context, OpenSessionRequest{SessionKey: "session"})
+	assert.Nil(t, err)
+
+	subID, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:      "logs",
+		Addresses:  []string{"0x01"},
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+
+	id, err := manager.DeployServiceAsync(Context, DeployServiceRequest{
+		SessionKey:     "session",
+		IdempotencyKey: "key",
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, manager.RegisterABI(Context, RegisterABIRequest{
+		SessionKey: "session",
+		Address:    "0x0000000000000000000000000000000000000001",
+		ABI:        testABI,
+	}))
+
+	ev, err := manager.WaitService(Context, WaitServiceRequest{ID: id, SessionKey: "session"})
+	assert.Nil(t, err)
+	assert.NotNil(t, ev)
+
+	assert.Nil(t, manager.CloseSession(Context, CloseSessionRequest{SessionKey: "session"}))
+
+	client.AssertCalled(t, "UnsubscribeRequest", mock.Anything, DestroySubscriptionRequest{
+		SubID: SubID("session", subID),
+	})
+	assert.False(t, manager.subman.Exists(Context, SubID("session", subID)))
+	assert.Equal(t, 0, manager.sessions.Len())
+
+	for _, key := range []string{"session", SubinfoID("session"), IdempotencyID("session", "key"), ABIID("session")} {
+		ok, derr := manager.mqueue.Exists(Context, mqueue.ExistsRequest{Key: key})
+		assert.Nil(t, derr)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestCloseSessionNotOpen(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	_, err := manager.mqueue.Next(Context, mqueue.NextRequest{Key: "session"})
+	assert.Nil(t, err)
+
+	assert.Nil(t, manager.CloseSession(Context, CloseSessionRequest{SessionKey: "session"}))
+
+	ok, err := manager.mqueue.Exists(Context, mqueue.ExistsRequest{Key: "session"})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+// insertNotifier is an MQueue that reports the result of
+// each insertion
+type insertNotifier struct {
+	mqueue.MQueue
+	inserted chan error
+}
+
+func (m insertNotifier) Insert(ctx context.Context, req mqueue.InsertRequest) error {
+	err := m.MQueue.Insert(ctx, req)
+	m.inserted <- err
+	return err
+}
+
+func TestCloseSessionInflightRequest(t *testing.T) {
+	inserted := make(chan error, 1)
+	manager := NewRequestManager(RequestManagerProperties{
+		MQueue: insertNotifier{
+			MQueue:   mem.NewServer(context.Background(), mem.Services{Logger: Logger}),
+			inserted: inserted,
+		},
+		Client: &MockClient{},
+		Logger: Logger,
+	})
+
+	release := make(chan struct{})
+	manager.client.(*MockClient).On("ExecuteService", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-release
+		}).Return(ExecuteServiceResponse{ID: 0, Address: "0x01"}, nil)
+
+	_, err := manager.ExecuteServiceAsync(Context, ExecuteServiceRequest{
+		Address:    "0x01",
+		Data:       "0x00",
+		SessionKey: "session",
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, manager.CloseSession(Context, CloseSessionRequest{SessionKey: "session"}))
+
+	// the response of the request is dropped once it completes
+	// because the queue of the session no longer exists
+	close(release)
+	select {
+	case err := <-inserted:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "request did not complete")
+	}
+}
+
+func TestNextIdempotencyKeyManyRetries(t *testing.T) {
+	manager := createSessionRequestManager()
+
+	first, ok, err := manager.next(Context, "session", "key", "fingerprint")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// retries do not reserve elements in the queue of the key,
+	// so they are not limited by the size of the queue
+	for i := 0; i < 2000; i++ {
+		id, ok, err := manager.next(Context, "session", "key", "fingerprint")
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, first, id)
+	}
+
+	_, _, err = manager.next(Context, "session", "key", "other")
+	assert.Equal(t, errors.ErrIdempotencyKeyReused, err.ErrorCode())
+}
@@ -9,20 +9,22 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/eth"
 	"github.com/oasislabs/oasis-gateway/log"
 	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
 	"github.com/oasislabs/oasis-gateway/stats"
 )
 
 type subscription struct {
-	ctx    context.Context
-	logger log.Logger
-	c      <-chan interface{}
-	done   chan<- subscriptionEndEvent
-	stop   chan interface{}
-	key    string
-	mqueue mqueue.MQueue
-	wg     sync.WaitGroup
+	ctx     context.Context
+	logger  log.Logger
+	c       <-chan interface{}
+	done    chan<- subscriptionEndEvent
+	stop    chan interface{}
+	key     string
+	mqueue  mqueue.MQueue
+	decoder logDecoder
+	wg      sync.WaitGroup
 }
 
 type subscriptionProps struct {
@@ -32,6 +34,10 @@ type subscriptionProps struct {
 	Key     string
 	Done    chan<- subscriptionEndEvent
 	C       <-chan interface{}
+
+	// Decoder is optional and it is used to decode the
+	// logs received by the subscription
+	Decoder logDecoder
 }
 
 func newSubscription(props subscriptionProps) *subscription {
@@ -52,14 +58,15 @@ func newSubscription(props subscriptionProps) *subscription {
 	}
 
 	return &subscription{
-		ctx:    props.Context,
-		logger: props.Logger.ForClass("backend/core", "subscription"),
-		c:      props.C,
-		done:   props.Done,
-		stop:   make(chan interface{}),
-		key:    props.Key,
-		mqueue: props.MQueue,
-		wg:     sync.WaitGroup{},
+		ctx:     props.Context,
+		logger:  props.Logger.ForClass("backend/core", "subscription"),
+		c:       props.C,
+		done:    props.Done,
+		stop:    make(chan interface{}),
+		key:     props.Key,
+		mqueue:  props.MQueue,
+		decoder: props.Decoder,
+		wg:      sync.WaitGroup{},
 	}
 }
 
@@ -113,7 +120,7 @@ func (s *subscription) Start() {
 				continue
 			}
 
-			data, ok := ev.(types.Log)
+			event, ok := makeSubscriptionEvent(s.ctx, id, ev, s.decoder)
 			if !ok {
 				s.logger.Warn(s.ctx, "received event of unexpected type", log.MapFields{
 					"call_type": "InsertSubscriptionEventFailure",
@@ -123,16 +130,7 @@ func (s *subscription) Start() {
 				continue
 			}
 
-			var topics []string
-			for _, topic := range data.Topics {
-				topics = append(topics, topic.Hex())
-			}
-
-			el, err := makeElement(DataEvent{
-				ID:     id,
-				Data:   hexutil.Encode(data.Data),
-				Topics: topics,
-			}, id)
+			el, err := makeElement(event, id)
 			if err != nil {
 				s.logger.Warn(s.ctx, "failed to serialize event", log.MapFields{
 					"call_type": "InsertSubscriptionEventFailure",
@@ -154,6 +152,80 @@ func (s *subscription) Start() {
 	}
 }
 
+// makeSubscriptionEvent converts an event received from a backend
+// subscription into the event stored for the client. It returns
+// false if the event is not of a known type. Logs are decoded with
+// the decoder if one is provided
+func makeSubscriptionEvent(ctx context.Context, id uint64, ev interface{}, decoder logDecoder) (Event, bool) {
+	switch ev := ev.(type) {
+	case types.Log:
+		var topics []string
+		for _, topic := range ev.Topics {
+			topics = append(topics, topic.Hex())
+		}
+
+		event := DataEvent{
+			ID:      id,
+			Address: ev.Address.Hex(),
+			Data:    hexutil.Encode(ev.Data),
+			Topics:  topics,
+		}
+
+		if decoder != nil {
+			if decoded, ok := decoder.DecodeLog(ctx, ev); ok {
+				event.Event = decoded.Event
+				event.Args = decoded.Args
+			}
+		}
+
+		return event, true
+	case *types.Header:
+		return BlockEvent{
+			ID:         id,
+			Number:     ev.Number.Uint64(),
+			Hash:       ev.Hash().Hex(),
+			ParentHash: ev.ParentHash.Hex(),
+			Timestamp:  ev.Time,
+		}, true
+	case *eth.Receipt:
+		logs := make([]Log, 0, len(ev.Logs))
+		for _, log := range ev.Logs {
+			topics := make([]string, 0, len(log.Topics))
+			for _, topic := range log.Topics {
+				topics = append(topics, topic.Hex())
+			}
+
+			logs = append(logs, Log{
+				Address: log.Address.Hex(),
+				Topics:  topics,
+				Data:    hexutil.Encode(log.Data),
+				Index:   log.Index,
+			})
+		}
+
+		var address string
+		if ev.To != nil {
+			address = ev.To.Hex()
+		}
+
+		return ReceiptEvent{
+			ID:      id,
+			Address: address,
+			Status:  ev.Status,
+			Transaction: Transaction{
+				Hash:        ev.TxHash.Hex(),
+				BlockNumber: ev.BlockNumber,
+				BlockHash:   ev.BlockHash.Hex(),
+				GasUsed:     ev.GasUsed,
+				From:        ev.From.Hex(),
+				Logs:        logs,
+			},
+		}, true
+	default:
+		return nil, false
+	}
+}
+
 type subscriptionEndEvent struct {
 	Key   string
 	Error error
@@ -164,6 +236,7 @@ type createSubscriptionRequest struct {
 	Key     string
 	Err     chan<- errors.Err
 	C       <-chan interface{}
+	Decoder logDecoder
 }
 
 type destroySubscriptionRequest struct {
@@ -313,6 +386,7 @@ func (m *SubscriptionManager) create(req createSubscriptionRequest) {
 		Done:    m.done,
 		MQueue:  m.mqueue,
 		C:       req.C,
+		Decoder: req.Decoder,
 	})
 
 	m.incrSubscriptions()
@@ -364,11 +438,13 @@ func (m *SubscriptionManager) Exists(
 }
 
 // Create a new subscription identified by the
-// specified key
+// specified key. The decoder is optional and it is used
+// to decode the logs received by the subscription
 func (m *SubscriptionManager) Create(
 	ctx context.Context,
 	key string,
 	c chan interface{},
+	decoder logDecoder,
 ) errors.Err {
 	err := make(chan errors.Err)
 	m.req <- createSubscriptionRequest{
@@ -376,6 +452,7 @@ func (m *SubscriptionManager) Create(
 		Key:     key,
 		C:       c,
 		Err:     err,
+		Decoder: decoder,
 	}
 	return <-err
 }
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasislabs/oasis-gateway/eth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeSubscriptionEventLog(t *testing.T) {
+	ev, ok := makeSubscriptionEvent(Context, 1, types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics:  []common.Hash{common.HexToHash("0x02")},
+		Data:    []byte{3},
+	}, nil)
+
+	assert.True(t, ok)
+	assert.Equal(t, DataEvent{
+		ID:      1,
+		Address: common.HexToAddress("0x01").Hex(),
+		Data:    "0x03",
+		Topics:  []string{common.HexToHash("0x02").Hex()},
+	}, ev)
+}
+
+func TestMakeSubscriptionEventDecodedLog(t *testing.T) {
+	registry := newTestABIRegistry()
+	contract := parseTestABI(t)
+	assert.Nil(t, registry.Register(Context, "session", common.HexToAddress("0x01"), testABI))
+
+	data, err := contract.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(5))
+	assert.Nil(t, err)
+
+	ev, ok := makeSubscriptionEvent(Context, 1, types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics: []common.Hash{
+			contract.Events["Transfer"].Id(),
+			common.HexToHash("0x02"),
+			common.HexToHash("0x03"),
+		},
+		Data: data,
+	}, registry.Decoder("session"))
+
+	assert.True(t, ok)
+	assert.Equal(t, DataEvent{
+		ID:      1,
+		Address: common.HexToAddress("0x01").Hex(),
+		Data:    hexutil.Encode(data),
+		Topics: []string{
+			contract.Events["Transfer"].Id().Hex(),
+			common.HexToHash("0x02").Hex(),
+			common.HexToHash("0x03").Hex(),
+		},
+		Event: "Transfer",
+		Args: map[string]interface{}{
+			"from":  common.HexToAddress("0x02").Hex(),
+			"to":    common.HexToAddress("0x03").Hex(),
+			"value": "5",
+		},
+	}, ev)
+}
+
+func TestMakeSubscriptionEventHeader(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(2),
+		ParentHash: common.HexToHash("0x01"),
+		Time:       3,
+	}
+
+	ev, ok := makeSubscriptionEvent(Context, 1, header, nil)
+
+	assert.True(t, ok)
+	assert.Equal(t, BlockEvent{
+		ID:         1,
+		Number:     2,
+		Hash:       header.Hash().Hex(),
+		ParentHash: common.HexToHash("0x01").Hex(),
+		Timestamp:  3,
+	}, ev)
+}
+
+func TestMakeSubscriptionEventReceipt(t *testing.T) {
+	to := common.HexToAddress("0x01")
+	ev, ok := makeSubscriptionEvent(Context, 1, &eth.Receipt{
+		Receipt: types.Receipt{
+			Status:  1,
+			TxHash:  common.HexToHash("0x02"),
+			GasUsed: 21000,
+			Logs: []*types.Log{{
+				Address: to,
+				Topics:  []common.Hash{common.HexToHash("0x03")},
+				Data:    []byte{4},
+				Index:   5,
+			}},
+		},
+		BlockHash:   common.HexToHash("0x06"),
+		BlockNumber: 7,
+		From:        common.HexToAddress("0x08"),
+		To:          &to,
+	}, nil)
+
+	assert.True(t, ok)
+	assert.Equal(t, ReceiptEvent{
+		ID:      1,
+		Address: to.Hex(),
+		Status:  1,
+		Transaction: Transaction{
+			Hash:        common.HexToHash("0x02").Hex(),
+			BlockNumber: 7,
+			BlockHash:   common.HexToHash("0x06").Hex(),
+			GasUsed:     21000,
+			From:        common.HexToAddress("0x08").Hex(),
+			Logs: []Log{{
+				Address: to.Hex(),
+				Topics:  []string{common.HexToHash("0x03").Hex()},
+				Data:    "0x04",
+				Index:   5,
+			}},
+		},
+	}, ev)
+}
+
+func TestMakeSubscriptionEventUnknown(t *testing.T) {
+	_, ok := makeSubscriptionEvent(Context, 1, "event", nil)
+
+	assert.False(t, ok)
+}
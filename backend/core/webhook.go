@@ -0,0 +1,232 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/concurrent"
+	"github.com/oasislabs/oasis-gateway/log"
+	mqueue "github.com/oasislabs/oasis-gateway/mqueue/core"
+)
+
+// webhookRetryConfig is the configuration used to retry the delivery
+// of an event to a callback URL. Deliveries are retried for several
+// minutes so that no event is skipped because the callback URL is
+// temporarily unavailable. Once the attempts are exhausted the delivery
+// of the subscription stops, and its events can still be polled
+var webhookRetryConfig = concurrent.RetryConfig{
+	BaseTimeout:     1 * time.Second,
+	BaseExp:         2,
+	MaxRetryTimeout: 1 * time.Minute,
+	Attempts:        10,
+}
+
+// WebhookClient delivers the events of subscriptions to the callback
+// URLs provided by the clients
+type WebhookClient interface {
+	// DeliverEvent sends the event to the url and returns the
+	// status code of the response
+	DeliverEvent(ctx context.Context, url string, ev Event) (int, error)
+
+	// ValidateURL returns an error if events cannot be
+	// delivered to the url
+	ValidateURL(ctx context.Context, url string) error
+}
+
+// webhook keeps the state of the delivery of the events of a
+// subscription to its callback URL
+type webhook struct {
+	url    string
+	cancel context.CancelFunc
+
+	// cursor is the ID of the next event to be delivered. It is
+	// only accessed by the goroutine that delivers the events
+	cursor uint64
+}
+
+// webhooks keeps track of the subscriptions that deliver their
+// events to a callback URL so that the delivery can be stopped
+type webhooks struct {
+	mutex sync.Mutex
+	hooks map[string]*webhook
+}
+
+func newWebhooks() *webhooks {
+	return &webhooks{hooks: make(map[string]*webhook)}
+}
+
+// Add registers the webhook of the subscription identified by key
+func (w *webhooks) Add(key string, hook *webhook) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.hooks[key] = hook
+}
+
+// Remove stops tracking the webhook of the subscription identified
+// by key once the delivery has stopped
+func (w *webhooks) Remove(key string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.hooks, key)
+}
+
+// Cancel stops the delivery of the events of the subscription
+// identified by key. It returns false if the subscription does
+// not have a webhook
+func (w *webhooks) Cancel(key string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	hook, ok := w.hooks[key]
+	if !ok {
+		return false
+	}
+
+	hook.cancel()
+	delete(w.hooks, key)
+	return true
+}
+
+// Len returns the number of webhooks delivering events
+func (w *webhooks) Len() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.hooks)
+}
+
+// validateCallbackURL returns false if the callback URL is not
+// an absolute http or https URL
+func validateCallbackURL(callbackURL string) bool {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0
+}
+
+// startWebhook starts delivering the events of the subscription
+// identified by key to the callback URL
+func (m *RequestManager) startWebhook(key, callbackURL string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	hook := &webhook{url: callbackURL, cancel: cancel}
+	m.webhooks.Add(key, hook)
+	go m.runWebhook(ctx, key, hook)
+}
+
+// runWebhook delivers the events of the subscription identified by key
+// to the callback URL in the order of their IDs, one at a time, until the
+// subscription is destroyed. Events are discarded from the subscription's
+// queue once they are delivered
+func (m *RequestManager) runWebhook(ctx context.Context, key string, hook *webhook) {
+	defer m.webhooks.Remove(key)
+	defer hook.cancel()
+
+	c := make(chan Event)
+	go func() {
+		defer close(c)
+		if err := m.stream(ctx, key, hook.cursor, c, func() bool {
+			return m.subman.Exists(ctx, key)
+		}); err != nil {
+			m.logger.Warn(ctx, "failed to stream events to webhook", log.MapFields{
+				"call_type": "WebhookStreamFailure",
+				"key":       key,
+				"err":       err.Error(),
+			})
+		}
+	}()
+
+	for ev := range c {
+		// once the delivery stops the remaining events are drained
+		// so that the stream can terminate
+		if ctx.Err() != nil {
+			continue
+		}
+
+		if !m.deliverWebhookEvent(ctx, key, hook, ev) {
+			hook.cancel()
+			continue
+		}
+
+		hook.cursor = ev.EventID() + 1
+		if err := m.mqueue.Discard(ctx, mqueue.DiscardRequest{
+			Key:    key,
+			Offset: hook.cursor,
+		}); err != nil {
+			m.logger.Warn(ctx, "failed to discard delivered events", log.MapFields{
+				"call_type": "WebhookDiscardFailure",
+				"key":       key,
+				"err":       err.Error(),
+			})
+		}
+	}
+}
+
+// deliverWebhookEvent delivers an event to the callback URL. Events the
+// callback URL rejects with a client error are skipped, and any other
+// failure is retried. It returns false if the delivery stopped before
+// the event could be delivered
+func (m *RequestManager) deliverWebhookEvent(
+	ctx context.Context,
+	key string,
+	hook *webhook,
+	ev Event,
+) bool {
+	v, err := concurrent.RetryWithConfig(ctx, concurrent.SupplierFunc(func() (interface{}, error) {
+		if !m.subman.Exists(ctx, key) {
+			return false, concurrent.ErrCannotRecover{Cause: context.Canceled}
+		}
+
+		code, err := m.webhookClient.DeliverEvent(ctx, hook.url, ev)
+		if err != nil {
+			m.logger.Debug(ctx, "failed to deliver event to webhook", log.MapFields{
+				"call_type": "WebhookDeliveryFailure",
+				"key":       key,
+				"id":        ev.EventID(),
+				"err":       err.Error(),
+			})
+			return false, err
+		}
+
+		switch {
+		case code >= 200 && code < 300:
+			return true, nil
+		case code >= 400 && code < 500 &&
+			code != http.StatusRequestTimeout && code != http.StatusTooManyRequests:
+			m.logger.Warn(ctx, "webhook rejected event", log.MapFields{
+				"call_type":  "WebhookDeliveryFailure",
+				"key":        key,
+				"id":         ev.EventID(),
+				"statusCode": code,
+			})
+			return false, nil
+		default:
+			return false, fmt.Errorf("webhook responded with status %d", code)
+		}
+	}), webhookRetryConfig)
+
+	if err != nil {
+		if _, ok := err.(concurrent.ErrMaxAttemptsReached); ok {
+			m.logger.Warn(ctx, "webhook delivery stopped after too many failed attempts", log.MapFields{
+				"call_type": "WebhookDeliveryFailure",
+				"key":       key,
+				"id":        ev.EventID(),
+			})
+		}
+		return false
+	}
+
+	if delivered := v.(bool); delivered {
+		m.logger.Debug(ctx, "event delivered to webhook", log.MapFields{
+			"call_type": "WebhookDeliverySuccess",
+			"key":       key,
+			"id":        ev.EventID(),
+		})
+	}
+
+	return true
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"context"
+	stderr "errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasislabs/oasis-gateway/concurrent"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/mqueue/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookClient records the events delivered to it and
+// responds with the status codes it is set up with
+type MockWebhookClient struct {
+	mutex     sync.Mutex
+	responses []int
+	delivered []uint64
+	attempts  int
+
+	// invalidURL is the error returned when validating a URL
+	invalidURL error
+}
+
+func (c *MockWebhookClient) ValidateURL(ctx context.Context, url string) error {
+	return c.invalidURL
+}
+
+func (c *MockWebhookClient) Attempts() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.attempts
+}
+
+func (c *MockWebhookClient) DeliverEvent(ctx context.Context, url string, ev Event) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.attempts++
+	code := http.StatusOK
+	if len(c.responses) > 0 {
+		code, c.responses = c.responses[0], c.responses[1:]
+	}
+
+	if code == 0 {
+		return 0, stderr.New("failed to send request")
+	}
+
+	if code >= 200 && code < 300 {
+		c.delivered = append(c.delivered, ev.EventID())
+	}
+
+	return code, nil
+}
+
+func (c *MockWebhookClient) Delivered() []uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]uint64(nil), c.delivered...)
+}
+
+func createWebhookRequestManager(client *MockWebhookClient) *RequestManager {
+	return NewRequestManager(RequestManagerProperties{
+		MQueue:   mem.NewServer(context.Background(), mem.Services{Logger: Logger}),
+		Client:   &MockClient{},
+		Logger:   Logger,
+		Webhooks: client,
+	})
+}
+
+func TestValidateCallbackURL(t *testing.T) {
+	assert.True(t, validateCallbackURL("http://localhost:1234/events"))
+	assert.True(t, validateCallbackURL("https://example.com/events?id=1"))
+	assert.False(t, validateCallbackURL("localhost:1234"))
+	assert.False(t, validateCallbackURL("ftp://example.com/events"))
+	assert.False(t, validateCallbackURL("http:///events"))
+	assert.False(t, validateCallbackURL("http://%"))
+}
+
+func TestSubscribeErrCallbackURLNotSupported(t *testing.T) {
+	manager := createRequestManager()
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:       "logs",
+		SessionKey:  "session",
+		CallbackURL: "http://localhost:1234/events",
+	})
+
+	assert.Equal(t, errors.ErrAPINotImplemented, err.ErrorCode())
+}
+
+func TestSubscribeErrInvalidCallbackURL(t *testing.T) {
+	manager := createWebhookRequestManager(&MockWebhookClient{})
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:       "logs",
+		SessionKey:  "session",
+		CallbackURL: "localhost:1234",
+	})
+
+	assert.Equal(t, errors.ErrInvalidCallbackURL, err.ErrorCode())
+}
+
+func TestSubscribeErrCallbackURLNotAllowed(t *testing.T) {
+	manager := createWebhookRequestManager(&MockWebhookClient{
+		invalidURL: stderr.New("host resolves to non public address"),
+	})
+
+	_, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:       "logs",
+		SessionKey:  "session",
+		CallbackURL: "http://169.254.169.254/latest",
+	})
+
+	assert.Equal(t, errors.ErrInvalidCallbackURL, err.ErrorCode())
+}
+
+func TestSubscribeWebhookStopsAfterMaxAttempts(t *testing.T) {
+	retryConfig := webhookRetryConfig
+	webhookRetryConfig = concurrent.RetryConfig{
+		BaseTimeout:     1,
+		BaseExp:         1,
+		MaxRetryTimeout: 10 * time.Millisecond,
+		Attempts:        3,
+	}
+	defer func() { webhookRetryConfig = retryConfig }()
+
+	client := &MockWebhookClient{responses: []int{0, 0, 0, 0, 0}}
+	manager := createWebhookRequestManager(client)
+
+	manager.client.(*MockClient).On("SubscribeRequest",
+		mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(chan<- interface{}) <- types.Log{Address: common.HexToAddress("0x01")}
+	}).Return(nil)
+
+	id, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:       "logs",
+		Addresses:   []string{"0x01"},
+		SessionKey:  "session",
+		CallbackURL: "http://localhost:1234/events",
+	})
+	assert.Nil(t, err)
+
+	// the delivery stops once the attempts are exhausted and the
+	// event is kept in the subscription so that it can be polled
+	assert.True(t, waitFor(func() bool { return manager.webhooks.Len() == 0 }))
+	assert.Equal(t, 3, client.Attempts())
+
+	evs, err := manager.PollEvent(Context, PollEventRequest{
+		ID:         id,
+		SessionKey: "session",
+		Count:      10,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(evs.Events))
+}
+
+func TestSubscribeWebhookDeliversInOrder(t *testing.T) {
+	retryConfig := webhookRetryConfig
+	webhookRetryConfig = concurrent.RetryConfig{
+		BaseTimeout:       1,
+		BaseExp:           1,
+		MaxRetryTimeout:   10 * time.Millisecond,
+		UnlimitedAttempts: true,
+	}
+	defer func() { webhookRetryConfig = retryConfig }()
+
+	// the first attempt to deliver the first event fails and it is
+	// retried, and the second event is rejected, so it is skipped
+	client := &MockWebhookClient{responses: []int{0, http.StatusServiceUnavailable, http.StatusOK, http.StatusBadRequest}}
+	manager := createWebhookRequestManager(client)
+
+	manager.client.(*MockClient).On("SubscribeRequest",
+		mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		c := args.Get(2).(chan<- interface{})
+		for i := 0; i < 4; i++ {
+			c <- types.Log{Address: common.HexToAddress("0x01")}
+		}
+	}).Return(nil)
+
+	id, err := manager.Subscribe(Context, SubscribeRequest{
+		Event:       "logs",
+		Addresses:   []string{"0x01"},
+		SessionKey:  "session",
+		CallbackURL: "http://localhost:1234/events",
+	})
+	assert.Nil(t, err)
+
+	assert.True(t, waitFor(func() bool { return len(client.Delivered()) == 3 }))
+	assert.Equal(t, []uint64{0, 2, 3}, client.Delivered())
+
+	// delivered events are discarded from the subscription
+	assert.True(t, waitFor(func() bool {
+		evs, err := manager.PollEvent(Context, PollEventRequest{
+			ID:         id,
+			SessionKey: "session",
+			Count:      10,
+		})
+		return err == nil && evs.Offset == 4 && len(evs.Events) == 0
+	}))
+
+	manager.client.(*MockClient).On("UnsubscribeRequest",
+		mock.Anything, mock.Anything).Return(nil)
+	assert.Nil(t, manager.Unsubscribe(Context, UnsubscribeRequest{ID: id, SessionKey: "session"}))
+	assert.True(t, waitFor(func() bool { return manager.webhooks.Len() == 0 }))
+}
+
+// waitFor waits until the condition is met or a timeout elapses. It
+// returns false if the condition is not met
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
@@ -96,6 +96,20 @@ func (c *Client) GetPublicKey(
 	return &core.GetPublicKeyResponse{}, nil
 }
 
+func (c *Client) CallService(
+	ctx context.Context,
+	req core.CallServiceRequest,
+) (*core.CallServiceResponse, errors.Err) {
+	return nil, errors.New(errors.ErrAPINotImplemented, nil)
+}
+
+func (c *Client) EstimateGas(
+	ctx context.Context,
+	req core.EstimateGasRequest,
+) (*core.EstimateGasResponse, errors.Err) {
+	return nil, errors.New(errors.ErrAPINotImplemented, nil)
+}
+
 func (c *Client) ExecuteService(
 	ctx context.Context,
 	id uint64,
@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	stderr "errors"
 	"fmt"
+	"math/big"
 	"net/url"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -24,6 +25,8 @@ import (
 const (
 	getCode            string = "GetCode"
 	getPublicKey       string = "GetPublicKey"
+	callService        string = "CallService"
+	estimateGas        string = "EstimateGas"
 	deployService      string = "DeployService"
 	executeService     string = "ExecuteService"
 	subscribeRequest   string = "SubscribeRequest"
@@ -40,9 +43,10 @@ type executeTransactionRequest struct {
 }
 
 type executeTransactionResponse struct {
-	ID      uint64
-	Address string
-	Output  string
+	ID          uint64
+	Address     string
+	Output      string
+	Transaction *backend.Transaction
 }
 
 type ClientProps struct {
@@ -172,6 +176,145 @@ func (c *Client) GetPublicKey(
 	return v.(backend.GetPublicKeyResponse), nil
 }
 
+func (c *Client) callService(
+	ctx context.Context,
+	req backend.CallServiceRequest,
+) (backend.CallServiceResponse, errors.Err) {
+	c.logger.Debug(ctx, "", log.MapFields{
+		"call_type": "CallServiceAttempt",
+		"address":   req.Address,
+	})
+
+	if err := c.verifyAddress(req.Address); err != nil {
+		return backend.CallServiceResponse{}, err
+	}
+
+	data, err := c.decodeBytes(req.Data)
+	if err != nil {
+		return backend.CallServiceResponse{}, err
+	}
+
+	block, err := c.decodeBlock(req.Block)
+	if err != nil {
+		return backend.CallServiceResponse{}, err
+	}
+
+	wallet, ok := c.executor.Wallet()
+	if !ok {
+		return backend.CallServiceResponse{}, errors.New(errors.ErrInternalError,
+			stderr.New("no wallet available to call service"))
+	}
+
+	// the call is sent from the wallet that would send the transaction
+	// so that services that check the sender behave as they would
+	address := common.HexToAddress(req.Address)
+	output, cerr := c.client.Call(ctx, ethereum.CallMsg{
+		From: wallet,
+		To:   &address,
+		Data: data,
+	}, block)
+	if cerr != nil {
+		err := errors.New(errors.ErrInternalError, fmt.Errorf("failed to call service %s", cerr.Error()))
+		if cerr == eth.ErrExecutionReverted {
+			err = errors.New(errors.ErrCallServiceReverted, cerr)
+		}
+
+		c.logger.Debug(ctx, "client call failed", log.MapFields{
+			"call_type": "CallServiceFailure",
+			"address":   req.Address,
+		}, err)
+		return backend.CallServiceResponse{}, err
+	}
+
+	c.logger.Debug(ctx, "", log.MapFields{
+		"call_type": "CallServiceSuccess",
+		"address":   req.Address,
+	})
+
+	return backend.CallServiceResponse{
+		Address: req.Address,
+		Output:  hexutil.Encode(output),
+	}, nil
+}
+
+func (c *Client) CallService(
+	ctx context.Context,
+	req backend.CallServiceRequest,
+) (backend.CallServiceResponse, errors.Err) {
+	v, err := c.tracker.Instrument(callService, func() (interface{}, error) {
+		return c.callService(ctx, req)
+	})
+
+	if err != nil {
+		return backend.CallServiceResponse{}, err.(errors.Err)
+	}
+
+	return v.(backend.CallServiceResponse), nil
+}
+
+func (c *Client) estimateGas(
+	ctx context.Context,
+	req backend.EstimateGasRequest,
+) (backend.EstimateGasResponse, errors.Err) {
+	c.logger.Debug(ctx, "", log.MapFields{
+		"call_type": "EstimateGasAttempt",
+		"address":   req.Address,
+	})
+
+	if len(req.Address) > 0 {
+		if err := c.verifyAddress(req.Address); err != nil {
+			return backend.EstimateGasResponse{}, err
+		}
+	}
+
+	data, err := c.decodeBytes(req.Data)
+	if err != nil {
+		return backend.EstimateGasResponse{}, err
+	}
+
+	// the gas is estimated with the wallet that sends the transactions,
+	// so that the execution is estimated as it would be sent
+	res, err := c.executor.EstimateGas(ctx, tx.EstimateGasRequest{
+		Address: req.Address,
+		Data:    data,
+	})
+	if err != nil {
+		c.logger.Debug(ctx, "failed to estimate gas", log.MapFields{
+			"call_type": "EstimateGasFailure",
+			"address":   req.Address,
+		}, err)
+		return backend.EstimateGasResponse{}, err
+	}
+
+	c.logger.Debug(ctx, "", log.MapFields{
+		"call_type": "EstimateGasSuccess",
+		"address":   req.Address,
+		"gas":       res.Gas,
+		"fallback":  res.Fallback,
+	})
+
+	return backend.EstimateGasResponse{
+		Gas:      res.Gas,
+		GasPrice: uint64(tx.GasPrice),
+		Fallback: res.Fallback,
+	}, nil
+}
+
+func (c *Client) EstimateGas(
+	ctx context.Context,
+	req backend.EstimateGasRequest,
+) (backend.EstimateGasResponse, errors.Err) {
+	v, err := c.tracker.Instrument(estimateGas, func() (interface{}, error) {
+		return c.estimateGas(ctx, req)
+	})
+
+	if err != nil {
+		return backend.EstimateGasResponse{}, err.(errors.Err)
+	}
+
+	return v.(backend.EstimateGasResponse), nil
+}
+
 func (c *Client) verifyAddress(addr string) errors.Err {
 	if len(addr) != 42 {
 		return errors.New(errors.ErrInvalidAddress, nil)
@@ -220,8 +363,9 @@ func (c *Client) deployService(
 	}
 
 	return backend.DeployServiceResponse{
-		ID:      res.ID,
-		Address: res.Address,
+		ID:          res.ID,
+		Address:     res.Address,
+		Transaction: res.Transaction,
 	}, nil
 }
 
@@ -265,9 +409,10 @@ func (c *Client) executeService(
 	}
 
 	return backend.ExecuteServiceResponse{
-		ID:      res.ID,
-		Address: res.Address,
-		Output:  res.Output,
+		ID:          res.ID,
+		Address:     res.Address,
+		Output:      res.Output,
+		Transaction: res.Transaction,
 	}, nil
 }
 
@@ -291,30 +436,17 @@ func (c *Client) subscribeRequest(
 	req backend.CreateSubscriptionRequest,
 	ch chan<- interface{},
 ) errors.Err {
-	if req.Event != "logs" {
-		return errors.New(errors.ErrTopicLogsSupported, nil)
-	}
-
-	if len(req.Address) == 0 {
-		return errors.New(errors.ErrInvalidAddress, nil)
-	}
-
-	var topics [][]common.Hash
-	for _, topic := range req.Topics {
-		topics = append(topics, []common.Hash{common.HexToHash(topic)})
+	subscriber, err := makeSubscriber(req)
+	if err != nil {
+		return err
 	}
 
-	address := common.HexToAddress(req.Address)
-	if err := c.subman.Create(ctx, req.SubID, &eth.LogSubscriber{
-		FilterQuery: ethereum.FilterQuery{
-			Addresses: []common.Address{address},
-			Topics:    topics,
-		},
-	}, ch); err != nil {
+	if err := c.subman.Create(ctx, req.SubID, subscriber, ch); err != nil {
 		err := errors.New(errors.ErrInternalError, err)
 		c.logger.Debug(ctx, "failed to create subscription", log.MapFields{
 			"call_type": "SubscribeRequestFailure",
-			"address":   req.Address,
+			"event":     req.Event,
+			"addresses": req.Addresses,
 		}, err)
 		return err
 	}
@@ -322,6 +454,70 @@ func (c *Client) subscribeRequest(
 	return nil
 }
 
+// makeSubscriber creates the subscriber for the event type of the
+// request. Subscriptions to new blocks do not filter by address or
+// topics, and subscriptions to receipts only filter by address
+func makeSubscriber(req backend.CreateSubscriptionRequest) (eth.Subscriber, errors.Err) {
+	switch req.Event {
+	case "logs":
+		addresses, err := decodeAddresses(req.Addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		// a single filter query is created for all the addresses and
+		// topics so that a single subscription is needed
+		topics := make([][]common.Hash, 0, len(req.Topics))
+		for _, set := range req.Topics {
+			// a nil set matches any topic at its position
+			var hashes []common.Hash
+			for _, topic := range set {
+				hashes = append(hashes, common.HexToHash(topic))
+			}
+
+			topics = append(topics, hashes)
+		}
+
+		return &eth.LogSubscriber{
+			FilterQuery: ethereum.FilterQuery{
+				Addresses: addresses,
+				Topics:    topics,
+			},
+		}, nil
+	case "newHeads":
+		return &eth.HeadSubscriber{}, nil
+	case "receipts":
+		addresses, err := decodeAddresses(req.Addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		return &eth.ReceiptSubscriber{Addresses: addresses}, nil
+	default:
+		return nil, errors.New(errors.ErrTopicNotSupported, nil)
+	}
+}
+
+// decodeAddresses decodes the addresses a subscription filters
+// events by. At least one address is required and all of them
+// need to be valid hex addresses
+func decodeAddresses(addresses []string) ([]common.Address, errors.Err) {
+	if len(addresses) == 0 {
+		return nil, errors.New(errors.ErrInvalidAddress, nil)
+	}
+
+	decoded := make([]common.Address, 0, len(addresses))
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, errors.New(errors.ErrInvalidAddress, nil)
+		}
+
+		decoded = append(decoded, common.HexToAddress(address))
+	}
+
+	return decoded, nil
+}
+
 func (c *Client) UnsubscribeRequest(
 	ctx context.Context,
 	req backend.DestroySubscriptionRequest,
@@ -385,12 +581,58 @@ func (c *Client) executeTransaction(
 	})
 
 	return &executeTransactionResponse{
-		ID:      req.ID,
-		Address: res.Address,
-		Output:  res.Output,
+		ID:          req.ID,
+		Address:     res.Address,
+		Output:      res.Output,
+		Transaction: makeTransaction(res),
 	}, nil
 }
 
+func makeTransaction(res tx.ExecuteResponse) *backend.Transaction {
+	logs := make([]backend.Log, 0, len(res.Logs))
+	for _, log := range res.Logs {
+		topics := make([]string, 0, len(log.Topics))
+		for _, topic := range log.Topics {
+			topics = append(topics, topic.Hex())
+		}
+
+		logs = append(logs, backend.Log{
+			Address: log.Address.Hex(),
+			Topics:  topics,
+			Data:    hexutil.Encode(log.Data),
+			Index:   log.Index,
+		})
+	}
+
+	return &backend.Transaction{
+		Hash:        res.Hash,
+		BlockNumber: res.BlockNumber,
+		BlockHash:   res.BlockHash,
+		GasUsed:     res.GasUsed,
+		From:        res.From,
+		Logs:        logs,
+	}
+}
+
+// decodeBlock decodes the block number at which a call is executed. A
+// nil block number refers to the latest block
+func (c *Client) decodeBlock(s string) (*big.Int, errors.Err) {
+	if len(s) == 0 || s == "latest" {
+		return nil, nil
+	}
+
+	if block, err := hexutil.DecodeBig(s); err == nil {
+		return block, nil
+	}
+
+	block, ok := new(big.Int).SetString(s, 10)
+	if !ok || block.Sign() < 0 {
+		return nil, errors.New(errors.ErrInvalidBlock, fmt.Errorf("failed to decode block %s", s))
+	}
+
+	return block, nil
+}
+
 func (c *Client) decodeBytes(s string) ([]byte, errors.Err) {
 	data, err := hexutil.Decode(s)
 	if err != nil {
@@ -419,6 +661,8 @@ func NewClientWithDeps(ctx context.Context, deps *ClientDeps) *Client {
 		client:   deps.Client,
 		executor: deps.Executor,
 		tracker: stats.NewMethodTracker(getPublicKey,
+			callService,
+			estimateGas,
 			deployService,
 			executeService,
 			subscribeRequest,
@@ -10,6 +10,8 @@ import (
 	"sync/atomic"
 	"testing"
 
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/oasislabs/oasis-gateway/backend/core"
@@ -67,6 +69,26 @@ func NewClient() (*Client, error) {
 	}), nil
 }
 
+// mockTransaction returns the transaction metadata generated from the
+// responses of the default ethtest mock
+func mockTransaction() *backend.Transaction {
+	return &backend.Transaction{
+		Hash:        "0x00000000000000000000000000000000000000000000000000000000000000000",
+		BlockNumber: 5,
+		BlockHash:   "0x0000000000000000000000000000000000000000000000000000000000000004",
+		GasUsed:     21000,
+		From:        crypto.PubkeyToAddress(GetPrivateKey().PublicKey).Hex(),
+		Logs: []backend.Log{
+			{
+				Address: "0x0000000000000000000000000000000000000000",
+				Topics:  []string{"0x0000000000000000000000000000000000000000000000000000000000000002"},
+				Data:    "0x03",
+				Index:   0,
+			},
+		},
+	}
+}
+
 func TestGetCodeInvalidAddress(t *testing.T) {
 	client, err := NewClient()
 	assert.Nil(t, err)
@@ -115,6 +137,261 @@ func TestGetCodeOK(t *testing.T) {
 	}, pk)
 }
 
+func TestCallServiceInvalidAddress(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	_, err = client.CallService(Context, backend.CallServiceRequest{
+		Address: "0x",
+		Data:    "0x00",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "[2006] error code InputError with desc Provided invalid address.", err.Error())
+}
+
+func TestCallServiceInvalidBlock(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	_, err = client.CallService(Context, backend.CallServiceRequest{
+		Address: "0x0000000000000000000000000000000000000000",
+		Data:    "0x00",
+		Block:   "earliest",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "[2015] error code InputError with desc Provided invalid block. with cause failed to decode block earliest", err.Error())
+}
+
+func TestCallServiceErr(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"Call": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{nil, errors.New("error")},
+			},
+		})
+
+	_, err = client.CallService(Context, backend.CallServiceRequest{
+		Address: "0x0000000000000000000000000000000000000000",
+		Data:    "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "[1000] error code InternalError with desc Internal Error. Please check the status of the service. with cause failed to call service error", err.Error())
+}
+
+func TestCallServiceReverted(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"Call": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{nil, eth.ErrExecutionReverted},
+			},
+		})
+
+	_, err = client.CallService(Context, backend.CallServiceRequest{
+		Address: "0x0000000000000000000000000000000000000000",
+		Data:    "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "[2024] error code InputError with desc The service call failed because its execution reverted. with cause execution reverted", err.Error())
+}
+
+func TestCallServiceOK(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMock(client.client.(*ethtest.MockClient))
+
+	res, err := client.CallService(Context, backend.CallServiceRequest{
+		Address: "0x0000000000000000000000000000000000000000",
+		Data:    "0x00",
+		Block:   "0x10",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.CallServiceResponse{
+		Address: "0x0000000000000000000000000000000000000000",
+		Output:  "0x73756363657373",
+	}, res)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000000")
+	client.client.(*ethtest.MockClient).AssertCalled(t, "Call",
+		mock.Anything, ethereum.CallMsg{
+			From: crypto.PubkeyToAddress(GetPrivateKey().PublicKey),
+			To:   &to,
+			Data: []byte{0},
+		}, big.NewInt(16))
+}
+
+func TestEstimateGasInvalidAddress(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	_, err = client.EstimateGas(Context, backend.EstimateGasRequest{
+		Address: "0x",
+		Data:    "0x00",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "[2006] error code InputError with desc Provided invalid address.", err.Error())
+}
+
+func TestEstimateGasErr(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{uint64(0), errors.New("error")},
+			},
+		})
+
+	_, err = client.EstimateGas(Context, backend.EstimateGasRequest{
+		Data: "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "[1002] error code InternalError with desc Internal Error. Please check the status of the service. with cause error", err.Error())
+}
+
+func TestEstimateGasExecutionFailure(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{tx.EstimateGasFailure, nil},
+			},
+		})
+
+	_, err = client.EstimateGas(Context, backend.EstimateGasRequest{
+		Data: "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "[2016] error code InputError with desc Gas estimation failed because the transaction execution fails. with cause gas estimation could not be completed because of execution failure", err.Error())
+}
+
+func TestEstimateGasDeployOK(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{uint64(21000), nil},
+			},
+		})
+
+	res, err := client.EstimateGas(Context, backend.EstimateGasRequest{
+		Data: "0x00",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.EstimateGasResponse{
+		Gas:      21000,
+		GasPrice: uint64(tx.GasPrice),
+	}, res)
+	client.client.(*ethtest.MockClient).AssertCalled(t, "EstimateGas",
+		mock.Anything, ethereum.CallMsg{
+			From: crypto.PubkeyToAddress(GetPrivateKey().PublicKey),
+			Data: []byte{0},
+		})
+}
+
+func TestEstimateGasExecute(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{uint64(30000), nil},
+			},
+		})
+
+	res, err := client.EstimateGas(Context, backend.EstimateGasRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+		Data:    "0x00",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.EstimateGasResponse{
+		Gas:      30000,
+		GasPrice: uint64(tx.GasPrice),
+	}, res)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	client.client.(*ethtest.MockClient).AssertCalled(t, "EstimateGas",
+		mock.Anything, ethereum.CallMsg{
+			From: crypto.PubkeyToAddress(GetPrivateKey().PublicKey),
+			To:   &to,
+			Data: []byte{0},
+		})
+}
+
+func TestEstimateGasExecuteConfidentialFallback(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	// the mock returns a public key for the service, so it is confidential
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{uint64(0), errors.New("error")},
+			},
+		})
+
+	res, err := client.EstimateGas(Context, backend.EstimateGasRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+		Data:    "0x00",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.EstimateGasResponse{
+		Gas:      tx.ConfidentialGas,
+		GasPrice: uint64(tx.GasPrice),
+		Fallback: true,
+	}, res)
+}
+
+func TestEstimateGasExecuteRevertedNotConfidential(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"EstimateGas": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{uint64(0), eth.ErrExecutionReverted},
+			},
+			"GetPublicKey": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{eth.PublicKey{}, nil},
+			},
+		})
+
+	_, err = client.EstimateGas(Context, backend.EstimateGasRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+		Data:    "0x00",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "[2016] error code InputError with desc Gas estimation failed because the transaction execution fails. with cause execution reverted", err.Error())
+}
+
 func TestGetPublicKeyInvalidAddress(t *testing.T) {
 	client, err := NewClient()
 	assert.Nil(t, err)
@@ -196,8 +473,9 @@ func TestDeployServiceOK(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, backend.DeployServiceResponse{
-		ID:      uint64(1),
-		Address: "0x0000000000000000000000000000000000000000",
+		ID:          uint64(1),
+		Address:     "0x0000000000000000000000000000000000000000",
+		Transaction: mockTransaction(),
 	}, res)
 }
 
@@ -233,9 +511,10 @@ func TestExecuteServiceOK(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, backend.ExecuteServiceResponse{
-		ID:      uint64(1),
-		Address: "0x5d352cf2160f79CBF3554534cF25A4b42C43D502",
-		Output:  "0x73756363657373",
+		ID:          uint64(1),
+		Address:     "0x5d352cf2160f79CBF3554534cF25A4b42C43D502",
+		Output:      "0x73756363657373",
+		Transaction: mockTransaction(),
 	}, res)
 }
 
@@ -275,12 +554,12 @@ func TestSubscribeInvalidTopicErr(t *testing.T) {
 
 	c := make(chan interface{})
 	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
-		Event:   "topic",
-		Address: "address",
-		SubID:   "subID",
+		Event:     "topic",
+		Addresses: []string{"address"},
+		SubID:     "subID",
 	}, c)
 
-	assert.Equal(t, "[2012] error code InputError with desc Only logs topic supported for subscriptions.", err.Error())
+	assert.Equal(t, "[2012] error code InputError with desc Only logs, newHeads and receipts topics supported for subscriptions.", err.Error())
 }
 
 func TestSubscribeErr(t *testing.T) {
@@ -297,9 +576,9 @@ func TestSubscribeErr(t *testing.T) {
 
 	c := make(chan interface{})
 	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
-		Event:   "logs",
-		Address: "address",
-		SubID:   "subID",
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001"},
+		SubID:     "subID",
 	}, c)
 
 	assert.Equal(t, "[1000] error code InternalError with desc Internal Error. Please check the status of the service. with cause error", err.Error())
@@ -326,9 +605,9 @@ func TestSubscribeOK(t *testing.T) {
 
 	c := make(chan interface{})
 	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
-		Event:   "logs",
-		Address: "address",
-		SubID:   "subID",
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001"},
+		SubID:     "subID",
 	}, c)
 	assert.Nil(t, err)
 
@@ -336,6 +615,170 @@ func TestSubscribeOK(t *testing.T) {
 	close(c)
 }
 
+func TestSubscribeMultipleAddressesAndTopicSets(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	sub := &ethtest.MockSubscription{ErrC: make(chan error)}
+	query := make(chan ethereum.FilterQuery, 1)
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"SubscribeFilterLogs": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					query <- args.Get(1).(ethereum.FilterQuery)
+					close(args.Get(2).(chan<- types.Log))
+				},
+			},
+		})
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"},
+		SubID:     "subID",
+		Topics:    [][]string{{"0x03", "0x04"}, nil, {"0x05"}},
+	}, c)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")},
+		Topics: [][]common.Hash{
+			{common.HexToHash("0x03"), common.HexToHash("0x04")},
+			nil,
+			{common.HexToHash("0x05")},
+		},
+	}, <-query)
+	close(c)
+}
+
+func TestSubscribeEmptyAddressErr(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001", ""},
+		SubID:     "subID",
+	}, c)
+
+	assert.Equal(t, "[2006] error code InputError with desc Provided invalid address.", err.Error())
+}
+
+func TestSubscribeInvalidAddressErr(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001", "0x01"},
+		SubID:     "subID",
+	}, c)
+
+	assert.Equal(t, "[2006] error code InputError with desc Provided invalid address.", err.Error())
+}
+
+func TestSubscribeNewHeadsOK(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	sub := &ethtest.MockSubscription{ErrC: make(chan error)}
+	header := &types.Header{Number: big.NewInt(1)}
+
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"SubscribeNewHead": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					c := args.Get(1).(chan<- *types.Header)
+					c <- header
+					close(c)
+				},
+			},
+		})
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event: "newHeads",
+		SubID: "subID",
+	}, c)
+	assert.Nil(t, err)
+
+	assert.Equal(t, header, <-c)
+	close(c)
+}
+
+func TestSubscribeReceiptsNoAddressErr(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event: "receipts",
+		SubID: "subID",
+	}, c)
+
+	assert.Equal(t, "[2006] error code InputError with desc Provided invalid address.", err.Error())
+}
+
+func TestSubscribeReceiptsOK(t *testing.T) {
+	client, err := NewClient()
+	assert.Nil(t, err)
+
+	sub := &ethtest.MockSubscription{ErrC: make(chan error)}
+	watched := common.HexToAddress("0x01")
+	other := common.HexToAddress("0x02")
+	watchedTx := types.NewTransaction(0, watched, big.NewInt(0), 0, big.NewInt(0), nil)
+	otherTx := types.NewTransaction(1, other, big.NewInt(0), 0, big.NewInt(0), nil)
+
+	blocks := make(chan *big.Int, 3)
+	ethtest.ImplementMockWithOverwrite(client.client.(*ethtest.MockClient),
+		ethtest.MockMethods{
+			"SubscribeNewHead": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					c := args.Get(1).(chan<- *types.Header)
+					c <- &types.Header{Number: big.NewInt(1)}
+					c <- &types.Header{Number: big.NewInt(3)}
+				},
+			},
+			"BlockByNumber": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything},
+				Return: []interface{}{
+					types.NewBlock(&types.Header{}, []*types.Transaction{watchedTx, otherTx}, nil, nil), nil,
+				},
+				Run: func(args mock.Arguments) {
+					blocks <- args.Get(1).(*big.Int)
+				},
+			},
+		})
+
+	c := make(chan interface{})
+	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
+		Event:     "receipts",
+		Addresses: []string{watched.Hex()},
+		SubID:     "subID",
+	}, c)
+	assert.Nil(t, err)
+
+	// the receipts of the blocks that are skipped between two
+	// headers are also delivered
+	for i := int64(1); i <= 3; i++ {
+		receipt := (<-c).(*eth.Receipt)
+		assert.Equal(t, uint64(1), receipt.Status)
+		assert.Equal(t, big.NewInt(i), <-blocks)
+	}
+
+	client.client.(*ethtest.MockClient).AssertCalled(t, "TransactionReceipt", mock.Anything, watchedTx.Hash())
+	client.client.(*ethtest.MockClient).AssertNotCalled(t, "TransactionReceipt", mock.Anything, otherTx.Hash())
+}
+
 func TestSubscribeSubscriptionErr(t *testing.T) {
 	client, err := NewClient()
 	assert.Nil(t, err)
@@ -362,9 +805,9 @@ func TestSubscribeSubscriptionErr(t *testing.T) {
 
 	c := make(chan interface{})
 	err = client.SubscribeRequest(Context, backend.CreateSubscriptionRequest{
-		Event:   "logs",
-		Address: "address",
-		SubID:   "subID",
+		Event:     "logs",
+		Addresses: []string{"0x0000000000000000000000000000000000000001"},
+		SubID:     "subID",
 	}, c)
 	assert.Nil(t, err)
 
@@ -14,9 +14,10 @@ import (
 )
 
 type Deps struct {
-	Logger log.Logger
-	MQueue mqueue.MQueue
-	Client core.Client
+	Logger   log.Logger
+	MQueue   mqueue.MQueue
+	Client   core.Client
+	Webhooks core.WebhookClient
 }
 
 type ClientServices struct {
@@ -46,9 +47,10 @@ func (f RequestManagerFactoryFunc) New(ctx context.Context, deps *Deps) (*core.R
 
 var NewRequestManagerWithDeps = RequestManagerFactoryFunc(func(ctx context.Context, deps *Deps) (*core.RequestManager, error) {
 	return core.NewRequestManager(core.RequestManagerProperties{
-		MQueue: deps.MQueue,
-		Client: deps.Client,
-		Logger: deps.Logger,
+		MQueue:   deps.MQueue,
+		Client:   deps.Client,
+		Logger:   deps.Logger,
+		Webhooks: deps.Webhooks,
 	}), nil
 })
 
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -14,7 +15,10 @@ import (
 	"github.com/oasislabs/oasis-gateway/stats"
 )
 
-const walletOutOfFunds string = "WalletOutOfFunds"
+const (
+	walletOutOfFunds string = "WalletOutOfFunds"
+	postJSON         string = "PostJSON"
+)
 
 // CallbackProps are properties that can be passed
 // when executing a callback to modify the behaviour
@@ -76,7 +80,7 @@ type Deps struct {
 func NewClient(services *Services, props *Props) *Client {
 	return NewClientWithDeps(&Deps{
 		Logger: services.Logger,
-		Client: &http.Client{},
+		Client: &http.Client{Timeout: DefaultRequestTimeout},
 	}, props)
 }
 
@@ -88,7 +92,7 @@ func NewClientWithDeps(deps *Deps, props *Props) *Client {
 		retryConfig: props.RetryConfig,
 		client:      deps.Client,
 		logger:      deps.Logger,
-		tracker:     stats.NewMethodTracker(walletOutOfFunds),
+		tracker:     stats.NewMethodTracker(walletOutOfFunds, postJSON),
 	}
 }
 
@@ -258,6 +262,57 @@ func (c *Client) deliver(ctx context.Context, callback *Callback, req *http.Requ
 	return err
 }
 
+// PostJSON sends the body encoded as JSON in a POST request to the url.
+// Requests that cannot be sent or whose response has a 5xx status are
+// retried following the client's RetryConfig. It returns the status code
+// of the response
+func (c *Client) PostJSON(ctx context.Context, url string, body interface{}) (int, error) {
+	p, err := json.Marshal(body)
+	if err != nil {
+		return 0, ErrNewHttpRequest{Cause: err}
+	}
+
+	code, err := c.tracker.Instrument(postJSON, func() (interface{}, error) {
+		return concurrent.RetryWithConfig(ctx, concurrent.SupplierFunc(func() (interface{}, error) {
+			// the request is created on every attempt so that
+			// the body can be read again
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(p))
+			if err != nil {
+				return 0, concurrent.ErrCannotRecover{Cause: ErrNewHttpRequest{Cause: err}}
+			}
+
+			req = req.WithContext(ctx)
+			req.Header.Set("Content-Type", "application/json")
+
+			res, err := c.client.Do(req)
+			if err != nil {
+				return 0, err
+			}
+
+			if res.Body != nil {
+				_ = res.Body.Close()
+			}
+
+			if res.StatusCode >= 500 {
+				return 0, fmt.Errorf("http request failed with status %d", res.StatusCode)
+			}
+
+			return res.StatusCode, nil
+		}), c.retryConfig)
+	})
+
+	if err != nil {
+		c.logger.Debug(ctx, "failed to deliver http request", log.MapFields{
+			"call_type": "PostJSONFailure",
+			"url":       url,
+			"err":       err.Error(),
+		})
+		return 0, ErrDeliverHttpRequest{Cause: err}
+	}
+
+	return code.(int), nil
+}
+
 // WalletOutOfFunds sends a callback that is triggered when a wallet
 // is out of funds
 func (c *Client) WalletOutOfFunds(ctx context.Context, body WalletOutOfFundsBody) {
@@ -113,6 +113,60 @@ func TestClientCallbackSendNotOK(t *testing.T) {
 	mockclient.AssertCalled(t, "Do", mock.Anything)
 }
 
+func TestClientPostJSONOK(t *testing.T) {
+	client := newClient()
+	mockclient := client.client.(*MockHttpClient)
+
+	mockclient.On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusAccepted}, nil)
+
+	code, err := client.PostJSON(Context, "http://localhost:1234/", map[string]string{
+		"key": "value",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, code)
+	mockclient.AssertCalled(t, "Do", mock.MatchedBy(func(req *http.Request) bool {
+		v, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return false
+		}
+
+		return req.Method == http.MethodPost &&
+			req.URL.String() == "http://localhost:1234/" &&
+			req.Header.Get("Content-Type") == "application/json" &&
+			string(v) == `{"key":"value"}`
+	}))
+}
+
+func TestClientPostJSONClientError(t *testing.T) {
+	client := newClient()
+	mockclient := client.client.(*MockHttpClient)
+
+	mockclient.On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusBadRequest}, nil)
+
+	code, err := client.PostJSON(Context, "http://localhost:1234/", nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, code)
+	mockclient.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestClientPostJSONRetryServerError(t *testing.T) {
+	client := newClient()
+	mockclient := client.client.(*MockHttpClient)
+
+	mockclient.On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusInternalServerError}, nil)
+
+	_, err := client.PostJSON(Context, "http://localhost:1234/", nil)
+
+	_, ok := err.(ErrDeliverHttpRequest)
+	assert.True(t, ok)
+	mockclient.AssertNumberOfCalls(t, "Do", int(TestRetryConfig.Attempts))
+}
+
 func TestClientWalletOutOfFundsOK(t *testing.T) {
 	bodyTmpl, err := template.New("WalletOutOfFundsBody").Parse("{\"address\": \"{{.Address}}\"}")
 	assert.Nil(t, err)
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultRequestTimeout is the timeout of the requests sent
+// by the http clients created for the callbacks
+const DefaultRequestTimeout = 10 * time.Second
+
+// privateNetworks are the networks that are not reachable from
+// the internet and that the URLs provided by clients cannot target
+var privateNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"fc00::/7",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid network %s", cidr))
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// IsPublicIP returns false for loopback, link-local, private,
+// unspecified and multicast addresses
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HostPolicy restricts the hosts that can be reached through the URLs
+// provided by the clients of the gateway, so that those URLs cannot be
+// used to reach the network in which the gateway is deployed. Only the
+// hosts that resolve to public addresses and the hosts explicitly
+// allowed by the operator can be reached
+type HostPolicy struct {
+	allowed  map[string]bool
+	resolver *net.Resolver
+	dialer   *net.Dialer
+}
+
+// NewHostPolicy creates a new HostPolicy that also allows
+// the provided hosts to be reached
+func NewHostPolicy(allowedHosts []string) *HostPolicy {
+	allowed := make(map[string]bool)
+	for _, host := range allowedHosts {
+		allowed[strings.ToLower(host)] = true
+	}
+
+	return &HostPolicy{
+		allowed:  allowed,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{Timeout: DefaultRequestTimeout},
+	}
+}
+
+// resolve returns the addresses of the host. It fails if the host is
+// not allowed and any of its addresses is not public. It returns no
+// addresses for the allowed hosts, which are resolved when dialed
+func (p *HostPolicy) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	if p.allowed[strings.ToLower(host)] {
+		return nil, nil
+	}
+
+	addrs, err := p.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("host %s has no addresses", host)
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return nil, fmt.Errorf("host %s resolves to non public address %s", host, addr.IP)
+		}
+		ips = append(ips, addr.IP)
+	}
+
+	return ips, nil
+}
+
+// ValidateURL returns an error if the host of the URL cannot be reached
+func (p *HostPolicy) ValidateURL(ctx context.Context, rawurl string) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.resolve(ctx, u.Hostname())
+	return err
+}
+
+// DialContext connects to the address if its host can be reached. The
+// connection is made to the addresses that have been checked, so that
+// the host cannot resolve to a different address when dialed
+func (p *HostPolicy) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := p.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ips) == 0 {
+		return p.dialer.DialContext(ctx, network, address)
+	}
+
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, err = p.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
+
+// NewHttpClient creates an http client that can only reach the hosts
+// allowed by the policy. Requests time out after the provided timeout
+func (p *HostPolicy) NewHttpClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// requests are never sent through a proxy so
+			// that the policy applies to the actual host
+			Proxy:               nil,
+			DialContext:         p.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
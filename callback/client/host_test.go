@@ -0,0 +1,101 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, tc := range []struct {
+		ip     string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"0.0.0.0", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"224.0.0.1", false},
+	} {
+		assert.Equal(t, tc.public, IsPublicIP(net.ParseIP(tc.ip)), tc.ip)
+	}
+}
+
+func TestHostPolicyValidateURLErrPrivate(t *testing.T) {
+	policy := NewHostPolicy(nil)
+
+	for _, url := range []string{
+		"http://127.0.0.1:1234/events",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.1/events",
+		"http://[::1]:8080/events",
+		"http://localhost:1234/events",
+	} {
+		assert.Error(t, policy.ValidateURL(Context, url), url)
+	}
+}
+
+func TestHostPolicyValidateURLPublic(t *testing.T) {
+	policy := NewHostPolicy(nil)
+
+	assert.Nil(t, policy.ValidateURL(Context, "http://8.8.8.8/events"))
+}
+
+func TestHostPolicyValidateURLAllowed(t *testing.T) {
+	policy := NewHostPolicy([]string{"127.0.0.1", "LocalHost"})
+
+	assert.Nil(t, policy.ValidateURL(Context, "http://127.0.0.1:1234/events"))
+	assert.Nil(t, policy.ValidateURL(Context, "http://localhost:1234/events"))
+	assert.Error(t, policy.ValidateURL(Context, "http://10.0.0.1/events"))
+}
+
+func TestHostPolicyHttpClientErrPrivate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewHostPolicy(nil).NewHttpClient(time.Second)
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestHostPolicyHttpClientAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewHostPolicy([]string{"127.0.0.1"}).NewHttpClient(time.Second)
+
+	res, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res.Body.Close()
+}
+
+func TestHostPolicyHttpClientTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client := NewHostPolicy([]string{"127.0.0.1"}).NewHttpClient(10 * time.Millisecond)
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package callback
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -201,6 +202,42 @@ func (c *WalletReachedFundsThreshold) Log(fields log.Fields) {
 	fields.Add("callback.wallet_reached_funds_threshold.sync", c.Sync)
 }
 
+// Webhook is the configuration of the delivery of the events of
+// subscriptions to the callback URLs provided by the clients
+type Webhook struct {
+	// AllowedHosts are the hosts that callback URLs can target even
+	// if they resolve to private, loopback or link-local addresses
+	AllowedHosts []string
+
+	// TimeoutMs is the timeout of each delivery request
+	TimeoutMs int64
+}
+
+func (c *Webhook) Configure(v *viper.Viper) error {
+	c.AllowedHosts = v.GetStringSlice("callback.webhook.allowed_hosts")
+	c.TimeoutMs = v.GetInt64("callback.webhook.timeout_ms")
+	if c.TimeoutMs <= 0 {
+		return errors.New("callback.webhook.timeout_ms must be greater than 0")
+	}
+
+	return nil
+}
+
+func (c *Webhook) Bind(v *viper.Viper, cmd *cobra.Command) error {
+	cmd.PersistentFlags().StringSlice("callback.webhook.allowed_hosts", nil,
+		"hosts that the callback urls of subscriptions can target even if they "+
+			"resolve to private, loopback or link-local addresses.")
+	cmd.PersistentFlags().Int64("callback.webhook.timeout_ms", 10000,
+		"timeout of the requests that deliver events to callback urls.")
+
+	return nil
+}
+
+func (c *Webhook) Log(fields log.Fields) {
+	fields.Add("callback.webhook.allowed_hosts", strings.Join(c.AllowedHosts, ","))
+	fields.Add("callback.webhook.timeout_ms", c.TimeoutMs)
+}
+
 type Callback struct {
 	Enabled  bool
 	Sync     bool
@@ -215,6 +252,7 @@ type Config struct {
 	TransactionCommitted        TransactionCommitted
 	WalletOutOfFunds            WalletOutOfFunds
 	WalletReachedFundsThreshold WalletReachedFundsThreshold
+	Webhook                     Webhook
 }
 
 func (c *Config) Configure(v *viper.Viper) error {
@@ -227,6 +265,9 @@ func (c *Config) Configure(v *viper.Viper) error {
 	if err := c.WalletReachedFundsThreshold.Configure(v); err != nil {
 		return err
 	}
+	if err := c.Webhook.Configure(v); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -240,6 +281,9 @@ func (c *Config) Bind(v *viper.Viper, cmd *cobra.Command) error {
 	if err := c.WalletReachedFundsThreshold.Bind(v, cmd); err != nil {
 		return err
 	}
+	if err := c.Webhook.Bind(v, cmd); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -247,4 +291,5 @@ func (c *Config) Log(fields log.Fields) {
 	c.TransactionCommitted.Log(fields)
 	c.WalletOutOfFunds.Log(fields)
 	c.WalletReachedFundsThreshold.Log(fields)
+	c.Webhook.Log(fields)
 }
@@ -116,6 +116,6 @@ func NewClientWithDeps(ctx context.Context, deps *client.Deps, config *Config) (
 var NewClient = CallbacksFactoryFunc(func(ctx context.Context, services *ClientServices, config *Config) (*client.Client, error) {
 	return NewClientWithDeps(ctx, &client.Deps{
 		Logger: services.Logger,
-		Client: &http.Client{},
+		Client: &http.Client{Timeout: client.DefaultRequestTimeout},
 	}, config)
 })
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"sync"
@@ -11,6 +12,8 @@ import (
 	"github.com/oasislabs/oasis-gateway/gateway"
 	"github.com/oasislabs/oasis-gateway/log"
 	"github.com/oasislabs/oasis-gateway/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func publicServer(config *gateway.BindPublicConfig, router *rpc.HttpRouter) {
@@ -55,6 +58,58 @@ func publicServer(config *gateway.BindPublicConfig, router *rpc.HttpRouter) {
 	}
 }
 
+func publicGrpcServer(config *gateway.Config, group *gateway.ServiceGroup) {
+	httpInterface := config.BindPublicConfig.HttpInterface
+	grpcPort := config.BindPublicConfig.GrpcPort
+
+	var options []grpc.ServerOption
+	if config.BindPublicConfig.HttpsEnabled {
+		creds, err := credentials.NewServerTLSFromFile(
+			config.BindPublicConfig.TlsCertificatePath,
+			config.BindPublicConfig.TlsPrivateKeyPath)
+		if err != nil {
+			gateway.RootLogger.Fatal(gateway.RootContext, "failed to load tls credentials", log.MapFields{
+				"call_type": "GrpcPublicListenFailure",
+				"port":      grpcPort,
+				"interface": httpInterface,
+				"err":       err.Error(),
+			})
+			os.Exit(1)
+		}
+
+		options = append(options, grpc.Creds(creds))
+	}
+
+	s := gateway.NewPublicGrpcServer(config, group, options...)
+
+	gateway.RootLogger.Info(gateway.RootContext, "listening to port", log.MapFields{
+		"call_type": "GrpcPublicListenAttempt",
+		"port":      grpcPort,
+		"interface": httpInterface,
+	})
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", httpInterface, grpcPort))
+	if err != nil {
+		gateway.RootLogger.Fatal(gateway.RootContext, "grpc server failed to listen", log.MapFields{
+			"call_type": "GrpcPublicListenFailure",
+			"port":      grpcPort,
+			"interface": httpInterface,
+			"err":       err.Error(),
+		})
+		os.Exit(1)
+	}
+
+	if err := s.Serve(lis); err != nil {
+		gateway.RootLogger.Fatal(gateway.RootContext, "grpc server failed to serve", log.MapFields{
+			"call_type": "GrpcPublicListenFailure",
+			"port":      grpcPort,
+			"interface": httpInterface,
+			"err":       err.Error(),
+		})
+		os.Exit(1)
+	}
+}
+
 func privateServer(config *gateway.BindPrivateConfig, router *rpc.HttpRouter) {
 	httpInterface := config.HttpInterface
 	httpPort := config.HttpPort
@@ -134,6 +189,9 @@ func main() {
 	gateway.RootLogger.Info(gateway.RootContext, "callback config configuration parsed", log.MapFields{
 		"callType": "CallbackConfigParseSuccess",
 	}, &config.CallbackConfig)
+	gateway.RootLogger.Info(gateway.RootContext, "rate limit configuration parsed", log.MapFields{
+		"callType": "RateLimitConfigParseSuccess",
+	}, &config.RateLimitConfig)
 
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -159,5 +217,13 @@ func main() {
 		wg.Done()
 	}()
 
+	if config.BindPublicConfig.GrpcEnabled {
+		wg.Add(1)
+		go func() {
+			publicGrpcServer(config, group)
+			wg.Done()
+		}()
+	}
+
 	wg.Wait()
 }
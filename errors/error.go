@@ -278,6 +278,30 @@ var (
 		desc:     "Internal Error. Please check the status of the service.",
 	}
 
+	ErrHttpStreamingNotSupported = ErrorCode{
+		category: InternalError,
+		code:     1044,
+		desc:     "Internal Error. Please check the status of the service.",
+	}
+
+	ErrQueueWatch = ErrorCode{
+		category: InternalError,
+		code:     1045,
+		desc:     "Internal Error. Please check the status of the service.",
+	}
+
+	ErrQueueGet = ErrorCode{
+		category: InternalError,
+		code:     1046,
+		desc:     "Internal Error. Please check the status of the service.",
+	}
+
+	ErrSerializeEvent = ErrorCode{
+		category: InternalError,
+		code:     1047,
+		desc:     "Internal Error. Please check the status of the service.",
+	}
+
 	ErrOutOfRange = ErrorCode{
 		category: InputError,
 		code:     2001,
@@ -344,10 +368,10 @@ var (
 		desc:     "Provided invalid key.",
 	}
 
-	ErrTopicLogsSupported = ErrorCode{
+	ErrTopicNotSupported = ErrorCode{
 		category: InputError,
 		code:     2012,
-		desc:     "Only logs topic supported for subscriptions.",
+		desc:     "Only logs, newHeads and receipts topics supported for subscriptions.",
 	}
 
 	ErrStringNotHex = ErrorCode{
@@ -356,6 +380,72 @@ var (
 		desc:     "Provided string is not a valid hex encoding.",
 	}
 
+	ErrBatchTooLarge = ErrorCode{
+		category: InputError,
+		code:     2014,
+		desc:     "Batch exceeds the maximum number of items.",
+	}
+
+	ErrInvalidBlock = ErrorCode{
+		category: InputError,
+		code:     2015,
+		desc:     "Provided invalid block.",
+	}
+
+	ErrEstimateGasExecution = ErrorCode{
+		category: InputError,
+		code:     2016,
+		desc:     "Gas estimation failed because the transaction execution fails.",
+	}
+
+	ErrInvalidABI = ErrorCode{
+		category: InputError,
+		code:     2017,
+		desc:     "Provided invalid contract ABI.",
+	}
+
+	ErrInvalidCallbackURL = ErrorCode{
+		category: InputError,
+		code:     2018,
+		desc:     "Provided invalid callback URL.",
+	}
+
+	ErrInvalidSessionTTL = ErrorCode{
+		category: InputError,
+		code:     2019,
+		desc:     "Provided invalid session TTL.",
+	}
+
+	ErrHttpContentTypeNotSupported = ErrorCode{
+		category: InputError,
+		code:     2020,
+		desc:     "Content-type should be application/json, application/cbor or application/msgpack.",
+	}
+
+	ErrDeserializeBody = ErrorCode{
+		category: InputError,
+		code:     2021,
+		desc:     "Failed to deserialize body.",
+	}
+
+	ErrHttpContentEncodingNotSupported = ErrorCode{
+		category: InputError,
+		code:     2022,
+		desc:     "Content-encoding should be gzip, deflate or identity.",
+	}
+
+	ErrMultipleEventTypes = ErrorCode{
+		category: InputError,
+		code:     2023,
+		desc:     "A subscription can only be created for a single event type.",
+	}
+
+	ErrCallServiceReverted = ErrorCode{
+		category: InputError,
+		code:     2024,
+		desc:     "The service call failed because its execution reverted.",
+	}
+
 	ErrQueueLimitReached = ErrorCode{
 		category: ResourceLimitReached,
 		code:     3001,
@@ -363,6 +453,25 @@ var (
 			"No further requests can be processed until requests are confirmed.",
 	}
 
+	ErrABILimitReached = ErrorCode{
+		category: ResourceLimitReached,
+		code:     3002,
+		desc:     "The number of contract ABIs registered for the session has reached its limit.",
+	}
+
+	ErrRateLimitExceeded = ErrorCode{
+		category: ResourceLimitReached,
+		code:     3003,
+		desc:     "Too many requests. Retry after the time set in the Retry-After header.",
+	}
+
+	ErrWebSocketInflightLimitReached = ErrorCode{
+		category: ResourceLimitReached,
+		code:     3004,
+		desc: "The number of requests in flight in the WebSocket connection has reached its limit. " +
+			"No further requests can be sent until previous requests complete.",
+	}
+
 	ErrQueueDiscardNotExists = ErrorCode{
 		category: StateConflict,
 		code:     4001,
@@ -375,6 +484,24 @@ var (
 		desc:     "Attempt to create a subscription that already exists.",
 	}
 
+	ErrIdempotencyKeyInProgress = ErrorCode{
+		category: StateConflict,
+		code:     4003,
+		desc:     "A request with the same idempotency key is still in progress.",
+	}
+
+	ErrRequestCancelled = ErrorCode{
+		category: StateConflict,
+		code:     4004,
+		desc:     "The request has been cancelled.",
+	}
+
+	ErrIdempotencyKeyReused = ErrorCode{
+		category: StateConflict,
+		code:     4005,
+		desc:     "The idempotency key has already been used for a different request.",
+	}
+
 	ErrAPINotImplemented = ErrorCode{
 		category: NotImplemented,
 		code:     5001,
@@ -393,6 +520,24 @@ var (
 		desc:     "Subscription not found.",
 	}
 
+	ErrAPINotFound = ErrorCode{
+		category: NotFound,
+		code:     6003,
+		desc:     "API not found.",
+	}
+
+	ErrRequestNotFound = ErrorCode{
+		category: NotFound,
+		code:     6004,
+		desc:     "Request not found or already completed.",
+	}
+
+	ErrSessionNotFound = ErrorCode{
+		category: NotFound,
+		code:     6005,
+		desc:     "Session not found or already closed.",
+	}
+
 	ErrInvalidAAD = ErrorCode{
 		category: AuthenticationError,
 		code:     7001,
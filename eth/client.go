@@ -22,24 +22,30 @@ var (
 	ErrExceedsBalance    = errors.New("cost of transaction exceeds sender balance")
 	ErrExceedsBlockLimit = errors.New("requested gas greater than block gas limit")
 	ErrInvalidNonce      = errors.New("invalid transaction nonce")
+	ErrExecutionReverted = errors.New("execution reverted")
 )
 
 type Client interface {
+	Call(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error)
 	EstimateGas(context.Context, ethereum.CallMsg) (uint64, error)
 	GetPublicKey(context.Context, common.Address) (PublicKey, error)
 	NonceAt(context.Context, common.Address) (uint64, error)
 	SendTransaction(context.Context, *types.Transaction) (SendTransactionResponse, error)
 	SubscribeFilterLogs(context.Context, ethereum.FilterQuery, chan<- types.Log) (ethereum.Subscription, error)
-	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	SubscribeNewHead(context.Context, chan<- *types.Header) (ethereum.Subscription, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	GetCode(ctx context.Context, addr common.Address) (string, error)
 }
 
 type ethClient interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 	NonceAt(ctx context.Context, account common.Address, n *big.Int) (uint64, error)
-	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, c chan<- types.Log) (ethereum.Subscription, error)
+	SubscribeNewHead(ctx context.Context, c chan<- *types.Header) (ethereum.Subscription, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	CodeAt(ctx context.Context, addr common.Address, blockNumber *big.Int) ([]byte, error)
 	Close()
@@ -83,6 +89,8 @@ func (c *PooledClient) inferError(err error) error {
 		return concurrent.ErrCannotRecover{Cause: ErrExceedsBlockLimit}
 	case strings.Contains(err.Error(), "Invalid transaction nonce"):
 		return concurrent.ErrCannotRecover{Cause: ErrInvalidNonce}
+	case strings.Contains(strings.ToLower(err.Error()), "revert"):
+		return concurrent.ErrCannotRecover{Cause: ErrExecutionReverted}
 	default:
 		return err
 	}
@@ -117,6 +125,18 @@ func (c *PooledClient) request(ctx context.Context, fn func(conn *Conn) (interfa
 	return v, nil
 }
 
+func (c *PooledClient) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	v, err := c.request(ctx, func(conn *Conn) (interface{}, error) {
+		return conn.eclient.CallContract(ctx, msg, blockNumber)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]byte), nil
+}
+
 func (c *PooledClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
 	v, err := c.request(ctx, func(conn *Conn) (interface{}, error) {
 		return conn.eclient.EstimateGas(ctx, msg)
@@ -216,16 +236,28 @@ func (c *PooledClient) GetCode(ctx context.Context, addr common.Address) (string
 	return hexutil.Encode(v.([]byte)), nil
 }
 
-func (c *PooledClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+func (c *PooledClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
 	v, err := c.request(ctx, func(conn *Conn) (interface{}, error) {
-		return conn.eclient.TransactionReceipt(ctx, txHash)
+		// the receipt is retrieved through the rpc client because the
+		// receipt decoded by the eth client does not include the
+		// information of the block
+		var receipt *Receipt
+		if err := conn.rclient.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+			return nil, err
+		}
+
+		if receipt == nil {
+			return nil, ethereum.NotFound
+		}
+
+		return receipt, nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return v.(*types.Receipt), nil
+	return v.(*Receipt), nil
 }
 
 func (c *PooledClient) SubscribeFilterLogs(
@@ -244,6 +276,33 @@ func (c *PooledClient) SubscribeFilterLogs(
 	return v.(ethereum.Subscription), nil
 }
 
+func (c *PooledClient) SubscribeNewHead(
+	ctx context.Context,
+	ch chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	v, err := c.request(ctx, func(conn *Conn) (interface{}, error) {
+		return conn.eclient.SubscribeNewHead(ctx, ch)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(ethereum.Subscription), nil
+}
+
+func (c *PooledClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	v, err := c.request(ctx, func(conn *Conn) (interface{}, error) {
+		return conn.eclient.BlockByNumber(ctx, number)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*types.Block), nil
+}
+
 type Conn struct {
 	eclient ethClient
 	rclient rpcClient
@@ -2,8 +2,10 @@ package eth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +57,15 @@ func (c *mockEthClient) BalanceAt(ctx context.Context, address common.Address, b
 	return args.Get(0).(*big.Int), nil
 }
 
+func (c *mockEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	args := c.Called(ctx, msg, block)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), nil
+}
+
 func (c *mockEthClient) CodeAt(ctx context.Context, address common.Address, block *big.Int) ([]byte, error) {
 	args := c.Called(ctx, address, block)
 	if args.Get(1) != nil {
@@ -82,17 +93,17 @@ func (c *mockEthClient) NonceAt(ctx context.Context, account common.Address, n *
 	return args.Get(0).(uint64), nil
 }
 
-func (c *mockEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	args := c.Called(ctx, txHash)
+func (c *mockEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	args := c.Called(ctx, q, ch)
 	if args.Get(1) != nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).(*types.Receipt), nil
+	return args.Get(0).(ethereum.Subscription), nil
 }
 
-func (c *mockEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	args := c.Called(ctx, q, ch)
+func (c *mockEthClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	args := c.Called(ctx, ch)
 	if args.Get(1) != nil {
 		return nil, args.Error(1)
 	}
@@ -100,6 +111,15 @@ func (c *mockEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filt
 	return args.Get(0).(ethereum.Subscription), nil
 }
 
+func (c *mockEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	args := c.Called(ctx, number)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*types.Block), nil
+}
+
 func (c *mockEthClient) Close() {
 	c.Called()
 }
@@ -209,3 +229,109 @@ func TestPooledClientSendTransactionCallErr(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "maximum number of attempts 10 reached with last error error", err.Error())
 }
+
+func TestPooledClientCallOK(t *testing.T) {
+	pool := mockPool{conn: &Conn{eclient: &mockEthClient{}, rclient: &mockRpcClient{}}}
+	c := NewPooledClient(PooledClientProps{
+		Pool:        pool,
+		RetryConfig: TestRetryConfig,
+	})
+
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	msg := ethereum.CallMsg{To: &to, Data: []byte{1}}
+	pool.conn.eclient.(*mockEthClient).
+		On("CallContract", mock.Anything, msg, big.NewInt(1)).
+		Return([]byte{2}, nil)
+
+	output, err := c.Call(context.Background(), msg, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2}, output)
+}
+
+func TestPooledClientCallErr(t *testing.T) {
+	pool := mockPool{conn: &Conn{eclient: &mockEthClient{}, rclient: &mockRpcClient{}}}
+	c := NewPooledClient(PooledClientProps{
+		Pool:        pool,
+		RetryConfig: TestRetryConfig,
+	})
+
+	pool.conn.eclient.(*mockEthClient).
+		On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("error"))
+
+	_, err := c.Call(context.Background(), ethereum.CallMsg{}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "maximum number of attempts 10 reached with last error error", err.Error())
+}
+
+func TestPooledClientCallReverted(t *testing.T) {
+	pool := mockPool{conn: &Conn{eclient: &mockEthClient{}, rclient: &mockRpcClient{}}}
+	c := NewPooledClient(PooledClientProps{
+		Pool:        pool,
+		RetryConfig: TestRetryConfig,
+	})
+
+	pool.conn.eclient.(*mockEthClient).
+		On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("execution reverted"))
+
+	_, err := c.Call(context.Background(), ethereum.CallMsg{}, nil)
+	assert.Equal(t, ErrExecutionReverted, err)
+	pool.conn.eclient.(*mockEthClient).AssertNumberOfCalls(t, "CallContract", 1)
+}
+
+func TestPooledClientTransactionReceiptOK(t *testing.T) {
+	pool := mockPool{conn: &Conn{eclient: &mockEthClient{}, rclient: &mockRpcClient{}}}
+	c := NewPooledClient(PooledClientProps{
+		Pool:        pool,
+		RetryConfig: TestRetryConfig,
+	})
+
+	hash := common.HexToHash("0x01")
+	pool.conn.rclient.(*mockRpcClient).
+		On("CallContext", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			err := json.Unmarshal([]byte(`{
+				"blockHash": "0x0000000000000000000000000000000000000000000000000000000000000002",
+				"blockNumber": "0x3",
+				"contractAddress": null,
+				"from": "0x0000000000000000000000000000000000000004",
+				"to": "0x0000000000000000000000000000000000000005",
+				"cumulativeGasUsed": "0x5208",
+				"gasUsed": "0x5208",
+				"logs": [],
+				"logsBloom": "0x`+strings.Repeat("0", 512)+`",
+				"status": "0x1",
+				"transactionHash": "0x0000000000000000000000000000000000000000000000000000000000000001"
+			}`), args[1])
+			assert.Nil(t, err)
+		}).
+		Return(nil)
+
+	receipt, err := c.TransactionReceipt(context.Background(), hash)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), receipt.BlockNumber)
+	assert.Equal(t, common.HexToHash("0x02"), receipt.BlockHash)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.Equal(t, hash, receipt.TxHash)
+	assert.Equal(t, uint64(1), receipt.Status)
+	assert.Equal(t, common.HexToAddress("0x04"), receipt.From)
+	assert.Equal(t, common.HexToAddress("0x05"), *receipt.To)
+}
+
+func TestPooledClientTransactionReceiptNotFound(t *testing.T) {
+	pool := mockPool{conn: &Conn{eclient: &mockEthClient{}, rclient: &mockRpcClient{}}}
+	c := NewPooledClient(PooledClientProps{
+		Pool: pool,
+		RetryConfig: concurrent.RetryConfig{
+			Attempts: 1,
+		},
+	})
+
+	pool.conn.rclient.(*mockRpcClient).
+		On("CallContext", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil)
+
+	_, err := c.TransactionReceipt(context.Background(), common.HexToHash("0x01"))
+	assert.Error(t, err)
+}
@@ -1,5 +1,13 @@
 package eth
 
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
 type PublicKey struct {
 	Timestamp uint64 `json:"timestamp"`
 	PublicKey string `json:"public_key"`
@@ -17,3 +25,49 @@ type sendTransactionResponseDeserialize struct {
 	Status string `json:"status"`
 	Hash   string `json:"transactionHash"`
 }
+
+// Receipt is the receipt of a transaction along with the
+// information of the block in which the transaction was included
+type Receipt struct {
+	types.Receipt
+
+	// BlockHash is the hash of the block in which the transaction
+	// was included
+	BlockHash common.Hash
+
+	// BlockNumber is the number of the block in which the transaction
+	// was included
+	BlockNumber uint64
+
+	// From is the address of the sender of the transaction
+	From common.Address
+
+	// To is the address of the receiver of the transaction. It is
+	// nil for contract creation transactions
+	To *common.Address
+}
+
+type receiptBlockDeserialize struct {
+	BlockHash   common.Hash     `json:"blockHash"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to"`
+}
+
+// UnmarshalJSON is the implementation of json.Unmarshaler for Receipt
+func (r *Receipt) UnmarshalJSON(p []byte) error {
+	if err := json.Unmarshal(p, &r.Receipt); err != nil {
+		return err
+	}
+
+	var block receiptBlockDeserialize
+	if err := json.Unmarshal(p, &block); err != nil {
+		return err
+	}
+
+	r.BlockHash = block.BlockHash
+	r.BlockNumber = uint64(block.BlockNumber)
+	r.From = block.From
+	r.To = block.To
+	return nil
+}
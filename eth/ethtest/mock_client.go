@@ -20,6 +20,10 @@ type MockMethod struct {
 type MockMethods map[string]MockMethod
 
 var DefaultMockMethods = map[string]MockMethod{
+	"Call": {
+		Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+		Return:    []interface{}{[]byte("success"), nil},
+	},
 	"EstimateGas": {
 		Arguments: []interface{}{mock.Anything, mock.Anything},
 		Return:    []interface{}{uint64(0), nil},
@@ -39,9 +43,23 @@ var DefaultMockMethods = map[string]MockMethod{
 	"TransactionReceipt": {
 		Arguments: []interface{}{mock.Anything, mock.Anything},
 		Return: []interface{}{
-			&types.Receipt{
-				Status:          1,
-				ContractAddress: common.HexToAddress("0x0000000000000000000000000000000000000000"),
+			&eth.Receipt{
+				Receipt: types.Receipt{
+					Status:          1,
+					ContractAddress: common.HexToAddress("0x0000000000000000000000000000000000000000"),
+					TxHash:          common.HexToHash("0x01"),
+					GasUsed:         21000,
+					Logs: []*types.Log{
+						{
+							Address: common.HexToAddress("0x0000000000000000000000000000000000000000"),
+							Topics:  []common.Hash{common.HexToHash("0x02")},
+							Data:    []byte{3},
+							Index:   0,
+						},
+					},
+				},
+				BlockHash:   common.HexToHash("0x04"),
+				BlockNumber: 5,
 			}, nil,
 		},
 	},
@@ -71,6 +89,18 @@ var DefaultMockMethods = map[string]MockMethod{
 			&MockSubscription{ErrC: make(chan error)}, nil,
 		},
 	},
+	"SubscribeNewHead": {
+		Arguments: []interface{}{mock.Anything, mock.Anything},
+		Return: []interface{}{
+			&MockSubscription{ErrC: make(chan error)}, nil,
+		},
+	},
+	"BlockByNumber": {
+		Arguments: []interface{}{mock.Anything, mock.Anything},
+		Return: []interface{}{
+			types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}), nil,
+		},
+	},
 }
 
 func OverwriteDefaults(overwrite MockMethods) MockMethods {
@@ -124,6 +154,19 @@ func (c *MockClient) BalanceAt(
 	return args.Get(0).(*big.Int), nil
 }
 
+func (m *MockClient) Call(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+	block *big.Int,
+) ([]byte, error) {
+	args := m.Called(ctx, msg, block)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), nil
+}
+
 func (m *MockClient) EstimateGas(
 	ctx context.Context,
 	msg ethereum.CallMsg,
@@ -176,7 +219,29 @@ func (m *MockClient) SubscribeFilterLogs(
 	return args.Get(0).(*MockSubscription), nil
 }
 
-func (m *MockClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+func (m *MockClient) SubscribeNewHead(
+	ctx context.Context,
+	c chan<- *types.Header,
+) (ethereum.Subscription, error) {
+	args := m.Called(ctx, c)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MockSubscription), nil
+}
+
+func (m *MockClient) BlockByNumber(
+	ctx context.Context,
+	number *big.Int,
+) (*types.Block, error) {
+	args := m.Called(ctx, number)
+	if args.Get(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Block), nil
+}
+
+func (m *MockClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*eth.Receipt, error) {
 	args := m.Called(ctx, txHash)
-	return args.Get(0).(*types.Receipt), args.Error(1)
+	return args.Get(0).(*eth.Receipt), args.Error(1)
 }
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/oasislabs/oasis-gateway/concurrent"
 	"github.com/oasislabs/oasis-gateway/log"
@@ -113,6 +114,179 @@ func (s *LogSubscriber) Subscribe(
 	return &EthSubscription{sub: sub, err: cerr}, nil
 }
 
+// HeadSubscriber creates subscriptions that deliver the header
+// of each new block added to the chain
+type HeadSubscriber struct{}
+
+// Subscribe implementation of Subscriber for HeadSubscriber
+func (s *HeadSubscriber) Subscribe(
+	ctx context.Context,
+	client Client,
+	c chan<- interface{},
+) (ethereum.Subscription, error) {
+	cerr := make(chan error)
+	cheader := make(chan *types.Header, 64)
+
+	sub, err := client.SubscribeNewHead(ctx, cheader)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(cerr)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case header, ok := <-cheader:
+				if !ok {
+					return
+				}
+
+				c <- header
+			case err, ok := <-sub.Err():
+				if !ok {
+					return
+				}
+
+				cerr <- err
+				return
+			}
+		}
+	}()
+
+	return &EthSubscription{sub: sub, err: cerr}, nil
+}
+
+// maxReceiptCatchUpBlocks is the maximum number of blocks a
+// ReceiptSubscriber goes back to deliver the receipts of blocks
+// it missed while it was not subscribed
+const maxReceiptCatchUpBlocks uint64 = 64
+
+// ReceiptSubscriber creates subscriptions that deliver the receipts
+// of the transactions sent to any of the addresses once they are
+// included in a block
+type ReceiptSubscriber struct {
+	lock      sync.Mutex
+	Addresses []common.Address
+
+	// BlockNumber is the number of the last block for which
+	// all the receipts have been delivered
+	BlockNumber uint64
+}
+
+func (s *ReceiptSubscriber) watches(address *common.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	for _, watched := range s.Addresses {
+		if watched == *address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deliver sends the receipts of the watched transactions included in
+// the blocks up to number that have not been delivered yet. If it
+// fails within a block, the receipts of that block will be delivered
+// again the next time a block is received
+func (s *ReceiptSubscriber) deliver(
+	ctx context.Context,
+	client Client,
+	number uint64,
+	c chan<- interface{},
+) error {
+	s.lock.Lock()
+	last := s.BlockNumber
+	s.lock.Unlock()
+
+	if last != 0 && number <= last {
+		return nil
+	}
+
+	from := last + 1
+	if last == 0 || number-last > maxReceiptCatchUpBlocks {
+		from = number
+	}
+
+	for n := from; n <= number; n++ {
+		block, err := client.BlockByNumber(ctx, big.NewInt(0).SetUint64(n))
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range block.Transactions() {
+			if !s.watches(tx.To()) {
+				continue
+			}
+
+			receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return err
+			}
+
+			c <- receipt
+		}
+
+		s.lock.Lock()
+		s.BlockNumber = n
+		s.lock.Unlock()
+	}
+
+	return nil
+}
+
+// Subscribe implementation of Subscriber for ReceiptSubscriber
+func (s *ReceiptSubscriber) Subscribe(
+	ctx context.Context,
+	client Client,
+	c chan<- interface{},
+) (ethereum.Subscription, error) {
+	cerr := make(chan error)
+	cheader := make(chan *types.Header, 64)
+
+	sub, err := client.SubscribeNewHead(ctx, cheader)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(cerr)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case header, ok := <-cheader:
+				if !ok {
+					return
+				}
+
+				if err := s.deliver(ctx, client, header.Number.Uint64(), c); err != nil {
+					// the subscription is recreated on failure, at which
+					// point the blocks that were missed are delivered
+					sub.Unsubscribe()
+					cerr <- err
+					return
+				}
+			case err, ok := <-sub.Err():
+				if !ok {
+					return
+				}
+
+				cerr <- err
+				return
+			}
+		}
+	}()
+
+	return &EthSubscription{sub: sub, err: cerr}, nil
+}
+
 // Subscriber is an interface for types that creates subscriptions
 // against an ethereum-like backend
 type Subscriber interface {
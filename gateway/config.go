@@ -10,6 +10,7 @@ import (
 	"github.com/oasislabs/oasis-gateway/config"
 	"github.com/oasislabs/oasis-gateway/log"
 	"github.com/oasislabs/oasis-gateway/mqueue"
+	"github.com/oasislabs/oasis-gateway/ratelimit"
 	"github.com/oasislabs/oasis-gateway/rpc"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,6 +24,7 @@ type Config struct {
 	MailboxConfig     mqueue.Config
 	AuthConfig        auth.Config
 	CallbackConfig    callback.Config
+	RateLimitConfig   ratelimit.Config
 	LoggingConfig     LoggingConfig
 }
 
@@ -42,6 +44,7 @@ func (c *Config) Binders() []config.Binder {
 		&c.MailboxConfig,
 		&c.AuthConfig,
 		&c.CallbackConfig,
+		&c.RateLimitConfig,
 		&c.LoggingConfig,
 	}
 }
@@ -53,6 +56,7 @@ func (c *Config) Log(fields log.Fields) {
 	c.MailboxConfig.Log(fields)
 	c.AuthConfig.Log(fields)
 	c.CallbackConfig.Log(fields)
+	c.RateLimitConfig.Log(fields)
 	c.LoggingConfig.Log(fields)
 }
 
@@ -144,6 +148,11 @@ func (c *BindConfig) Bind(prefix string, v *viper.Viper, cmd *cobra.Command) err
 type BindPublicConfig struct {
 	BindConfig
 	rpc.HttpCorsPreProcessorProps
+
+	// GrpcEnabled is true if the public API is also served
+	// over gRPC on GrpcPort
+	GrpcEnabled bool
+	GrpcPort    int32
 }
 
 func (c *BindPublicConfig) Log(fields log.Fields) {
@@ -163,6 +172,8 @@ func (c *BindPublicConfig) Log(fields log.Fields) {
 	fields.Add("bind_public.http_cors.exposed_headers", c.HttpCorsPreProcessorProps.ExposedHeaders)
 	fields.Add("bind_public.http_cors.max_age", c.HttpCorsPreProcessorProps.MaxAge)
 	fields.Add("bind_public.http_cors.allowed_credentials", c.HttpCorsPreProcessorProps.AllowCredentials)
+	fields.Add("bind_public.grpc_enabled", c.GrpcEnabled)
+	fields.Add("bind_public.grpc_port", c.GrpcPort)
 }
 
 func (c *BindPublicConfig) Configure(v *viper.Viper) error {
@@ -178,6 +189,12 @@ func (c *BindPublicConfig) Configure(v *viper.Viper) error {
 	c.HttpCorsPreProcessorProps.MaxAge = v.GetInt("bind_public.http_cors.max_age")
 	c.HttpCorsPreProcessorProps.AllowCredentials = v.GetBool("bind_public.http_cors.allowed_credentials")
 
+	c.GrpcEnabled = v.GetBool("bind_public.grpc_enabled")
+	c.GrpcPort = v.GetInt32("bind_public.grpc_port")
+	if c.GrpcPort > 65535 || c.GrpcPort < 0 {
+		return errors.New("bind_public.grpc_port must be an integer between 0 and 65535")
+	}
+
 	return nil
 }
 
@@ -200,6 +217,11 @@ func (c *BindPublicConfig) Bind(v *viper.Viper, cmd *cobra.Command) error {
 		"exposed headers for CORS")
 	cmd.PersistentFlags().Bool("bind_public.http_cors.allowed_credentials", true,
 		"whether credentials are allowed when using CORS")
+	cmd.PersistentFlags().Bool("bind_public.grpc_enabled", false,
+		"if set the public API is also served over gRPC on "+
+			"bind_public.grpc_port")
+	cmd.PersistentFlags().Int32("bind_public.grpc_port", 1236,
+		"port to listen to for gRPC")
 
 	return nil
 }
@@ -2,10 +2,14 @@ package gateway
 
 import (
 	"context"
+	"time"
 
+	apigrpc "github.com/oasislabs/oasis-gateway/api/grpc"
 	"github.com/oasislabs/oasis-gateway/api/v0/event"
 	"github.com/oasislabs/oasis-gateway/api/v0/health"
 	"github.com/oasislabs/oasis-gateway/api/v0/service"
+	"github.com/oasislabs/oasis-gateway/api/v0/session"
+	v1 "github.com/oasislabs/oasis-gateway/api/v1"
 	"github.com/oasislabs/oasis-gateway/auth"
 	authcore "github.com/oasislabs/oasis-gateway/auth/core"
 	"github.com/oasislabs/oasis-gateway/backend"
@@ -15,8 +19,10 @@ import (
 	"github.com/oasislabs/oasis-gateway/log"
 	"github.com/oasislabs/oasis-gateway/mqueue"
 	mqueuecore "github.com/oasislabs/oasis-gateway/mqueue/core"
+	"github.com/oasislabs/oasis-gateway/ratelimit"
 	"github.com/oasislabs/oasis-gateway/rpc"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 // RootLogger is the base logger of the application, all
@@ -36,6 +42,9 @@ type ServiceGroup struct {
 	Request       *backendcore.RequestManager
 	Backend       backendcore.Client
 	Authenticator authcore.Auth
+
+	// RateLimiter is nil if rate limiting is not enabled
+	RateLimiter ratelimit.Limiter
 }
 
 type ServiceFactories struct {
@@ -44,6 +53,7 @@ type ServiceFactories struct {
 	BackendClientFactory  backend.ClientFactory
 	BackendRequestManager backend.RequestManagerFactory
 	AuthFactory           auth.Factory
+	RateLimiterFactory    ratelimit.LimiterFactory
 }
 
 func setDefaultFactories(factories *ServiceFactories) *ServiceFactories {
@@ -65,6 +75,9 @@ func setDefaultFactories(factories *ServiceFactories) *ServiceFactories {
 	if factories.AuthFactory == nil {
 		factories.AuthFactory = auth.NewAuth
 	}
+	if factories.RateLimiterFactory == nil {
+		factories.RateLimiterFactory = ratelimit.NewLimiter
+	}
 
 	return factories
 }
@@ -114,9 +127,10 @@ func NewServiceGroupWithFactories(ctx context.Context, config *Config, factories
 	}
 
 	request, err := factories.BackendRequestManager.New(ctx, &backend.Deps{
-		Logger: RootLogger,
-		MQueue: mqueue,
-		Client: client,
+		Logger:   RootLogger,
+		MQueue:   mqueue,
+		Client:   client,
+		Webhooks: NewWebhookClient(&config.CallbackConfig.Webhook),
 	})
 	if err != nil {
 		return nil, err
@@ -128,15 +142,35 @@ func NewServiceGroupWithFactories(ctx context.Context, config *Config, factories
 	}
 	authenticator.SetLogger(RootLogger)
 
+	limiter, err := factories.RateLimiterFactory.New(ctx, &config.RateLimitConfig, &config.MailboxConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ServiceGroup{
 		Mailbox:       mqueue,
 		Request:       request,
 		Backend:       client,
 		Authenticator: authenticator,
 		Callback:      callbacks,
+		RateLimiter:   limiter,
 	}, nil
 }
 
+// NewWebhookClient creates the client that delivers the events of
+// subscriptions to the callback URLs provided by the clients. The
+// requests can only reach public hosts and the hosts allowed
+// by the configuration
+func NewWebhookClient(config *callback.Webhook) *event.WebhookClient {
+	hosts := callbackclient.NewHostPolicy(config.AllowedHosts)
+	poster := callbackclient.NewClientWithDeps(&callbackclient.Deps{
+		Logger: RootLogger,
+		Client: hosts.NewHttpClient(time.Duration(config.TimeoutMs) * time.Millisecond),
+	}, &callbackclient.Props{})
+
+	return event.NewWebhookClient(poster, hosts)
+}
+
 func NewServiceGroup(ctx context.Context, config *Config) (*ServiceGroup, error) {
 	return NewServiceGroupWithFactories(ctx, config, nil)
 }
@@ -189,35 +223,133 @@ func NewPrivateRouter(config *Config, services Services, group *ServiceGroup) *r
 	return binder.Build()
 }
 
+// newOperationLimiter creates the limiter of the operations dispatched
+// by the public APIs, or nil if the operations are not rate limited
+func newOperationLimiter(config *Config, group *ServiceGroup) rpc.OperationLimiter {
+	if group.RateLimiter == nil {
+		return nil
+	}
+
+	return ratelimit.NewOperationLimiter(ratelimit.OperationLimiterProps{
+		Limiter: group.RateLimiter,
+		Logger:  RootLogger,
+		Key:     config.RateLimitConfig.Key,
+		Limit:   config.RateLimitConfig.Limit,
+		Routes:  config.RateLimitConfig.Routes,
+	})
+}
+
 func NewPublicRouter(config *Config, group *ServiceGroup) *rpc.HttpRouter {
+	limiter := newOperationLimiter(config, group)
 	binder := rpc.NewHttpBinder(rpc.HttpBinderProperties{
 		Encoder: rpc.JsonEncoder{},
 		Logger:  RootLogger,
 		HandlerFactory: rpc.HttpHandlerFactoryFunc(func(factory rpc.EntityFactory, handler rpc.Handler) rpc.HttpMiddleware {
-			jsonHandler := rpc.NewHttpJsonHandler(rpc.HttpJsonHandlerProperties{
+			// operations are limited once the requests are authenticated
+			// so that they can be keyed by AAD or session
+			next := rpc.NewHttpJsonHandler(rpc.HttpJsonHandlerProperties{
 				Limit:   config.BindPublicConfig.MaxBodyBytes,
 				Handler: handler,
 				Logger:  RootLogger,
 				Factory: factory,
+				Limiter: limiter,
 			})
 
-			return authcore.NewHttpMiddlewareAuth(group.Authenticator, RootLogger, jsonHandler)
+			// the routes that issue a session key are
+			// served without one
+			return authcore.NewHttpMiddlewareAuthWithProps(authcore.HttpMiddlewareAuthProps{
+				Auth:            group.Authenticator,
+				Logger:          RootLogger,
+				Next:            next,
+				SessionOptional: authcore.IsSessionOptional(handler),
+			})
 		}),
+		OpenAPI: rpc.HttpOpenAPIProps{
+			Path: "/openapi.json",
+			Info: rpc.OpenAPIInfo{Title: "Oasis Gateway", Version: "1"},
+		},
 	})
 
 	if config.BindPublicConfig.HttpCorsPreProcessorProps.Enabled {
 		binder.AddPreProcessor(rpc.NewHttpCorsPreProcessor(config.BindPublicConfig.HttpCorsPreProcessorProps))
 	}
 
-	service.BindHandler(service.Services{
+	wsBinder := rpc.NewWebSocketBinder(rpc.WebSocketBinderProperties{
+		Logger:  RootLogger,
+		Limit:   config.BindPublicConfig.MaxBodyBytes,
+		Cors:    config.BindPublicConfig.HttpCorsPreProcessorProps,
+		Limiter: limiter,
+	})
+
+	rpcBinder := rpc.NewJsonRpcBinder(rpc.JsonRpcBinderProperties{
+		Logger:  RootLogger,
+		Limiter: limiter,
+	})
+
+	serviceServices := service.Services{
 		Logger:   RootLogger,
 		Client:   group.Request,
 		Verifier: group.Authenticator,
-	}, binder)
-	event.BindHandler(event.Services{
+	}
+	eventServices := event.Services{
+		Logger: RootLogger,
+		Client: group.Request,
+	}
+	sessionServices := session.Services{
 		Logger: RootLogger,
 		Client: group.Request,
-	}, binder)
+	}
+
+	for _, b := range []rpc.HandlerBinder{binder, wsBinder} {
+		service.BindHandler(serviceServices, b)
+		event.BindHandler(eventServices, b)
+		session.BindHandler(sessionServices, b)
+		v1.BindHandler(v1.Services{
+			Service: serviceServices,
+			Event:   eventServices,
+			Session: sessionServices,
+		}, b)
+	}
+
+	// the WebSocket connection is authenticated on the handshake and
+	// all the requests sent through it are handled within that session.
+	// A single connection can reach the routes of all the API versions
+	wsHandler := wsBinder.Build()
+	binder.Bind("GET", "/v0/api/ws", wsHandler,
+		rpc.EntityFactoryFunc(func() interface{} { return nil }))
+	binder.Bind("GET", v1.Prefix+"/ws", wsHandler,
+		rpc.EntityFactoryFunc(func() interface{} { return nil }))
+
+	// the JSON-RPC route is served without a session key so that
+	// sessions can be opened through it. The methods that require a
+	// session fail if the request did not provide one
+	sessionBinder := authcore.NewSessionBinder(rpcBinder)
+	service.BindRoutes(serviceServices, sessionBinder)
+	event.BindRoutes(eventServices, sessionBinder)
+	session.BindRoutes(sessionServices, sessionBinder)
+	binder.Bind("POST", "/rpc", authcore.SessionOptional(rpcBinder.Build()),
+		rpc.EntityFactoryFunc(func() interface{} { return &rpc.JsonRpcPayload{} }))
 
 	return binder.Build()
 }
+
+// NewPublicGrpcServer creates the gRPC server that serves the public
+// service and event APIs alongside the public router
+func NewPublicGrpcServer(config *Config, group *ServiceGroup, options ...grpc.ServerOption) *grpc.Server {
+	return apigrpc.NewServer(apigrpc.ServerProps{
+		Auth:        group.Authenticator,
+		Logger:      RootLogger,
+		MaxMsgBytes: config.BindPublicConfig.MaxBodyBytes,
+		Limiter:     newOperationLimiter(config, group),
+		Options:     options,
+		Service: service.Services{
+			Logger:   RootLogger,
+			Client:   group.Request,
+			Verifier: group.Authenticator,
+		},
+		Event: event.Services{
+			Logger: RootLogger,
+			Client: group.Request,
+		},
+	})
+}
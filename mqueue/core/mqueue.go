@@ -33,6 +33,32 @@ type Elements struct {
 	Elements []Element
 }
 
+// ElementStatus is the status of an element within a queue
+type ElementStatus uint
+
+const (
+	// ElementUnknown is the status of an element whose offset has
+	// never been reserved, that has already been discarded or that
+	// belongs to a queue that does not exist
+	ElementUnknown ElementStatus = iota
+
+	// ElementReserved is the status of an element whose offset has
+	// been reserved but that has not been set yet
+	ElementReserved
+
+	// ElementSet is the status of an element that has been set
+	ElementSet
+)
+
+// ElementState is the state of a single element in the queue
+type ElementState struct {
+	// Status of the element
+	Status ElementStatus
+
+	// Element is only populated when the status is ElementSet
+	Element Element
+}
+
 // InsertRequest is the request to insert elements into a queue
 type InsertRequest struct {
 	// Key unique identifier of the queue
@@ -81,6 +107,16 @@ type DiscardRequest struct {
 	Key string
 }
 
+// GetRequest to request the state of the element
+// in the queue at the provided offset
+type GetRequest struct {
+	// Key unique identifier of the queue
+	Key string
+
+	// Offset of the element
+	Offset uint64
+}
+
 // NextRequest to request the next offset available
 // in the queue that can be inserted
 type NextRequest struct {
@@ -102,6 +138,13 @@ type ExistsRequest struct {
 	Key string
 }
 
+// WatchRequest to ask to be notified of changes in the queue
+// identified by the provided key
+type WatchRequest struct {
+	// Key unique identifier of the queue
+	Key string
+}
+
 // MQueue is an interface to a messaging queue service that
 // provides the basic operations for a simple publish
 // subscribe mechanism in which the clients manage the offsets
@@ -120,6 +163,9 @@ type MQueue interface {
 	// messaging queue after the provided offset
 	Retrieve(context.Context, RetrieveRequest) (Elements, error)
 
+	// Get returns the state of a single element of the queue
+	Get(context.Context, GetRequest) (ElementState, error)
+
 	// Discard all elements that have a prior or equal
 	// offset to the provided offset
 	Discard(context.Context, DiscardRequest) error
@@ -132,4 +178,10 @@ type MQueue interface {
 
 	// Exists returns true if the key exists
 	Exists(context.Context, ExistsRequest) (bool, error)
+
+	// Watch returns a channel that is closed the next time an element
+	// is inserted into the queue or the queue is removed. The channel is
+	// also closed when the context is done, so callers should cancel the
+	// context once they are no longer interested in the notification
+	Watch(context.Context, WatchRequest) (<-chan struct{}, error)
 }
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// Watchers keeps track of the callers watching for changes
+// on queues, so that they can be notified when they happen.
+// It can be used by the MQueue implementations to implement Watch
+type Watchers struct {
+	mutex    sync.Mutex
+	watchers map[string]map[chan struct{}]struct{}
+}
+
+// NewWatchers creates a new instance of Watchers
+func NewWatchers() *Watchers {
+	return &Watchers{watchers: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Watch returns a channel that is closed the next time Notify is
+// called for the key or when the context is done
+func (w *Watchers) Watch(ctx context.Context, key string) <-chan struct{} {
+	c := make(chan struct{})
+
+	w.mutex.Lock()
+	watchers, ok := w.watchers[key]
+	if !ok {
+		watchers = make(map[chan struct{}]struct{})
+		w.watchers[key] = watchers
+	}
+	watchers[c] = struct{}{}
+	w.mutex.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.remove(key, c)
+		case <-c:
+		}
+	}()
+
+	return c
+}
+
+func (w *Watchers) remove(key string, c chan struct{}) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	watchers, ok := w.watchers[key]
+	if !ok {
+		return
+	}
+
+	if _, ok := watchers[c]; !ok {
+		// the channel has already been closed by Notify
+		return
+	}
+
+	close(c)
+	delete(watchers, c)
+	if len(watchers) == 0 {
+		delete(w.watchers, key)
+	}
+}
+
+// Notify wakes up all the callers watching the key
+func (w *Watchers) Notify(key string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for c := range w.watchers[key] {
+		close(c)
+	}
+
+	delete(w.watchers, key)
+}
+
+// NotifyAll wakes up all the callers watching any key
+func (w *Watchers) NotifyAll() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for key, watchers := range w.watchers {
+		for c := range watchers {
+			close(c)
+		}
+
+		delete(w.watchers, key)
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchersNotify(t *testing.T) {
+	w := NewWatchers()
+
+	c1 := w.Watch(context.Background(), "key")
+	c2 := w.Watch(context.Background(), "key")
+	c3 := w.Watch(context.Background(), "other")
+
+	w.Notify("key")
+
+	_, ok := <-c1
+	assert.False(t, ok)
+	_, ok = <-c2
+	assert.False(t, ok)
+
+	select {
+	case <-c3:
+		assert.Fail(t, "unexpected notification for key other")
+	default:
+	}
+}
+
+func TestWatchersContextDone(t *testing.T) {
+	w := NewWatchers()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := w.Watch(ctx, "key")
+	cancel()
+
+	_, ok := <-c
+	assert.False(t, ok)
+
+	// notifying after the watcher is removed must not close
+	// the channel twice
+	w.Notify("key")
+}
+
+func TestWatchersNotifyAll(t *testing.T) {
+	w := NewWatchers()
+
+	c1 := w.Watch(context.Background(), "key")
+	c2 := w.Watch(context.Background(), "other")
+
+	w.NotifyAll()
+
+	_, ok := <-c1
+	assert.False(t, ok)
+	_, ok = <-c2
+	assert.False(t, ok)
+
+	// the watchers are removed once notified
+	w.Notify("key")
+}
@@ -35,6 +35,11 @@ func (m *Mailbox) Retrieve(ctx context.Context, req core.RetrieveRequest) (core.
 	return args.Get(0).(core.Elements), args.Error(1)
 }
 
+func (m *Mailbox) Get(ctx context.Context, req core.GetRequest) (core.ElementState, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(core.ElementState), args.Error(1)
+}
+
 func (m *Mailbox) Discard(ctx context.Context, req core.DiscardRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
@@ -49,3 +54,12 @@ func (m *Mailbox) Remove(ctx context.Context, req core.RemoveRequest) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
+
+func (m *Mailbox) Watch(ctx context.Context, req core.WatchRequest) (<-chan struct{}, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(<-chan struct{}), args.Error(1)
+}
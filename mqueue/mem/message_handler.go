@@ -20,6 +20,10 @@ type retrieveRequest struct {
 	Count  uint
 }
 
+type getRequest struct {
+	Offset uint64
+}
+
 type discardRequest struct {
 	KeepPrevious bool
 	Count        uint
@@ -63,6 +67,8 @@ func (w *MessageHandler) handleRequestEvent(ctx context.Context, ev concurrent.R
 		return nil, err
 	case retrieveRequest:
 		return w.retrieve(req)
+	case getRequest:
+		return w.get(req), nil
 	case discardRequest:
 		err := w.discard(req)
 		return nil, err
@@ -88,6 +94,10 @@ func (w *MessageHandler) retrieve(req retrieveRequest) (core.Elements, error) {
 	return w.window.Get(req.Offset, req.Count)
 }
 
+func (w *MessageHandler) get(req getRequest) core.ElementState {
+	return w.window.Lookup(req.Offset)
+}
+
 func (w *MessageHandler) discard(req discardRequest) error {
 	if !req.KeepPrevious {
 		if _, err := w.window.Slide(req.Offset); err != nil {
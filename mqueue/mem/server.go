@@ -13,8 +13,9 @@ import (
 const maxInactivityTimeout = time.Duration(10) * time.Minute
 
 type Server struct {
-	master *concurrent.Master
-	logger log.Logger
+	master   *concurrent.Master
+	logger   log.Logger
+	watchers *core.Watchers
 }
 
 type Services struct {
@@ -23,7 +24,8 @@ type Services struct {
 
 func NewServer(ctx context.Context, services Services) *Server {
 	s := &Server{
-		logger: services.Logger.ForClass("mqueue/mem", "Server"),
+		logger:   services.Logger.ForClass("mqueue/mem", "Server"),
+		watchers: core.NewWatchers(),
 	}
 
 	s.master = concurrent.NewMaster(concurrent.MasterProps{
@@ -68,7 +70,12 @@ func (s *Server) destroy(ctx context.Context, ev concurrent.DestroyWorkerEvent)
 // Insert inserts the element to the provided offset.
 func (s *Server) Insert(ctx context.Context, req core.InsertRequest) error {
 	_, err := s.master.Request(ctx, req.Key, insertRequest{Element: req.Element})
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.watchers.Notify(req.Key)
+	return nil
 }
 
 // Retrieve all available elements from the
@@ -82,6 +89,25 @@ func (s *Server) Retrieve(ctx context.Context, req core.RetrieveRequest) (core.E
 	return v.(core.Elements), nil
 }
 
+// Get returns the state of a single element of the queue
+func (s *Server) Get(ctx context.Context, req core.GetRequest) (core.ElementState, error) {
+	// avoid allocating a queue for a key that does not exist
+	ok, err := s.master.Exists(ctx, req.Key)
+	if err != nil {
+		return core.ElementState{}, err
+	}
+	if !ok {
+		return core.ElementState{Status: core.ElementUnknown}, nil
+	}
+
+	v, err := s.master.Request(ctx, req.Key, getRequest{Offset: req.Offset})
+	if err != nil {
+		return core.ElementState{}, err
+	}
+
+	return v.(core.ElementState), nil
+}
+
 // Discard all elements that have a prior or equal
 // offset to the provided offset
 func (s *Server) Discard(ctx context.Context, req core.DiscardRequest) error {
@@ -105,7 +131,12 @@ func (s *Server) Next(ctx context.Context, req core.NextRequest) (uint64, error)
 
 // Remove the key's queue and it's associated resources
 func (s *Server) Remove(ctx context.Context, req core.RemoveRequest) error {
-	return s.master.Destroy(ctx, req.Key)
+	if err := s.master.Destroy(ctx, req.Key); err != nil {
+		return err
+	}
+
+	s.watchers.Notify(req.Key)
+	return nil
 }
 
 // Exists returns true if there is a queue allocated with the
@@ -114,6 +145,12 @@ func (s *Server) Exists(ctx context.Context, req core.ExistsRequest) (bool, erro
 	return s.master.Exists(ctx, req.Key)
 }
 
+// Watch returns a channel that is closed the next time an element
+// is inserted into the queue or the queue is removed
+func (s *Server) Watch(ctx context.Context, req core.WatchRequest) (<-chan struct{}, error) {
+	return s.watchers.Watch(ctx, req.Key), nil
+}
+
 func (s *Server) Name() string {
 	return "mqueue.mem.Server"
 }
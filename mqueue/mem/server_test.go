@@ -65,6 +65,34 @@ func TestServerRetrieve(t *testing.T) {
 	}, els)
 }
 
+func TestServerGet(t *testing.T) {
+	s := NewServer(context.TODO(), Services{Logger: logger})
+
+	state, err := s.Get(ctx, core.GetRequest{Key: "key", Offset: 0})
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{Status: core.ElementUnknown}, state)
+
+	offset, err := s.Next(ctx, core.NextRequest{Key: "key"})
+	assert.Nil(t, err)
+
+	state, err = s.Get(ctx, core.GetRequest{Key: "key", Offset: offset})
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{Status: core.ElementReserved}, state)
+
+	err = s.Insert(ctx, core.InsertRequest{Key: "key", Element: core.Element{
+		Offset: offset,
+		Value:  "value",
+	}})
+	assert.Nil(t, err)
+
+	state, err = s.Get(ctx, core.GetRequest{Key: "key", Offset: offset})
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{
+		Status:  core.ElementSet,
+		Element: core.Element{Offset: offset, Value: "value"},
+	}, state)
+}
+
 func TestServerDiscardKeepPreviousFalse(t *testing.T) {
 	s := NewServer(context.TODO(), Services{Logger: logger})
 
@@ -182,6 +210,38 @@ func TestServerNextErrLimitReached(t *testing.T) {
 	assert.Equal(t, 1024, it)
 }
 
+func TestServerWatchInsert(t *testing.T) {
+	s := NewServer(context.TODO(), Services{Logger: logger})
+
+	c, err := s.Watch(ctx, core.WatchRequest{Key: "key"})
+	assert.Nil(t, err)
+
+	offset, err := s.Next(ctx, core.NextRequest{Key: "key"})
+	assert.Nil(t, err)
+
+	err = s.Insert(ctx, core.InsertRequest{Key: "key", Element: core.Element{
+		Offset: offset,
+		Value:  "value",
+	}})
+	assert.Nil(t, err)
+
+	_, ok := <-c
+	assert.False(t, ok)
+}
+
+func TestServerWatchCancel(t *testing.T) {
+	s := NewServer(context.TODO(), Services{Logger: logger})
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	c, err := s.Watch(watchCtx, core.WatchRequest{Key: "key"})
+	assert.Nil(t, err)
+
+	cancel()
+
+	_, ok := <-c
+	assert.False(t, ok)
+}
+
 func TestServerName(t *testing.T) {
 	s := NewServer(context.TODO(), Services{Logger: logger})
 	assert.Equal(t, "mqueue.mem.Server", s.Name())
@@ -108,6 +108,30 @@ func (w *SlidingWindow) Get(offset uint64, count uint) (core.Elements, errors.Er
 	return res, nil
 }
 
+// Lookup returns the state of the element at the provided offset
+func (w *SlidingWindow) Lookup(offset uint64) core.ElementState {
+	if offset < w.offset || offset-w.offset >= uint64(w.nextUnreservedIndex) {
+		return core.ElementState{Status: core.ElementUnknown}
+	}
+
+	element := &w.elements[offset-w.offset]
+	switch {
+	case element.Discarded || !element.Reserved:
+		return core.ElementState{Status: core.ElementUnknown}
+	case !element.Set:
+		return core.ElementState{Status: core.ElementReserved}
+	default:
+		return core.ElementState{
+			Status: core.ElementSet,
+			Element: core.Element{
+				Offset: element.Offset,
+				Value:  element.Value,
+				Type:   element.Type,
+			},
+		}
+	}
+}
+
 // ReserveNext reserves the next offset available in the
 // window, or an error if it is not possible to provide
 // a next offset because either the window cannot grow more
@@ -31,6 +31,27 @@ func TestSlidingWindowSet(t *testing.T) {
 	}}, els)
 }
 
+func TestSlidingWindowLookup(t *testing.T) {
+	w := NewSlidingWindow(SlidingWindowProps{MaxSize: 16})
+
+	assert.Equal(t, core.ElementState{Status: core.ElementUnknown}, w.Lookup(0))
+
+	next, err := w.ReserveNext()
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{Status: core.ElementReserved}, w.Lookup(next))
+
+	err = w.Set(next, "type", "value")
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{
+		Status:  core.ElementSet,
+		Element: core.Element{Offset: next, Type: "type", Value: "value"},
+	}, w.Lookup(next))
+
+	_, err = w.Discard(next, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, core.ElementState{Status: core.ElementUnknown}, w.Lookup(next))
+}
+
 func TestSlidingWindowAlreadySet(t *testing.T) {
 	w := NewSlidingWindow(SlidingWindowProps{MaxSize: 16})
 
@@ -12,6 +12,7 @@ const (
 	mqnext     op = "return mqnext(KEYS[1])"
 	mqinsert   op = "return mqinsert(KEYS[1], ARGV[1], ARGV[2], ARGV[3])"
 	mqretrieve op = "return mqretrieve(KEYS[1], ARGV[1], ARGV[2])"
+	mqget      op = "return mqget(KEYS[1], ARGV[1])"
 	mqdiscard  op = "return mqdiscard(KEYS[1], ARGV[1], ARGV[2], ARGV[3])"
 	mqremove   op = "return mqremove(KEYS[1])"
 )
@@ -69,6 +70,23 @@ func (r retrieveRequest) Args() []interface{} {
 	return []interface{}{r.Offset, r.Count}
 }
 
+type getRequest struct {
+	Offset uint64
+	Key    string
+}
+
+func (r getRequest) Op() op {
+	return mqget
+}
+
+func (r getRequest) Keys() []string {
+	return []string{r.Key}
+}
+
+func (r getRequest) Args() []interface{} {
+	return []interface{}{r.Offset}
+}
+
 type discardRequest struct {
 	KeepPrevious bool
 	Count        uint
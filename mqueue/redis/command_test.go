@@ -43,6 +43,16 @@ func TestRetrieveRequest(t *testing.T) {
 	}, req.Args())
 }
 
+func TestGetRequest(t *testing.T) {
+	req := getRequest{
+		Offset: 1,
+		Key:    "key",
+	}
+
+	assert.Equal(t, []string{"key"}, req.Keys())
+	assert.Equal(t, []interface{}{uint64(1)}, req.Args())
+}
+
 func TestDiscardRequest(t *testing.T) {
 	req := discardRequest{
 		KeepPrevious: true,
@@ -3,6 +3,8 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"sync"
 
 	"github.com/go-redis/redis"
 	"github.com/oasislabs/oasis-gateway/log"
@@ -13,17 +15,34 @@ import (
 const (
 	insert   string = "insert"
 	retrieve string = "retrieve"
+	get      string = "get"
 	discard  string = "discard"
 	next     string = "next"
 	remove   string = "remove"
 	exists   string = "exists"
+	watch    string = "watch"
 )
 
+// watchChannelPrefix is the prefix of the channels in which a message
+// is published every time a queue changes, so that all the instances
+// can notify their watchers
+const watchChannelPrefix = "mqueue:watch:"
+
 // Client is the interface to the redis client used implementing
 // the methods used by the MQueue implementation
 type Client interface {
 	Eval(script string, keys []string, args ...interface{}) *redis.Cmd
 	Exists(key ...string) *redis.IntCmd
+	Publish(channel string, message interface{}) *redis.IntCmd
+	PSubscribe(channels ...string) *redis.PubSub
+}
+
+// pubSub is the subscription used to receive the notifications
+// published by the instances. It is implemented by *redis.PubSub
+type pubSub interface {
+	Receive() (interface{}, error)
+	Channel() <-chan *redis.Message
+	Close() error
 }
 
 type Props struct {
@@ -49,9 +68,20 @@ type SingleInstanceProps struct {
 // MQueue implements the messaging queue functionality required
 // from the mqueue package using Redis as a backend
 type MQueue struct {
-	client  Client
-	logger  log.Logger
-	tracker *stats.MethodTracker
+	ctx      context.Context
+	client   Client
+	logger   log.Logger
+	tracker  *stats.MethodTracker
+	watchers *core.Watchers
+
+	// subscribe creates the subscription to the channels that
+	// match the pattern
+	subscribe func(pattern string) pubSub
+
+	// mutex protects the initialization of the subscription
+	// used to receive the notifications for the watchers
+	mutex    sync.Mutex
+	watching bool
 }
 
 // NewClusterMQueue creates a new instance of a redis client
@@ -62,11 +92,8 @@ func NewClusterMQueue(props ClusterProps) (*MQueue, error) {
 		Addrs: props.Addrs,
 	})
 
-	return &MQueue{
-		client:  c,
-		logger:  logger,
-		tracker: stats.NewMethodTracker(insert, retrieve, discard, next, remove, exists),
-	}, nil
+	return newMQueue(props.Props, c, logger,
+		stats.NewMethodTracker(insert, retrieve, get, discard, next, remove, exists, watch)), nil
 }
 
 // NewSingleMQueue creates a new instance of a redis client
@@ -77,11 +104,26 @@ func NewSingleMQueue(props SingleInstanceProps) (*MQueue, error) {
 		Addr: props.Addr,
 	})
 
+	return newMQueue(props.Props, c, logger,
+		stats.NewMethodTracker(insert, retrieve, get, discard, next, remove, watch)), nil
+}
+
+func newMQueue(props Props, client Client, logger log.Logger, tracker *stats.MethodTracker) *MQueue {
+	ctx := props.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	return &MQueue{
-		client:  c,
-		logger:  logger,
-		tracker: stats.NewMethodTracker(insert, retrieve, discard, next, remove),
-	}, nil
+		ctx:      ctx,
+		client:   client,
+		logger:   logger,
+		tracker:  tracker,
+		watchers: core.NewWatchers(),
+		subscribe: func(pattern string) pubSub {
+			return client.PSubscribe(pattern)
+		},
+	}
 }
 
 func (m *MQueue) Name() string {
@@ -125,6 +167,7 @@ func (m *MQueue) insert(ctx context.Context, req core.InsertRequest) error {
 		return ErrOpNotOk
 	}
 
+	m.publish(ctx, req.Key)
 	return nil
 }
 
@@ -192,6 +235,58 @@ func (m *MQueue) retrieve(ctx context.Context, req core.RetrieveRequest) (core.E
 	}, nil
 }
 
+func (m *MQueue) Get(ctx context.Context, req core.GetRequest) (core.ElementState, error) {
+	state, err := m.tracker.Instrument(get, func() (interface{}, error) {
+		return m.get(ctx, req)
+	})
+	if err != nil {
+		return core.ElementState{}, err
+	}
+
+	return state.(core.ElementState), nil
+}
+
+func (m *MQueue) get(ctx context.Context, req core.GetRequest) (core.ElementState, error) {
+	v, err := m.exec(ctx, getRequest{
+		Key:    req.Key,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return core.ElementState{}, ErrRedisExec{Cause: err}
+	}
+
+	if len(v.(string)) == 0 {
+		return core.ElementState{Status: core.ElementUnknown}, nil
+	}
+
+	var decoded redisElement
+	if err := json.Unmarshal([]byte(v.(string)), &decoded); err != nil {
+		return core.ElementState{}, ErrDeserialize{Cause: err}
+	}
+
+	if decoded.Discarded {
+		return core.ElementState{Status: core.ElementUnknown}, nil
+	}
+
+	if !decoded.Set {
+		return core.ElementState{Status: core.ElementReserved}, nil
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(decoded.Value), &value); err != nil {
+		return core.ElementState{}, ErrDeserialize{Cause: err}
+	}
+
+	return core.ElementState{
+		Status: core.ElementSet,
+		Element: core.Element{
+			Offset: decoded.Offset,
+			Type:   decoded.Type,
+			Value:  value,
+		},
+	}, nil
+}
+
 func (m *MQueue) Discard(ctx context.Context, req core.DiscardRequest) error {
 	_, err := m.tracker.Instrument(discard, func() (interface{}, error) {
 		return nil, m.discard(ctx, req)
@@ -277,5 +372,103 @@ func (m *MQueue) remove(ctx context.Context, req core.RemoveRequest) error {
 		return ErrQueueNotFound
 	}
 
+	m.publish(ctx, req.Key)
+	return nil
+}
+
+// publish notifies the watchers of all the instances that the
+// queue identified by key has changed
+func (m *MQueue) publish(ctx context.Context, key string) {
+	// the operation on the queue has already succeeded, so a failure
+	// to notify the watchers is not reported to the caller
+	if err := m.client.Publish(watchChannelPrefix+key, "").Err(); err != nil {
+		m.logger.Warn(ctx, "failed to publish queue notification", log.MapFields{
+			"call_type": "PublishFailure",
+			"key":       key,
+			"err":       err.Error(),
+		})
+	}
+}
+
+// Watch returns a channel that is closed the next time an element
+// is inserted into the queue or the queue is removed
+func (m *MQueue) Watch(ctx context.Context, req core.WatchRequest) (<-chan struct{}, error) {
+	c, err := m.tracker.Instrument(watch, func() (interface{}, error) {
+		return m.watch(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.(<-chan struct{}), nil
+}
+
+func (m *MQueue) watch(ctx context.Context, req core.WatchRequest) (<-chan struct{}, error) {
+	if err := m.startWatching(); err != nil {
+		return nil, ErrRedisExec{Cause: err}
+	}
+
+	return m.watchers.Watch(ctx, req.Key), nil
+}
+
+// startWatching subscribes to the notifications published by all the
+// instances the first time it is called. A single subscription is
+// shared by all the watchers of the MQueue
+func (m *MQueue) startWatching() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.watching {
+		return nil
+	}
+
+	if err := m.ctx.Err(); err != nil {
+		return err
+	}
+
+	pubsub := m.subscribe(watchChannelPrefix + "*")
+
+	// wait for the subscription to be confirmed so that no notification
+	// is missed by the first watchers
+	if _, err := pubsub.Receive(); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	m.watching = true
+	go m.dispatch(pubsub)
 	return nil
 }
+
+// dispatch notifies the watchers of the changes published by the
+// instances until the subscription is closed or the context is done.
+// When that happens the watchers are woken up so that they do not miss
+// any change, and the next watcher subscribes again
+func (m *MQueue) dispatch(pubsub pubSub) {
+	defer func() {
+		_ = pubsub.Close()
+
+		m.mutex.Lock()
+		m.watching = false
+		m.mutex.Unlock()
+
+		m.watchers.NotifyAll()
+	}()
+
+	c := pubsub.Channel()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case msg, ok := <-c:
+			if !ok {
+				m.logger.Warn(m.ctx, "subscription to queue notifications closed", log.MapFields{
+					"call_type": "WatchFailure",
+				})
+				return
+			}
+
+			m.watchers.Notify(strings.TrimPrefix(msg.Channel, watchChannelPrefix))
+		}
+	}
+}
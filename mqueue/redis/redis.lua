@@ -83,6 +83,26 @@ local mqretrieve = function(key, offset, count)
   return redis.call('lrange', key, start, stop)
 end
 
+-- mqget returns the element at the provided offset, or an
+-- empty string if the element is not within the window
+local mqget = function(key, offset)
+  if redis.call('exists', key) == 0 then
+    return ""
+  end
+
+  local base_n_len = mqbasenlen(key)
+  local base = base_n_len[1]
+  local len = base_n_len[2]
+  local index = tonumber(offset) - base
+
+  if index < 0 or index >= len then
+    return ""
+  end
+
+  redis.call('expire', key, expire_time)
+  return redis.call('lindex', key, index)
+end
+
 -- mqdiscard discards all elements up to offset if keep_previous is false.
 -- It also discards all the elements up to offset + count that have been set.
 -- The window cannot be left empty because at least one element is needed
@@ -169,6 +189,7 @@ end
 rawset(_G, "mqremove", mqremove)
 rawset(_G, "mqdiscard", mqdiscard)
 rawset(_G, "mqretrieve", mqretrieve)
+rawset(_G, "mqget", mqget)
 rawset(_G, "mqinsert", mqinsert)
 rawset(_G, "mqnext", mqnext)
 
@@ -187,7 +208,13 @@ local test = function()
     assert(cjson.decode(t[i+1])['offset'] == i)
   end
 
+  local el = cjson.decode(mqget('example', 3))
+  assert(el['offset'] == 3)
+  assert(el['set'] == true)
+  assert(mqget('example', 11) == "")
+
   mqdiscard('example', 2, 0, false)
+  assert(mqget('example', 1) == "")
   local t = mqretrieve('example', 0, 10)
   for i = 0, 8  do
     assert(cjson.decode(t[i+1])['offset'] == i + 2)
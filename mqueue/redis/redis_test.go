@@ -0,0 +1,214 @@
+package redis
+
+import (
+	"context"
+	stderr "errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/mqueue/core"
+	"github.com/oasislabs/oasis-gateway/stats"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var Logger = log.NewLogrus(log.LogrusLoggerProperties{
+	Level:  logrus.DebugLevel,
+	Output: ioutil.Discard,
+})
+
+type MockClient struct {
+	mock.Mock
+}
+
+func (c *MockClient) Eval(script string, keys []string, args ...interface{}) *redis.Cmd {
+	ret := c.Called(script, keys, args)
+	return redis.NewCmdResult(ret.Get(0), ret.Error(1))
+}
+
+func (c *MockClient) Exists(key ...string) *redis.IntCmd {
+	ret := c.Called(key)
+	return redis.NewIntResult(int64(ret.Int(0)), ret.Error(1))
+}
+
+func (c *MockClient) Publish(channel string, message interface{}) *redis.IntCmd {
+	ret := c.Called(channel, message)
+	return redis.NewIntResult(int64(ret.Int(0)), ret.Error(1))
+}
+
+func (c *MockClient) PSubscribe(channels ...string) *redis.PubSub {
+	panic("subscriptions are created through MQueue.subscribe")
+}
+
+// MockPubSub is a subscription that delivers the messages
+// sent to C until C is closed
+type MockPubSub struct {
+	C   chan *redis.Message
+	err error
+}
+
+func (p *MockPubSub) Receive() (interface{}, error) {
+	return nil, p.err
+}
+
+func (p *MockPubSub) Channel() <-chan *redis.Message {
+	return p.C
+}
+
+func (p *MockPubSub) Close() error {
+	return nil
+}
+
+// subscriber keeps track of the subscriptions created by an MQueue
+type subscriber struct {
+	mutex   sync.Mutex
+	err     error
+	pubsubs []*MockPubSub
+}
+
+func (s *subscriber) subscribe(pattern string) pubSub {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pubsub := &MockPubSub{C: make(chan *redis.Message), err: s.err}
+	s.pubsubs = append(s.pubsubs, pubsub)
+	return pubsub
+}
+
+func (s *subscriber) Subscriptions() []*MockPubSub {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*MockPubSub(nil), s.pubsubs...)
+}
+
+func newTestMQueue(ctx context.Context) (*MQueue, *MockClient, *subscriber) {
+	client := &MockClient{}
+	sub := &subscriber{}
+	m := newMQueue(Props{Context: ctx, Logger: Logger}, client, Logger,
+		stats.NewMethodTracker(insert, retrieve, get, discard, next, remove, exists, watch))
+	m.subscribe = sub.subscribe
+	return m, client, sub
+}
+
+func assertClosed(t *testing.T, c <-chan struct{}) {
+	select {
+	case <-c:
+	case <-time.After(time.Second):
+		assert.Fail(t, "watch channel not closed")
+	}
+}
+
+func assertOpen(t *testing.T, c <-chan struct{}) {
+	select {
+	case <-c:
+		assert.Fail(t, "watch channel closed")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWatchNotify(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m, _, sub := newTestMQueue(ctx)
+
+	a, err := m.Watch(ctx, core.WatchRequest{Key: "a"})
+	assert.Nil(t, err)
+	b, err := m.Watch(ctx, core.WatchRequest{Key: "b"})
+	assert.Nil(t, err)
+
+	sub.Subscriptions()[0].C <- &redis.Message{Channel: watchChannelPrefix + "a"}
+
+	assertClosed(t, a)
+	assertOpen(t, b)
+	assert.Equal(t, 1, len(sub.Subscriptions()))
+}
+
+func TestWatchSubscriptionClosed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m, _, sub := newTestMQueue(ctx)
+
+	c, err := m.Watch(ctx, core.WatchRequest{Key: "a"})
+	assert.Nil(t, err)
+
+	// the watchers are woken up when the subscription is lost, and
+	// the next watcher subscribes again
+	close(sub.Subscriptions()[0].C)
+	assertClosed(t, c)
+
+	c, err = m.Watch(ctx, core.WatchRequest{Key: "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(sub.Subscriptions()))
+
+	sub.Subscriptions()[1].C <- &redis.Message{Channel: watchChannelPrefix + "a"}
+	assertClosed(t, c)
+}
+
+func TestWatchSubscribeErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m, _, sub := newTestMQueue(ctx)
+	sub.err = stderr.New("connection refused")
+
+	_, err := m.Watch(ctx, core.WatchRequest{Key: "a"})
+	assert.True(t, IsErrRedisExec(err))
+
+	sub.err = nil
+	_, err = m.Watch(ctx, core.WatchRequest{Key: "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(sub.Subscriptions()))
+}
+
+func TestWatchContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m, _, sub := newTestMQueue(ctx)
+
+	c, err := m.Watch(context.Background(), core.WatchRequest{Key: "a"})
+	assert.Nil(t, err)
+
+	cancel()
+	assertClosed(t, c)
+
+	_, err = m.Watch(context.Background(), core.WatchRequest{Key: "a"})
+	assert.True(t, IsErrRedisExec(err))
+	assert.Equal(t, 1, len(sub.Subscriptions()))
+}
+
+func TestRemovePublish(t *testing.T) {
+	m, client, _ := newTestMQueue(context.Background())
+	client.On("Eval", string(mqremove), []string{"key"}, mock.Anything).Return(int64(1), nil)
+	client.On("Publish", watchChannelPrefix+"key", "").Return(1, nil)
+
+	err := m.Remove(context.Background(), core.RemoveRequest{Key: "key"})
+
+	assert.Nil(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestRemovePublishErr(t *testing.T) {
+	m, client, _ := newTestMQueue(context.Background())
+	client.On("Eval", string(mqremove), []string{"key"}, mock.Anything).Return(int64(1), nil)
+	client.On("Publish", watchChannelPrefix+"key", "").Return(0, stderr.New("connection refused"))
+
+	// the queue has already been removed, so the failure
+	// to publish the notification is not reported
+	err := m.Remove(context.Background(), core.RemoveRequest{Key: "key"})
+
+	assert.Nil(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestRemoveNotFoundNoPublish(t *testing.T) {
+	m, client, _ := newTestMQueue(context.Background())
+	client.On("Eval", string(mqremove), []string{"key"}, mock.Anything).Return(int64(0), nil)
+
+	err := m.Remove(context.Background(), core.RemoveRequest{Key: "key"})
+
+	assert.Equal(t, ErrQueueNotFound, err)
+	client.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
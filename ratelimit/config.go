@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasislabs/oasis-gateway/config"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type Provider string
+
+const (
+	// ProviderMem keeps the state of the limits in memory, so
+	// that each instance of the gateway enforces them on its own
+	ProviderMem Provider = "mem"
+
+	// ProviderRedis keeps the state of the limits in the redis
+	// instance or cluster used for the mailbox, so that the
+	// limits are shared across the instances of the gateway
+	ProviderRedis Provider = "redis"
+)
+
+func (p Provider) String() string {
+	return string(p)
+}
+
+// KeyType defines how the requests are grouped to be limited
+type KeyType string
+
+const (
+	// KeyAAD limits the requests of each authenticated client
+	KeyAAD KeyType = "aad"
+
+	// KeySession limits the requests of each session. The requests
+	// served without a session key are limited by their AAD
+	KeySession KeyType = "session"
+
+	// KeyIP limits the requests by the address of the client
+	KeyIP KeyType = "ip"
+)
+
+func (k KeyType) String() string {
+	return string(k)
+}
+
+// Config is the configuration for the rate limits of
+// the public router
+type Config struct {
+	Enabled  bool
+	Provider Provider
+	Key      KeyType
+
+	// Limit is the limit applied to the routes that
+	// do not have a limit of their own
+	Limit Limit
+
+	// Routes are the limits specific to a route, keyed by the
+	// path of the route relative to the prefix of the API version
+	Routes map[string]Limit
+}
+
+func (c *Config) Log(fields log.Fields) {
+	fields.Add("ratelimit.enabled", c.Enabled)
+	fields.Add("ratelimit.provider", c.Provider)
+	fields.Add("ratelimit.key", c.Key)
+	fields.Add("ratelimit.rate", c.Limit.Rate)
+	fields.Add("ratelimit.burst", c.Limit.Burst)
+
+	routes := make([]string, 0, len(c.Routes))
+	for path, limit := range c.Routes {
+		routes = append(routes, formatRouteLimit(path, limit))
+	}
+	fields.Add("ratelimit.routes", strings.Join(routes, ","))
+}
+
+func (c *Config) Configure(v *viper.Viper) error {
+	c.Enabled = v.GetBool("ratelimit.enabled")
+	if !c.Enabled {
+		return nil
+	}
+
+	c.Provider = Provider(v.GetString("ratelimit.provider"))
+	switch c.Provider {
+	case ProviderMem, ProviderRedis:
+	default:
+		return config.ErrInvalidValue{
+			Key:          "ratelimit.provider",
+			InvalidValue: c.Provider.String(),
+			Values:       []string{ProviderMem.String(), ProviderRedis.String()},
+		}
+	}
+
+	c.Key = KeyType(v.GetString("ratelimit.key"))
+	switch c.Key {
+	case KeyAAD, KeySession, KeyIP:
+	default:
+		return config.ErrInvalidValue{
+			Key:          "ratelimit.key",
+			InvalidValue: c.Key.String(),
+			Values:       []string{KeyAAD.String(), KeySession.String(), KeyIP.String()},
+		}
+	}
+
+	c.Limit = Limit{
+		Rate:  v.GetFloat64("ratelimit.rate"),
+		Burst: v.GetInt("ratelimit.burst"),
+	}
+	if err := c.Limit.validate(); err != nil {
+		return fmt.Errorf("ratelimit.rate and ratelimit.burst %s", err.Error())
+	}
+
+	c.Routes = make(map[string]Limit)
+	for _, route := range v.GetStringSlice("ratelimit.routes") {
+		path, limit, err := parseRouteLimit(route)
+		if err != nil {
+			return fmt.Errorf("ratelimit.routes has invalid route limit %s: %s", route, err.Error())
+		}
+
+		c.Routes[path] = limit
+	}
+
+	return nil
+}
+
+func (c *Config) Bind(v *viper.Viper, cmd *cobra.Command) error {
+	cmd.PersistentFlags().Bool("ratelimit.enabled", false,
+		"if set the operations of the public router and grpc server are rate limited")
+	cmd.PersistentFlags().String("ratelimit.provider", "mem",
+		"provider for the state of the rate limits. Options are "+
+			string(ProviderMem)+", "+string(ProviderRedis)+". The "+
+			string(ProviderRedis)+" provider uses the mailbox redis configuration.")
+	cmd.PersistentFlags().String("ratelimit.key", "aad",
+		"how the requests are grouped to be rate limited. Options are "+
+			string(KeyAAD)+", "+string(KeySession)+", "+string(KeyIP)+".")
+	cmd.PersistentFlags().Float64("ratelimit.rate", 10,
+		"number of operations per second allowed for each key")
+	cmd.PersistentFlags().Int("ratelimit.burst", 20,
+		"maximum number of operations allowed at once for each key")
+	cmd.PersistentFlags().StringSlice("ratelimit.routes", nil,
+		"limits for specific routes with the format path=rate:burst, "+
+			"as in /service/execute=1:5")
+
+	return nil
+}
+
+// parseRouteLimit parses a route limit with the format path=rate:burst
+func parseRouteLimit(s string) (string, Limit, error) {
+	index := strings.LastIndex(s, "=")
+	if index <= 0 {
+		return "", Limit{}, errors.New("format must be path=rate:burst")
+	}
+
+	path := s[:index]
+	values := strings.Split(s[index+1:], ":")
+	if len(values) != 2 {
+		return "", Limit{}, errors.New("format must be path=rate:burst")
+	}
+
+	rate, err := strconv.ParseFloat(values[0], 64)
+	if err != nil {
+		return "", Limit{}, err
+	}
+
+	burst, err := strconv.Atoi(values[1])
+	if err != nil {
+		return "", Limit{}, err
+	}
+
+	limit := Limit{Rate: rate, Burst: burst}
+	if err := limit.validate(); err != nil {
+		return "", Limit{}, err
+	}
+
+	return path, limit, nil
+}
+
+func formatRouteLimit(path string, limit Limit) string {
+	return fmt.Sprintf("%s=%s:%d", path,
+		strconv.FormatFloat(limit.Rate, 'f', -1, 64), limit.Burst)
+}
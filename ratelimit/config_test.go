@@ -0,0 +1,30 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRouteLimit(t *testing.T) {
+	path, limit, err := parseRouteLimit("/v0/api/service/execute=0.5:5")
+	assert.Nil(t, err)
+	assert.Equal(t, "/v0/api/service/execute", path)
+	assert.Equal(t, Limit{Rate: 0.5, Burst: 5}, limit)
+	assert.Equal(t, "/v0/api/service/execute=0.5:5", formatRouteLimit(path, limit))
+}
+
+func TestParseRouteLimitErr(t *testing.T) {
+	for _, s := range []string{
+		"/v0/api/service/execute",
+		"=1:5",
+		"/v0/api/service/execute=1",
+		"/v0/api/service/execute=a:5",
+		"/v0/api/service/execute=1:a",
+		"/v0/api/service/execute=0:5",
+		"/v0/api/service/execute=1:0",
+	} {
+		_, _, err := parseRouteLimit(s)
+		assert.Error(t, err, s)
+	}
+}
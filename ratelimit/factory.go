@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasislabs/oasis-gateway/mqueue"
+)
+
+type LimiterFactory interface {
+	New(ctx context.Context, config *Config, mailbox *mqueue.Config) (Limiter, error)
+}
+
+type LimiterFactoryFunc func(ctx context.Context, config *Config, mailbox *mqueue.Config) (Limiter, error)
+
+func (f LimiterFactoryFunc) New(ctx context.Context, config *Config, mailbox *mqueue.Config) (Limiter, error) {
+	return f(ctx, config, mailbox)
+}
+
+// NewLimiter creates the Limiter for the configuration. It returns
+// nil if rate limiting is not enabled. The redis provider connects
+// to the redis instance or cluster configured for the mailbox
+var NewLimiter = LimiterFactoryFunc(func(ctx context.Context, config *Config, mailbox *mqueue.Config) (Limiter, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	switch config.Provider {
+	case ProviderMem:
+		return NewMemLimiter(), nil
+	case ProviderRedis:
+		switch c := mailbox.MailboxConfig.(type) {
+		case *mqueue.MailboxRedisSingleConfig:
+			return NewRedisSingleLimiter(c.Addr), nil
+		case *mqueue.MailboxRedisClusterConfig:
+			return NewRedisClusterLimiter(c.Addrs), nil
+		default:
+			return nil, fmt.Errorf("ratelimit.provider %s requires mailbox.provider to be %s or %s",
+				ProviderRedis, mqueue.MailboxRedisSingle, mqueue.MailboxRedisCluster)
+		}
+	default:
+		return nil, fmt.Errorf("unknown ratelimit.provider %s", config.Provider)
+	}
+})
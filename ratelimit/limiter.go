@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Limit is the limit of a token bucket. The bucket holds up to Burst
+// tokens and is refilled at Rate tokens per second. Each operation
+// takes a token from the bucket
+type Limit struct {
+	// Rate is the number of tokens added to the bucket per second
+	Rate float64
+
+	// Burst is the maximum number of tokens the bucket can hold
+	Burst int
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 {
+		return errors.New("rate must be greater than 0")
+	}
+
+	if l.Burst < 1 {
+		return errors.New("burst must be at least 1")
+	}
+
+	return nil
+}
+
+// refillTime returns the time it takes for the bucket to
+// be refilled with the provided number of tokens
+func (l Limit) refillTime(tokens float64) time.Duration {
+	return time.Duration(tokens / l.Rate * float64(time.Second))
+}
+
+// Result is the result of taking tokens from a bucket
+type Result struct {
+	// Allowed is true if the tokens were taken
+	Allowed bool
+
+	// RetryAfter is the time after which a token will be
+	// available if the request was not allowed
+	RetryAfter time.Duration
+}
+
+// Limiter keeps the token buckets used to rate limit requests
+type Limiter interface {
+	// Allow takes the provided number of tokens from the bucket
+	// identified by the key with the provided limit. The tokens are
+	// taken as long as the bucket has at least one, so that requests
+	// with more operations than the burst of the limit can still be
+	// served, and the bucket goes into debt until it is refilled
+	Allow(ctx context.Context, key string, limit Limit, tokens int) (Result, error)
+}
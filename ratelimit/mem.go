@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// memPruneInterval is the number of buckets created between the
+// removals of the buckets that are full, which are no different
+// from a bucket that does not exist yet
+const memPruneInterval = 1024
+
+type memBucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens generated since the bucket was last
+// updated
+func (b *memBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.last = now
+	}
+}
+
+// MemLimiter is a Limiter that keeps the token buckets in memory
+type MemLimiter struct {
+	mutex   sync.Mutex
+	buckets map[string]*memBucket
+	created int
+	now     func() time.Time
+}
+
+// NewMemLimiter creates a new instance of a MemLimiter
+func NewMemLimiter() *MemLimiter {
+	return &MemLimiter{
+		buckets: make(map[string]*memBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow is the implementation of Limiter for MemLimiter
+func (l *MemLimiter) Allow(ctx context.Context, key string, limit Limit, tokens int) (Result, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		l.prune(now)
+		bucket = &memBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.limit = limit
+	bucket.refill(now)
+
+	if bucket.tokens >= 1 {
+		bucket.tokens -= float64(tokens)
+		return Result{Allowed: true}, nil
+	}
+
+	return Result{RetryAfter: limit.refillTime(1 - bucket.tokens)}, nil
+}
+
+func (l *MemLimiter) prune(now time.Time) {
+	l.created++
+	if l.created < memPruneInterval {
+		return
+	}
+
+	l.created = 0
+	for key, bucket := range l.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
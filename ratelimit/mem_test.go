@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemLimiter(now *time.Time) *MemLimiter {
+	limiter := NewMemLimiter()
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestMemLimiterAllowBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTestMemLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		res, err := limiter.Allow(context.Background(), "key", limit, 1)
+		assert.Nil(t, err)
+		assert.True(t, res.Allowed)
+	}
+
+	res, err := limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+}
+
+func TestMemLimiterAllowRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTestMemLimiter(&now)
+	limit := Limit{Rate: 2, Burst: 1}
+
+	res, err := limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+
+	now = now.Add(250 * time.Millisecond)
+	res, err = limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 250*time.Millisecond, res.RetryAfter)
+
+	now = now.Add(250 * time.Millisecond)
+	res, err = limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemLimiterAllowTokens(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTestMemLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 3}
+
+	// the tokens are taken even if they exceed the burst, and the
+	// bucket needs to be refilled before the next request
+	res, err := limiter.Allow(context.Background(), "key", limit, 5)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 3*time.Second, res.RetryAfter)
+
+	now = now.Add(3 * time.Second)
+	res, err = limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemLimiterAllowKeys(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTestMemLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	res, err := limiter.Allow(context.Background(), "key1", limit, 1)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = limiter.Allow(context.Background(), "key2", limit, 1)
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = limiter.Allow(context.Background(), "key1", limit, 1)
+	assert.Nil(t, err)
+	assert.False(t, res.Allowed)
+}
+
+func TestMemLimiterPrune(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newTestMemLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	for i := 0; i < memPruneInterval-1; i++ {
+		_, err := limiter.Allow(context.Background(), fmt.Sprintf("key%d", i), limit, 1)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, memPruneInterval-1, len(limiter.buckets))
+
+	// once the buckets are full they are removed when the
+	// next bucket is created
+	now = now.Add(time.Second)
+	_, err := limiter.Allow(context.Background(), "key", limit, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(limiter.buckets))
+}
@@ -0,0 +1,151 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	authcore "github.com/oasislabs/oasis-gateway/auth/core"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// HttpHeaderRetryAfter is the header set in the responses to the
+// requests that exceed the limit with the number of seconds after
+// which the client can retry the request
+const HttpHeaderRetryAfter = "Retry-After"
+
+// defaultRoute is the route used in the keys of the buckets of the
+// routes that share the default limit
+const defaultRoute = "*"
+
+// OperationLimiter is an rpc.OperationLimiter that limits the rate at
+// which the operations are dispatched with token buckets. Each operation
+// takes a token from the bucket of the client for the route. It must be
+// used after the requests are authenticated when the operations are keyed
+// by AAD or session
+type OperationLimiter struct {
+	limiter Limiter
+	logger  log.Logger
+	key     KeyType
+	limit   Limit
+	routes  map[string]Limit
+}
+
+// OperationLimiterProps are the properties used to create
+// an OperationLimiter
+type OperationLimiterProps struct {
+	Limiter Limiter
+	Logger  log.Logger
+
+	// Key defines how the operations are grouped to be limited
+	Key KeyType
+
+	// Limit is the limit of the routes that are not in Routes
+	Limit Limit
+
+	// Routes are the limits specific to a route keyed by the template
+	// of the route relative to the prefix of the API version, as in
+	// /service/execute, so that a limit holds across all the versions
+	// and transports of the API. Keys that include the prefix, as in
+	// /v0/api/service/execute, are limited as the route they refer to
+	Routes map[string]Limit
+}
+
+// NewOperationLimiter creates a new instance of an OperationLimiter
+func NewOperationLimiter(props OperationLimiterProps) *OperationLimiter {
+	if props.Limiter == nil {
+		panic("limiter must be set")
+	}
+
+	if props.Logger == nil {
+		panic("logger must be set")
+	}
+
+	if len(props.Key) == 0 {
+		panic("key must be set")
+	}
+
+	routes := make(map[string]Limit, len(props.Routes))
+	for route, limit := range props.Routes {
+		routes[rpc.OperationRoute(route)] = limit
+	}
+
+	return &OperationLimiter{
+		limiter: props.Limiter,
+		logger:  props.Logger.ForClass("ratelimit", "OperationLimiter"),
+		key:     props.Key,
+		limit:   props.Limit,
+		routes:  routes,
+	}
+}
+
+// Allow is the implementation of rpc.OperationLimiter for
+// OperationLimiter. The route is the name returned by rpc.OperationRoute
+// for the route of the operations. It returns an *rpc.HttpError with the time after
+// which the client can retry if the operations exceed the limit
+func (l *OperationLimiter) Allow(ctx context.Context, route string, operations int) error {
+	limit, ok := l.routes[route]
+	if !ok {
+		route = defaultRoute
+		limit = l.limit
+	}
+
+	key := fmt.Sprintf("%s:%s:%s", route, l.key, l.clientKey(ctx))
+	res, err := l.limiter.Allow(ctx, key, limit, operations)
+	if err != nil {
+		// the operations are still dispatched if the state of the limits
+		// cannot be reached so that the gateway remains available
+		l.logger.Warn(ctx, "failed to check rate limit", log.MapFields{
+			"call_type": "RateLimitFailure",
+			"route":     route,
+			"err":       err.Error(),
+		})
+		return nil
+	}
+
+	if res.Allowed {
+		return nil
+	}
+
+	l.logger.Debug(ctx, "operations exceed rate limit", log.MapFields{
+		"call_type":   "RateLimitExceeded",
+		"route":       route,
+		"operations":  operations,
+		"retry_after": res.RetryAfter.String(),
+	})
+
+	retryAfter := int64(math.Ceil(res.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	httpErr := rpc.HttpTooManyRequests(ctx, errors.New(errors.ErrRateLimitExceeded, nil))
+	httpErr.Headers = http.Header{HttpHeaderRetryAfter: {strconv.FormatInt(retryAfter, 10)}}
+	return httpErr
+}
+
+// clientKey returns the value that identifies the client that sent
+// the operations for the key type of the limiter
+func (l *OperationLimiter) clientKey(ctx context.Context) string {
+	switch l.key {
+	case KeySession:
+		if session, ok := ctx.Value(authcore.Session{}).(string); ok {
+			return session
+		}
+		return authcore.MustGetAAD(ctx)
+	case KeyAAD:
+		return authcore.MustGetAAD(ctx)
+	default:
+		addr := rpc.GetRemoteAddr(ctx)
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return addr
+		}
+		return host
+	}
+}
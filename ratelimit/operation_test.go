@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	stderr "errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	authcore "github.com/oasislabs/oasis-gateway/auth/core"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var logger = log.NewLogrus(log.LogrusLoggerProperties{
+	Level:  logrus.DebugLevel,
+	Output: ioutil.Discard,
+})
+
+type limiterFunc func(ctx context.Context, key string, limit Limit, tokens int) (Result, error)
+
+func (f limiterFunc) Allow(ctx context.Context, key string, limit Limit, tokens int) (Result, error) {
+	return f(ctx, key, limit, tokens)
+}
+
+func newTestOperationLimiter(limiter Limiter, key KeyType) *OperationLimiter {
+	return NewOperationLimiter(OperationLimiterProps{
+		Limiter: limiter,
+		Logger:  logger,
+		Key:     key,
+		Limit:   Limit{Rate: 1, Burst: 1},
+		Routes: map[string]Limit{
+			"/v0/api/service/execute": {Rate: 1, Burst: 2},
+			"/service/{address}/code": {Rate: 1, Burst: 2},
+		},
+	})
+}
+
+func newTestContext(aad, session string) context.Context {
+	ctx := context.WithValue(context.Background(), rpc.RemoteAddr{}, "10.0.0.1:5000")
+	if len(aad) > 0 {
+		ctx = context.WithValue(ctx, authcore.AAD{}, aad)
+	}
+	if len(session) > 0 {
+		ctx = context.WithValue(ctx, authcore.Session{}, session)
+	}
+
+	return ctx
+}
+
+func TestNewOperationLimiterNoLimiter(t *testing.T) {
+	assert.Panics(t, func() {
+		NewOperationLimiter(OperationLimiterProps{
+			Logger: logger,
+			Key:    KeyAAD,
+		})
+	})
+}
+
+func TestOperationLimiterKeys(t *testing.T) {
+	tests := []struct {
+		key      KeyType
+		session  string
+		expected string
+	}{
+		{KeyAAD, "session", "*:aad:aad"},
+		{KeySession, "session", "*:session:session"},
+		{KeySession, "", "*:session:aad"},
+		{KeyIP, "session", "*:ip:10.0.0.1"},
+	}
+
+	for _, test := range tests {
+		var key string
+		l := newTestOperationLimiter(limiterFunc(func(ctx context.Context, k string, limit Limit, tokens int) (Result, error) {
+			key = k
+			return Result{Allowed: true}, nil
+		}), test.key)
+
+		err := l.Allow(newTestContext("aad", test.session), "/service/poll", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, key)
+	}
+}
+
+func TestOperationLimiterRoutes(t *testing.T) {
+	l := newTestOperationLimiter(NewMemLimiter(), KeyAAD)
+	ctx := newTestContext("aad", "")
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, l.Allow(ctx, "/service/execute", 1))
+	}
+
+	assert.Error(t, l.Allow(ctx, "/service/execute", 1))
+
+	// the routes without a limit of their own have a separate bucket
+	assert.Nil(t, l.Allow(ctx, "/service/poll", 1))
+	assert.Error(t, l.Allow(ctx, "/event/poll", 1))
+}
+
+func TestOperationLimiterOperations(t *testing.T) {
+	var tokens int
+	l := newTestOperationLimiter(limiterFunc(func(ctx context.Context, k string, limit Limit, n int) (Result, error) {
+		tokens = n
+		return Result{Allowed: true}, nil
+	}), KeyAAD)
+
+	assert.Nil(t, l.Allow(newTestContext("aad", ""), "/service/executeBatch", 256))
+	assert.Equal(t, 256, tokens)
+}
+
+func TestOperationLimiterExceeded(t *testing.T) {
+	l := newTestOperationLimiter(limiterFunc(func(ctx context.Context, key string, limit Limit, tokens int) (Result, error) {
+		return Result{RetryAfter: 1500 * time.Millisecond}, nil
+	}), KeyAAD)
+
+	err := l.Allow(newTestContext("aad", ""), "/service/execute", 1)
+	httpErr := err.(*rpc.HttpError)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, errors.ErrRateLimitExceeded, httpErr.Cause.ErrorCode())
+	assert.Equal(t, "2", httpErr.Headers.Get(HttpHeaderRetryAfter))
+}
+
+func TestOperationLimiterErr(t *testing.T) {
+	l := newTestOperationLimiter(limiterFunc(func(ctx context.Context, key string, limit Limit, tokens int) (Result, error) {
+		return Result{}, stderr.New("connection refused")
+	}), KeyAAD)
+
+	assert.Nil(t, l.Allow(newTestContext("aad", ""), "/service/execute", 1))
+}
+
+func TestOperationLimiterRouteTemplate(t *testing.T) {
+	var key string
+	l := newTestOperationLimiter(limiterFunc(func(ctx context.Context, k string, limit Limit, tokens int) (Result, error) {
+		key = k
+		return Result{Allowed: true}, nil
+	}), KeyAAD)
+
+	assert.Nil(t, l.Allow(newTestContext("aad", ""), "/service/{address}/code", 1))
+	assert.Equal(t, "/service/{address}/code:aad:aad", key)
+}
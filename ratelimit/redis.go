@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// redisKeyPrefix is the prefix of the keys of the token buckets
+const redisKeyPrefix = "ratelimit:"
+
+// takeTokenScript takes ARGV[4] tokens from the bucket stored in KEYS[1]
+// with rate ARGV[1], burst ARGV[2], at time ARGV[3] in milliseconds.
+// It returns whether the tokens were taken and, if not, the number
+// of milliseconds until one is available. The bucket expires once
+// it is full again
+const takeTokenScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+
+local state = redis.call('hmget', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+  tokens = tokens - cost
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('hmset', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('pexpire', KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1)
+return {allowed, retry}
+`
+
+// Client is the interface to the redis client used by the
+// RedisLimiter
+type Client interface {
+	Eval(script string, keys []string, args ...interface{}) *redis.Cmd
+}
+
+// RedisLimiter is a Limiter that keeps the token buckets in redis so
+// that the limits are shared by all the instances of the gateway
+type RedisLimiter struct {
+	client Client
+	now    func() time.Time
+}
+
+// RedisLimiterProps are the properties used to create a RedisLimiter
+type RedisLimiterProps struct {
+	Client Client
+}
+
+// NewRedisLimiter creates a new instance of a RedisLimiter
+func NewRedisLimiter(props RedisLimiterProps) *RedisLimiter {
+	if props.Client == nil {
+		panic("client must be set")
+	}
+
+	return &RedisLimiter{client: props.Client, now: time.Now}
+}
+
+// NewRedisSingleLimiter creates a new instance of a RedisLimiter
+// that connects to a single redis instance
+func NewRedisSingleLimiter(addr string) *RedisLimiter {
+	return NewRedisLimiter(RedisLimiterProps{
+		Client: redis.NewClient(&redis.Options{Addr: addr}),
+	})
+}
+
+// NewRedisClusterLimiter creates a new instance of a RedisLimiter
+// that connects to a redis cluster
+func NewRedisClusterLimiter(addrs []string) *RedisLimiter {
+	return NewRedisLimiter(RedisLimiterProps{
+		Client: redis.NewClusterClient(&redis.ClusterOptions{Addrs: addrs}),
+	})
+}
+
+// Allow is the implementation of Limiter for RedisLimiter
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit, tokens int) (Result, error) {
+	now := l.now().UnixNano() / int64(time.Millisecond)
+	v, err := l.client.Eval(takeTokenScript, []string{redisKeyPrefix + key},
+		limit.Rate, limit.Burst, now, tokens).Result()
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := v.([]interface{})
+	if !ok || len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected response %v from redis", v)
+	}
+
+	allowed, ok := values[0].(int64)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected response %v from redis", v)
+	}
+
+	retry, ok := values[1].(int64)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected response %v from redis", v)
+	}
+
+	return Result{
+		Allowed:    allowed == 1,
+		RetryAfter: time.Duration(retry) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	stderr "errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockClient struct {
+	keys []string
+	args []interface{}
+	cmd  *redis.Cmd
+}
+
+func (c *mockClient) Eval(script string, keys []string, args ...interface{}) *redis.Cmd {
+	c.keys = keys
+	c.args = args
+	return c.cmd
+}
+
+func newTestRedisLimiter(client Client) *RedisLimiter {
+	limiter := NewRedisLimiter(RedisLimiterProps{Client: client})
+	limiter.now = func() time.Time { return time.Unix(1, 0) }
+	return limiter
+}
+
+func TestNewRedisLimiterNoClient(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRedisLimiter(RedisLimiterProps{})
+	})
+}
+
+func TestRedisLimiterAllow(t *testing.T) {
+	client := &mockClient{cmd: redis.NewCmdResult([]interface{}{int64(1), int64(0)}, nil)}
+	limiter := newTestRedisLimiter(client)
+
+	res, err := limiter.Allow(context.Background(), "key", Limit{Rate: 2, Burst: 5}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Allowed: true}, res)
+	assert.Equal(t, []string{"ratelimit:key"}, client.keys)
+	assert.Equal(t, []interface{}{float64(2), 5, int64(1000), 1}, client.args)
+}
+
+func TestRedisLimiterAllowExceeded(t *testing.T) {
+	client := &mockClient{cmd: redis.NewCmdResult([]interface{}{int64(0), int64(1500)}, nil)}
+	limiter := newTestRedisLimiter(client)
+
+	res, err := limiter.Allow(context.Background(), "key", Limit{Rate: 2, Burst: 5}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{RetryAfter: 1500 * time.Millisecond}, res)
+}
+
+func TestRedisLimiterAllowErr(t *testing.T) {
+	client := &mockClient{cmd: redis.NewCmdResult(nil, stderr.New("connection refused"))}
+	limiter := newTestRedisLimiter(client)
+
+	_, err := limiter.Allow(context.Background(), "key", Limit{Rate: 2, Burst: 5}, 1)
+	assert.Error(t, err)
+}
+
+func TestRedisLimiterAllowUnexpectedResponse(t *testing.T) {
+	client := &mockClient{cmd: redis.NewCmdResult("OK", nil)}
+	limiter := newTestRedisLimiter(client)
+
+	_, err := limiter.Allow(context.Background(), "key", Limit{Rate: 2, Burst: 5}, 1)
+	assert.Error(t, err)
+}
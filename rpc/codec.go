@@ -0,0 +1,347 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oasislabs/oasis-gateway/rw"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// MediaTypeJson is the media type of JSON payloads
+	MediaTypeJson = "application/json"
+
+	// MediaTypeCbor is the media type of CBOR payloads
+	MediaTypeCbor = "application/cbor"
+
+	// MediaTypeMsgpack is the media type of MessagePack payloads
+	MediaTypeMsgpack = "application/msgpack"
+
+	// MediaTypeParamBytes is the media type parameter with which a client
+	// asks for the hex encoded byte strings of a binary response to be
+	// encoded as raw bytes, as in "application/cbor; bytes=raw"
+	MediaTypeParamBytes = "bytes"
+
+	// BytesTag is the struct tag that declares a string field as a hex
+	// encoded byte string, as in `bytes:"hex"`. Only the fields declared
+	// this way are encoded as raw bytes when a client asks for them, so
+	// that the type of a field does not depend on its value
+	BytesTag = "bytes"
+)
+
+var (
+	cborHandle    = newCborHandle()
+	msgpackHandle = newMsgpackHandle()
+)
+
+func newCborHandle() *codec.CborHandle {
+	h := &codec.CborHandle{}
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return h
+}
+
+func newMsgpackHandle() *codec.MsgpackHandle {
+	// WriteExt and RawToString make the handle use the str and bin
+	// types of the spec, so that strings and raw bytes can be told apart
+	h := &codec.MsgpackHandle{WriteExt: true, RawToString: true}
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return h
+}
+
+// CborEncoder is a payload encoder that serializes to CBOR
+type CborEncoder struct {
+	// RawBytes if true encodes the fields declared as hex encoded
+	// byte strings as raw bytes
+	RawBytes bool
+}
+
+// Encode is the implementation of Encoder for CborEncoder
+func (e CborEncoder) Encode(writer io.Writer, v interface{}) error {
+	return encodeBinary(writer, v, cborHandle, e.RawBytes)
+}
+
+// MsgpackEncoder is a payload encoder that serializes to MessagePack
+type MsgpackEncoder struct {
+	// RawBytes if true encodes the fields declared as hex encoded
+	// byte strings as raw bytes
+	RawBytes bool
+}
+
+// Encode is the implementation of Encoder for MsgpackEncoder
+func (e MsgpackEncoder) Encode(writer io.Writer, v interface{}) error {
+	return encodeBinary(writer, v, msgpackHandle, e.RawBytes)
+}
+
+// CborDecoder is a payload decoder that deserializes from CBOR
+type CborDecoder struct{}
+
+// Decode is the implementation of Decoder for CborDecoder
+func (d CborDecoder) Decode(reader io.Reader, v interface{}) error {
+	return decodeBinary(reader, v, cborHandle)
+}
+
+// DecodeWithLimit decodes the payload in the reader making sure not
+// to exceed the limit provided
+func (d CborDecoder) DecodeWithLimit(reader io.Reader, v interface{}, props rw.ReadLimitProps) error {
+	return decodeBinaryWithLimit(reader, v, props, cborHandle)
+}
+
+// MsgpackDecoder is a payload decoder that deserializes from MessagePack
+type MsgpackDecoder struct{}
+
+// Decode is the implementation of Decoder for MsgpackDecoder
+func (d MsgpackDecoder) Decode(reader io.Reader, v interface{}) error {
+	return decodeBinary(reader, v, msgpackHandle)
+}
+
+// DecodeWithLimit decodes the payload in the reader making sure not
+// to exceed the limit provided
+func (d MsgpackDecoder) DecodeWithLimit(reader io.Reader, v interface{}, props rw.ReadLimitProps) error {
+	return decodeBinaryWithLimit(reader, v, props, msgpackHandle)
+}
+
+// NegotiateDecoder returns the Decoder for a body with the provided
+// Content-Type. It returns false if the media type is not supported
+func NegotiateDecoder(contentType string) (LimitDecoder, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	switch mediaType {
+	case MediaTypeJson:
+		return JsonDecoder{}, true
+	case MediaTypeCbor:
+		return CborDecoder{}, true
+	case MediaTypeMsgpack:
+		return MsgpackDecoder{}, true
+	default:
+		return nil, false
+	}
+}
+
+// NegotiateEncoder returns the Encoder for the response to a request with
+// the provided Accept header, along with the media type of the response.
+// The media types are tried in the order in which they are listed. It
+// returns false if the header does not name any of the supported media
+// types, in which case the default encoding is to be used
+func NegotiateEncoder(accept string) (Encoder, string, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		rawBytes := params[MediaTypeParamBytes] == "raw"
+		switch mediaType {
+		case MediaTypeJson:
+			return JsonEncoder{}, MediaTypeJson, true
+		case MediaTypeCbor:
+			return CborEncoder{RawBytes: rawBytes}, MediaTypeCbor, true
+		case MediaTypeMsgpack:
+			return MsgpackEncoder{RawBytes: rawBytes}, MediaTypeMsgpack, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// encodeBinary encodes the value with the handle. The value is first
+// serialized to JSON so that the payloads have the same fields and
+// representation in all the encodings
+func encodeBinary(writer io.Writer, v interface{}, handle codec.Handle, rawBytes bool) error {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	return codec.NewEncoder(writer, handle).Encode(fromJsonValue(value, reflect.ValueOf(v), rawBytes))
+}
+
+// decodeBinary decodes the payload with the handle. The payload is
+// converted to JSON before it is decoded into the value, so that the
+// values are decoded in the same way as JSON payloads
+func decodeBinary(reader io.Reader, v interface{}, handle codec.Handle) error {
+	var value interface{}
+	if err := codec.NewDecoder(reader, handle).Decode(&value); err != nil {
+		return err
+	}
+
+	p, err := json.Marshal(toJsonValue(value))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(p, v)
+}
+
+func decodeBinaryWithLimit(reader io.Reader, v interface{}, props rw.ReadLimitProps, handle codec.Handle) error {
+	props.ErrOnEOF = true
+	limitReader := rw.NewLimitReader(reader, props)
+	p, err := ioutil.ReadAll(&limitReader)
+	if err != nil {
+		return err
+	}
+
+	return decodeBinary(bytes.NewReader(p), v, handle)
+}
+
+// jsonField is a struct field by the name with which it is serialized
+// to JSON
+type jsonField struct {
+	// value of the field
+	value reflect.Value
+
+	// bytes is true if the field is declared as a hex encoded byte string
+	bytes bool
+}
+
+// jsonFields adds the fields of the struct v to fields by the name with
+// which they are serialized to JSON, including the fields of embedded
+// structs
+func jsonFields(v reflect.Value, fields map[string]jsonField) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && len(name) == 0 {
+			if embedded := indirect(v.Field(i)); embedded.Kind() == reflect.Struct {
+				jsonFields(embedded, fields)
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			// unexported fields are not serialized
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		fields[name] = jsonField{value: v.Field(i), bytes: field.Tag.Get(BytesTag) == "hex"}
+	}
+}
+
+// indirect returns the value that v points to or holds, if v is
+// a pointer or an interface
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// fromJsonValue converts a value decoded from JSON so that it can be
+// encoded in a binary format. The original value v from which the JSON
+// was generated is used to find the fields declared as hex encoded byte
+// strings. Numbers are converted to integers when possible and, if
+// rawBytes is set, the byte strings to raw bytes
+func fromJsonValue(value interface{}, v reflect.Value, rawBytes bool) interface{} {
+	v = indirect(v)
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		var fields map[string]jsonField
+		if v.Kind() == reflect.Struct {
+			fields = make(map[string]jsonField)
+			jsonFields(v, fields)
+		}
+
+		for key, el := range value {
+			field, ok := fields[key]
+			switch {
+			case ok && field.bytes && rawBytes:
+				value[key] = fromHexString(el)
+			case ok:
+				value[key] = fromJsonValue(el, field.value, rawBytes)
+			case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+				index := reflect.ValueOf(key).Convert(v.Type().Key())
+				value[key] = fromJsonValue(el, v.MapIndex(index), rawBytes)
+			default:
+				value[key] = fromJsonValue(el, reflect.Value{}, rawBytes)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, el := range value {
+			var elv reflect.Value
+			if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && i < v.Len() {
+				elv = v.Index(i)
+			}
+
+			value[i] = fromJsonValue(el, elv, rawBytes)
+		}
+		return value
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	default:
+		return value
+	}
+}
+
+// fromHexString converts a hex encoded byte string to raw bytes. Values
+// that are not valid hex encoded strings are kept as they are
+func fromHexString(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return value
+	}
+
+	p, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return value
+	}
+
+	return p
+}
+
+// toJsonValue converts a value decoded from a binary format so that it
+// can be encoded to JSON. Raw bytes are converted to hex encoded strings
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, el := range v {
+			v[key] = toJsonValue(el)
+		}
+		return v
+	case []interface{}:
+		for i, el := range v {
+			v[i] = toJsonValue(el)
+		}
+		return v
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/rw"
+	"github.com/stretchr/testify/assert"
+	"github.com/ugorji/go/codec"
+)
+
+type codecEntity struct {
+	ID     uint64          `json:"id"`
+	Data   string          `json:"data" bytes:"hex"`
+	Topics []string        `json:"topics,omitempty"`
+	Args   json.RawMessage `json:"args,omitempty"`
+}
+
+func TestCborEncoderDecoder(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	v := codecEntity{ID: 1 << 60, Data: "0x0102", Topics: []string{"0xff"}, Args: json.RawMessage(`{"a":1}`)}
+
+	assert.Nil(t, CborEncoder{}.Encode(buffer, v))
+
+	var decoded codecEntity
+	assert.Nil(t, CborDecoder{}.Decode(buffer, &decoded))
+	assert.Equal(t, v, decoded)
+}
+
+func TestMsgpackEncoderDecoder(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	v := codecEntity{ID: 1 << 60, Data: "0x0102", Topics: []string{"0xff"}}
+
+	assert.Nil(t, MsgpackEncoder{}.Encode(buffer, v))
+
+	var decoded codecEntity
+	assert.Nil(t, MsgpackDecoder{}.DecodeWithLimit(buffer, &decoded, rw.ReadLimitProps{
+		FailOnExceed: true,
+		Limit:        1024,
+	}))
+	assert.Equal(t, v, decoded)
+}
+
+func TestCborEncoderRawBytes(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(t, CborEncoder{RawBytes: true}.Encode(buffer, codecEntity{ID: 1, Data: "0x0102"}))
+
+	var m map[string]interface{}
+	assert.Nil(t, codec.NewDecoder(bytes.NewReader(buffer.Bytes()), cborHandle).Decode(&m))
+	assert.Equal(t, []byte{1, 2}, m["data"])
+	assert.Equal(t, uint64(1), m["id"])
+
+	// raw bytes are decoded back as hex encoded strings
+	var decoded codecEntity
+	assert.Nil(t, CborDecoder{}.Decode(buffer, &decoded))
+	assert.Equal(t, codecEntity{ID: 1, Data: "0x0102"}, decoded)
+}
+
+func TestCborEncoderRawBytesOnlyDeclaredFields(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(t, CborEncoder{RawBytes: true}.Encode(buffer, codecEntity{Data: "0x0102", Topics: []string{"0xff"}}))
+
+	var m map[string]interface{}
+	assert.Nil(t, codec.NewDecoder(buffer, cborHandle).Decode(&m))
+	assert.Equal(t, []byte{1, 2}, m["data"])
+	assert.Equal(t, []interface{}{"0xff"}, m["topics"])
+}
+
+func TestCborEncoderRawBytesNested(t *testing.T) {
+	type embedded struct {
+		Output string `json:"output" bytes:"hex"`
+	}
+	type response struct {
+		embedded
+		Address string        `json:"address"`
+		Events  []interface{} `json:"events"`
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(t, CborEncoder{RawBytes: true}.Encode(buffer, &response{
+		embedded: embedded{Output: "0x01"},
+		Address:  "0x02",
+		Events:   []interface{}{&codecEntity{Data: "0x03"}, map[string]string{"data": "0x04"}},
+	}))
+
+	var m map[string]interface{}
+	assert.Nil(t, codec.NewDecoder(buffer, cborHandle).Decode(&m))
+	assert.Equal(t, []byte{1}, m["output"])
+	assert.Equal(t, "0x02", m["address"])
+
+	events := m["events"].([]interface{})
+	assert.Equal(t, []byte{3}, events[0].(map[string]interface{})["data"])
+	assert.Equal(t, "0x04", events[1].(map[string]interface{})["data"])
+}
+
+func TestMsgpackEncoderRawBytesNotHex(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(t, MsgpackEncoder{RawBytes: true}.Encode(buffer, codecEntity{Data: "0xzz"}))
+
+	var m map[string]interface{}
+	assert.Nil(t, codec.NewDecoder(buffer, msgpackHandle).Decode(&m))
+	assert.Equal(t, "0xzz", m["data"])
+}
+
+func TestNegotiateDecoder(t *testing.T) {
+	decoder, ok := NegotiateDecoder("application/json; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, JsonDecoder{}, decoder)
+
+	decoder, ok = NegotiateDecoder("application/cbor")
+	assert.True(t, ok)
+	assert.Equal(t, CborDecoder{}, decoder)
+
+	decoder, ok = NegotiateDecoder("application/msgpack")
+	assert.True(t, ok)
+	assert.Equal(t, MsgpackDecoder{}, decoder)
+
+	_, ok = NegotiateDecoder("text/plain")
+	assert.False(t, ok)
+}
+
+func TestNegotiateEncoder(t *testing.T) {
+	encoder, mediaType, ok := NegotiateEncoder("text/html, application/cbor; bytes=raw, application/json")
+	assert.True(t, ok)
+	assert.Equal(t, CborEncoder{RawBytes: true}, encoder)
+	assert.Equal(t, MediaTypeCbor, mediaType)
+
+	encoder, mediaType, ok = NegotiateEncoder("application/msgpack")
+	assert.True(t, ok)
+	assert.Equal(t, MsgpackEncoder{}, encoder)
+	assert.Equal(t, MediaTypeMsgpack, mediaType)
+
+	_, _, ok = NegotiateEncoder("*/*")
+	assert.False(t, ok)
+
+	_, _, ok = NegotiateEncoder("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	stderr "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+)
+
+const (
+	// HttpHeaderContentEncoding is the header that sets the
+	// compression of the body of a request or a response
+	HttpHeaderContentEncoding = "Content-Encoding"
+
+	// HttpHeaderAcceptEncoding is the header with which a client sets
+	// the compressions it accepts for the body of a response
+	HttpHeaderAcceptEncoding = "Accept-Encoding"
+
+	// ContentEncodingGzip is the content encoding for gzip compression
+	ContentEncodingGzip = "gzip"
+
+	// ContentEncodingDeflate is the content encoding for the zlib
+	// format with deflate compression
+	ContentEncodingDeflate = "deflate"
+
+	// ContentEncodingIdentity is the content encoding of bodies
+	// that are not compressed
+	ContentEncodingIdentity = "identity"
+
+	// compressMinBytes is the size from which a response body is
+	// compressed. Smaller bodies do not gain much from compression
+	compressMinBytes = 1024
+)
+
+// errContentEncodingNotSupported is returned when a body is
+// compressed with an encoding that is not supported
+var errContentEncodingNotSupported = stderr.New("content encoding not supported")
+
+// ContentEncoding is the key of the context value set with the
+// content encoding of a request body that is decompressed when read
+type ContentEncoding struct{}
+
+// GetContentEncoding returns the content encoding of the compressed
+// body of the request, or an empty string if it is not compressed
+func GetContentEncoding(ctx context.Context) string {
+	value := ctx.Value(ContentEncoding{})
+	if value == nil {
+		return ""
+	}
+
+	return value.(string)
+}
+
+// NewDecompressReader returns a reader that decompresses the contents
+// of the provided reader compressed with the content encoding
+func NewDecompressReader(r io.Reader, encoding string) (io.ReadCloser, error) {
+	switch encoding {
+	case ContentEncodingGzip:
+		return gzip.NewReader(r)
+	case ContentEncodingDeflate:
+		return zlib.NewReader(r)
+	default:
+		return nil, errContentEncodingNotSupported
+	}
+}
+
+// NewCompressWriter returns a writer that compresses the contents
+// written to it with the content encoding before writing them to
+// the provided writer. It must be closed to flush the contents
+func NewCompressWriter(w io.Writer, encoding string) (io.WriteCloser, error) {
+	switch encoding {
+	case ContentEncodingGzip:
+		return gzip.NewWriter(w), nil
+	case ContentEncodingDeflate:
+		return zlib.NewWriter(w), nil
+	default:
+		return nil, errContentEncodingNotSupported
+	}
+}
+
+// NegotiateContentEncoding returns the content encoding to compress
+// the response to a request with the provided Accept-Encoding header. It
+// returns false if the response should not be compressed
+func NegotiateContentEncoding(accept string) (string, bool) {
+	var (
+		encoding string
+		quality  float64
+	)
+
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name != ContentEncodingGzip && name != ContentEncodingDeflate {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > quality {
+			encoding, quality = name, q
+		}
+	}
+
+	return encoding, quality > 0
+}
+
+// decompressBody is the body of a request that is decompressed
+// when it is read
+type decompressBody struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+// Close is the implementation of io.Closer for decompressBody
+func (b decompressBody) Close() error {
+	err := b.ReadCloser.Close()
+	if berr := b.body.Close(); berr != nil {
+		return berr
+	}
+
+	return err
+}
+
+// decompressRequest sets up the request so that its body is decompressed
+// when it is read. The body is not decompressed ahead of time so that the
+// handler can limit the number of decompressed bytes it reads
+func decompressRequest(req *http.Request) (*http.Request, error) {
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get(HttpHeaderContentEncoding)))
+	if len(encoding) == 0 || encoding == ContentEncodingIdentity || req.Body == nil || req.ContentLength == 0 {
+		return req, nil
+	}
+
+	reader, err := NewDecompressReader(req.Body, encoding)
+	if err == errContentEncodingNotSupported {
+		return req, errors.New(errors.ErrHttpContentEncodingNotSupported, nil)
+	}
+	if err != nil {
+		return req, errors.New(errors.ErrDeserializeBody, err)
+	}
+
+	req = req.WithContext(context.WithValue(req.Context(), ContentEncoding{}, encoding))
+	req.Body = decompressBody{ReadCloser: reader, body: req.Body}
+	return req, nil
+}
+
+// writeBody writes the encoded body of a response. The body is
+// compressed if the client accepts it and it is large enough
+func writeBody(res http.ResponseWriter, req *http.Request, p []byte) error {
+	res.Header().Add("Vary", HttpHeaderAcceptEncoding)
+
+	encoding, ok := NegotiateContentEncoding(req.Header.Get(HttpHeaderAcceptEncoding))
+	if !ok || len(p) < compressMinBytes {
+		_, err := res.Write(p)
+		return err
+	}
+
+	res.Header().Set(HttpHeaderContentEncoding, encoding)
+	w, err := NewCompressWriter(res, encoding)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(p); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
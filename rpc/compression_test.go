@@ -0,0 +1,168 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCompressionRouter(limit uint) *HttpRouter {
+	binder := NewHttpBinder(HttpBinderProperties{
+		Encoder: JsonEncoder{},
+		Logger:  logger,
+		HandlerFactory: HttpHandlerFactoryFunc(func(factory EntityFactory, handler Handler) HttpMiddleware {
+			return NewHttpJsonHandler(HttpJsonHandlerProperties{
+				Limit:   limit,
+				Handler: handler,
+				Logger:  logger,
+				Factory: factory,
+			})
+		}),
+	})
+
+	binder.Bind("POST", "/echo", HandlerEcho{}, mapEntityFactory())
+	return binder.Build()
+}
+
+func gzipBody(t *testing.T, s string) []byte {
+	var buffer bytes.Buffer
+	w := gzip.NewWriter(&buffer)
+	_, err := w.Write([]byte(s))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return buffer.Bytes()
+}
+
+func TestNegotiateContentEncoding(t *testing.T) {
+	tests := map[string]string{
+		"gzip":                        ContentEncodingGzip,
+		"deflate, gzip":               ContentEncodingDeflate,
+		"deflate;q=0.5, gzip;q=0.8":   ContentEncodingGzip,
+		"br, GZIP":                    ContentEncodingGzip,
+		"gzip;q=0, deflate;q=0.1, br": ContentEncodingDeflate,
+	}
+
+	for accept, expected := range tests {
+		encoding, ok := NegotiateContentEncoding(accept)
+		assert.True(t, ok, accept)
+		assert.Equal(t, expected, encoding, accept)
+	}
+
+	for _, accept := range []string{"", "identity", "br", "gzip;q=0"} {
+		_, ok := NegotiateContentEncoding(accept)
+		assert.False(t, ok, accept)
+	}
+}
+
+func TestHttpRouterServeHTTPGzipRequest(t *testing.T) {
+	router := setupCompressionRouter(1024)
+	body := gzipBody(t, `{"potato":"fried"}`)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", bytes.NewReader(body))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderContentEncoding, ContentEncodingGzip)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "{\"potato\":\"fried\"}\n", recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPDeflateRequest(t *testing.T) {
+	router := setupCompressionRouter(1024)
+
+	var buffer bytes.Buffer
+	w := zlib.NewWriter(&buffer)
+	_, err := w.Write([]byte(`{"potato":"fried"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", &buffer)
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderContentEncoding, ContentEncodingDeflate)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "{\"potato\":\"fried\"}\n", recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPGzipRequestExceedsLimit(t *testing.T) {
+	router := setupCompressionRouter(1024)
+
+	// the compressed body is well within the limit but not
+	// the decompressed one
+	body := gzipBody(t, `{"potato":"`+strings.Repeat("a", 1<<16)+`"}`)
+	assert.True(t, len(body) < 1024)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", bytes.NewReader(body))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderContentEncoding, ContentEncodingGzip)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "{\"errorCode\":2003,\"description\":\"Content-length exceeds request limit.\"}\n",
+		recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPContentEncodingNotSupported(t *testing.T) {
+	router := setupCompressionRouter(1024)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", bytes.NewBufferString(`{"potato":"fried"}`))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderContentEncoding, "br")
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "{\"errorCode\":2022,\"description\":\"Content-encoding should be gzip, deflate or identity.\"}\n",
+		recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPGzipResponse(t *testing.T) {
+	router := setupCompressionRouter(1 << 16)
+	payload := `{"potato":"` + strings.Repeat("a", 2048) + `"}`
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", bytes.NewBufferString(payload))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderAcceptEncoding, "gzip")
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, ContentEncodingGzip, recorder.Header().Get(HttpHeaderContentEncoding))
+
+	r, err := gzip.NewReader(recorder.Body)
+	assert.Nil(t, err)
+	p, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, payload+"\n", string(p))
+}
+
+func TestHttpRouterServeHTTPSmallResponseNotCompressed(t *testing.T) {
+	router := setupCompressionRouter(1024)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/echo", bytes.NewBufferString(`{"potato":"fried"}`))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(HttpHeaderAcceptEncoding, "gzip")
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "", recorder.Header().Get(HttpHeaderContentEncoding))
+	assert.Equal(t, "{\"potato\":\"fried\"}\n", recorder.Body.String())
+}
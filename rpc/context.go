@@ -1,9 +1,25 @@
 package rpc
 
 import (
+	"context"
 	"strconv"
 )
 
+// IdempotencyKey is the key used to store in the context of a request
+// the idempotency key provided by the client
+type IdempotencyKey struct{}
+
+// GetIdempotencyKey returns the idempotency key provided by the client
+// for the request, or an empty string if none was provided
+func GetIdempotencyKey(ctx context.Context) string {
+	key, ok := ctx.Value(IdempotencyKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return key
+}
+
 // ParseTraceID parses a traceID from a string and in case of failure
 // it returns a default -1
 func ParseTraceID(s string) int64 {
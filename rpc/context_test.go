@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +21,12 @@ func TestParseContextInteger(t *testing.T) {
 	traceID := ParseTraceID("12345")
 	assert.Equal(t, int64(12345), traceID)
 }
+
+func TestGetIdempotencyKeyNotSet(t *testing.T) {
+	assert.Equal(t, "", GetIdempotencyKey(context.Background()))
+}
+
+func TestGetIdempotencyKeySet(t *testing.T) {
+	ctx := context.WithValue(context.Background(), IdempotencyKey{}, "key")
+	assert.Equal(t, "key", GetIdempotencyKey(ctx))
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WriteDeadline is the key of the context value set with the function
+// that extends the time the server has to write the response
+type WriteDeadline struct{}
+
+// ExtendWriteDeadline lets the server take longer to write the response
+// to a request that waits for up to the provided amount of time, so that
+// the response can still be written once the wait is over. The server is
+// given its write timeout after the wait. Transports without a write
+// deadline for each request are not affected
+func ExtendWriteDeadline(ctx context.Context, wait time.Duration) {
+	extend, ok := ctx.Value(WriteDeadline{}).(func(time.Duration))
+	if !ok {
+		return
+	}
+
+	extend(wait)
+}
+
+// newWriteDeadlineExtender returns the function that extends the write
+// deadline of the response, or nil if the server that serves the
+// request does not have a write timeout
+func newWriteDeadlineExtender(res http.ResponseWriter, req *http.Request) func(time.Duration) error {
+	server, ok := req.Context().Value(http.ServerContextKey).(*http.Server)
+	if !ok || server.WriteTimeout <= 0 {
+		return nil
+	}
+
+	controller := http.NewResponseController(res)
+	return func(wait time.Duration) error {
+		return controller.SetWriteDeadline(time.Now().Add(wait + server.WriteTimeout))
+	}
+}
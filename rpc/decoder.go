@@ -42,6 +42,16 @@ type Decoder interface {
 	Decode(r io.Reader, v interface{}) error
 }
 
+// LimitDecoder is a Decoder that can make sure not to read
+// more than a limit from the reader
+type LimitDecoder interface {
+	Decoder
+
+	// DecodeWithLimit decodes the payload in the reader making sure
+	// not to exceed the limit provided
+	DecodeWithLimit(r io.Reader, v interface{}, props rw.ReadLimitProps) error
+}
+
 // JsonEncoder is a payload encoder that serializes to JSON
 type JsonDecoder struct{}
 
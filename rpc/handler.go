@@ -38,3 +38,62 @@ type HandlerBinder interface {
 	// will be dispatched when method and path combination is provided
 	Bind(method string, path string, handler Handler, factory EntityFactory)
 }
+
+// DescribedHandler is a Handler that carries a description of the
+// responses it returns, so that the routes it is bound to can be
+// documented
+type DescribedHandler struct {
+	Handler
+
+	// Response is an instance of the type of the responses returned
+	// by the handler. If it is nil the handler does not return a body
+	Response interface{}
+
+	// OneOf has an instance of each of the types of the responses
+	// returned by a handler that returns responses of different types
+	OneOf []interface{}
+
+	// Stream is true if the handler returns a Stream of events
+	Stream bool
+}
+
+// Describe describes the responses returned by the handler with
+// the type of the provided response
+func Describe(handler Handler, response interface{}) Handler {
+	return DescribedHandler{Handler: handler, Response: response}
+}
+
+// DescribeOneOf describes the responses returned by the handler as
+// responses of any of the types of the provided responses
+func DescribeOneOf(handler Handler, responses ...interface{}) Handler {
+	return DescribedHandler{Handler: handler, OneOf: responses}
+}
+
+// DescribeStream describes the handler as a handler that returns a
+// Stream of events
+func DescribeStream(handler Handler) Handler {
+	return DescribedHandler{Handler: handler, Stream: true}
+}
+
+// PrefixBinder is a HandlerBinder that binds the handlers to
+// another HandlerBinder with the paths prefixed. It is used to group
+// the routes of a version of the API under the same prefix
+type PrefixBinder struct {
+	prefix string
+	binder HandlerBinder
+}
+
+// NewPrefixBinder creates a new PrefixBinder that binds the handlers
+// to the provided binder with the paths prefixed by prefix
+func NewPrefixBinder(prefix string, binder HandlerBinder) *PrefixBinder {
+	if binder == nil {
+		panic("binder must be set")
+	}
+
+	return &PrefixBinder{prefix: prefix, binder: binder}
+}
+
+// Bind is the implementation of HandlerBinder for PrefixBinder
+func (b *PrefixBinder) Bind(method string, path string, handler Handler, factory EntityFactory) {
+	b.binder.Bind(method, b.prefix+path, handler, factory)
+}
@@ -27,3 +27,30 @@ func TestEntityFactoryFunc(t *testing.T) {
 
 	assert.Nil(t, v)
 }
+
+type bindRecorder struct {
+	paths []string
+}
+
+func (b *bindRecorder) Bind(method string, path string, handler Handler, factory EntityFactory) {
+	b.paths = append(b.paths, method+" "+path)
+}
+
+func TestPrefixBinder(t *testing.T) {
+	recorder := &bindRecorder{}
+	binder := NewPrefixBinder("/v1/api", recorder)
+
+	binder.Bind("POST", "/service/execute", HandlerEcho{}, EntityFactoryFunc(func() interface{} { return nil }))
+
+	assert.Equal(t, []string{"POST /v1/api/service/execute"}, recorder.paths)
+}
+
+func TestDescribe(t *testing.T) {
+	handler := Describe(HandlerEcho{}, StreamEvent{})
+
+	v, err := handler.Handle(context.Background(), "body")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "body", v)
+	assert.Equal(t, StreamEvent{}, handler.(DescribedHandler).Response)
+}
@@ -1,12 +1,15 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	stderr "errors"
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/log"
@@ -17,6 +20,10 @@ import (
 
 const HttpHeaderTraceID = "X-OASIS-TRACE-ID"
 
+// HttpHeaderIdempotencyKey is the header a client can set so that
+// retries of the same request are not executed more than once
+const HttpHeaderIdempotencyKey = "Idempotency-Key"
+
 // HttpPreProcessor processes a request and can directly write a response
 // to the writer if required.
 type HttpPreProcessor interface {
@@ -52,6 +59,9 @@ type HttpError struct {
 
 	// StatusCode is the HTTP status code that defines the error cause
 	StatusCode int
+
+	// Headers are set in the response along with the error
+	Headers http.Header
 }
 
 // Log implementation of log.Loggable
@@ -158,7 +168,7 @@ func NewHttpRoute(props HttpRouteProps) *HttpRoute {
 		preProcessors: props.PreProcessors,
 		tracker: stats.NewMethodTrackerWithResult(&stats.MethodTrackerProps{
 			Methods:    methods,
-			Results:    []string{"200", "204", "400", "401", "403", "405", "409", "500", "error", "preprocessor"},
+			Results:    []string{"101", "200", "204", "400", "401", "403", "405", "409", "500", "error", "preprocessor"},
 			WindowSize: 64,
 		}),
 		encoder: props.Encoder,
@@ -197,7 +207,25 @@ func (h *HttpRoute) reportSuccess(
 		return http.StatusNoContent, nil
 	}
 
-	if err := h.encoder.Encode(res, body); err != nil {
+	if stream, ok := body.(Stream); ok {
+		return h.reportStream(res, req, stream)
+	}
+
+	// handlers that need to take over the connection, like the ones
+	// that upgrade it to a WebSocket, return an http.Handler
+	if handler, ok := body.(http.Handler); ok {
+		handler.ServeHTTP(res, req)
+		h.logger.Info(req.Context(), "", log.MapFields{
+			"path":        path,
+			"method":      method,
+			"call_type":   "HttpRequestHandleSuccess",
+			"status_code": http.StatusSwitchingProtocols,
+		})
+		return http.StatusSwitchingProtocols, nil
+	}
+
+	var buffer bytes.Buffer
+	if err := negotiateEncoder(res, req, h.encoder).Encode(&buffer, body); err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
 		h.logger.Warn(req.Context(), "failed to encode response to response writer", log.MapFields{
 			"path":        path,
@@ -209,6 +237,84 @@ func (h *HttpRoute) reportSuccess(
 		return 0, err
 	}
 
+	if err := writeBody(res, req, buffer.Bytes()); err != nil {
+		h.logger.Debug(req.Context(), "failed to write response to response writer", log.MapFields{
+			"path":      path,
+			"method":    method,
+			"call_type": "HttpRequestHandleFailure",
+			"err":       err,
+		})
+		return 0, err
+	}
+
+	h.logger.Info(req.Context(), "", log.MapFields{
+		"path":        path,
+		"method":      method,
+		"call_type":   "HttpRequestHandleSuccess",
+		"status_code": http.StatusOK,
+	})
+
+	return http.StatusOK, nil
+}
+
+// reportStream delivers the events of a stream as server-sent events
+// until the stream terminates or the client goes away
+func (h *HttpRoute) reportStream(
+	res http.ResponseWriter,
+	req *http.Request,
+	stream Stream,
+) (int, error) {
+	path := req.URL.EscapedPath()
+	method := req.Method
+
+	writer, ok := NewHttpEventStreamWriter(res, h.encoder)
+	if !ok {
+		return h.reportError(res, req, HttpInternalServerError(req.Context(),
+			errors.New(errors.ErrHttpStreamingNotSupported, nil)))
+	}
+
+	// a stream remains open for as long as the client is connected, so
+	// the write timeout of the server must not terminate it
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug(req.Context(), "failed to clear write deadline of stream", log.MapFields{
+			"path":      path,
+			"method":    method,
+			"call_type": "HttpRequestStreamFailure",
+			"err":       err.Error(),
+		})
+	}
+
+	ctx := req.Context()
+	if id, ok := parseLastEventID(req); ok {
+		ctx = context.WithValue(ctx, LastEventID{}, id)
+	}
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	writer.flusher.Flush()
+
+	if err := stream.Stream(ctx, writer); err != nil {
+		// the status code has already been sent to the client, so the
+		// only way to report the error is through the stream itself
+		if err, ok := err.(errors.Err); ok {
+			_ = writer.WriteError(Error{
+				ErrorCode:   err.ErrorCode().Code(),
+				Description: err.ErrorCode().Desc(),
+			})
+		}
+
+		h.logger.Info(req.Context(), "stream terminated with error", log.MapFields{
+			"path":        path,
+			"method":      method,
+			"call_type":   "HttpRequestStreamFailure",
+			"status_code": http.StatusOK,
+			"err":         err.Error(),
+		})
+		return http.StatusOK, nil
+	}
+
 	h.logger.Info(req.Context(), "", log.MapFields{
 		"path":        path,
 		"method":      method,
@@ -281,10 +387,18 @@ func (h *HttpRoute) reportError(res http.ResponseWriter, req *http.Request, err
 	method := req.Method
 
 	res.Header().Add(HttpHeaderTraceID, strconv.FormatInt(log.GetTraceID(req.Context()), 10))
+	for key, values := range err.Headers {
+		res.Header()[key] = values
+	}
+
+	encoder := h.encoder
+	if err.Cause != nil {
+		encoder = negotiateEncoder(res, req, h.encoder)
+	}
 	res.WriteHeader(err.StatusCode)
 
 	if err.Cause != nil {
-		if eerr := h.encoder.Encode(res, Error{
+		if eerr := encoder.Encode(res, Error{
 			ErrorCode:   err.Cause.ErrorCode().Code(),
 			Description: err.Cause.ErrorCode().Desc(),
 		}); eerr != nil {
@@ -306,25 +420,68 @@ func (h *HttpRoute) reportError(res http.ResponseWriter, req *http.Request, err
 	return err.StatusCode, nil
 }
 
+// negotiateEncoder returns the Encoder for the response negotiated with
+// the Accept header of the request and sets the Content-Type of the
+// response accordingly. If the client did not ask for any of the supported
+// media types the provided default encoder is returned
+func negotiateEncoder(res http.ResponseWriter, req *http.Request, encoder Encoder) Encoder {
+	negotiated, mediaType, ok := NegotiateEncoder(req.Header.Get("Accept"))
+	if !ok {
+		return encoder
+	}
+
+	res.Header().Set("Content-Type", mediaType)
+	return negotiated
+}
+
+// patternRoute is a route with a template that has path parameters
+type patternRoute struct {
+	pattern routePattern
+	route   *HttpRoute
+}
+
 // HttpRouter multiplexes the handling of server request amongst the different
 // handlers
 type HttpRouter struct {
 	encoder Encoder
-	mux     map[string]*HttpRoute
 	logger  log.Logger
+
+	// mux keeps all the routes by template
+	mux map[string]*HttpRoute
+
+	// patterns are the routes with path parameters sorted
+	// so that the most specific ones are tried first
+	patterns []patternRoute
+}
+
+// lookup returns the route that handles a request to the escaped path
+// along with its template and the parameters parsed from the path. The
+// routes without parameters take precedence over the ones with them
+func (h *HttpRouter) lookup(path string) (*HttpRoute, string, map[string]string, bool) {
+	if route, ok := h.mux[path]; ok {
+		return route, path, nil, true
+	}
+
+	for _, p := range h.patterns {
+		if params, ok := p.pattern.match(path); ok {
+			return p.route, p.pattern.template, params, true
+		}
+	}
+
+	return nil, "", nil, false
 }
 
 // HasRoute returns true if the router has a route to
 // handle a request to the path
 func (h *HttpRouter) HasRoute(path string) bool {
-	_, ok := h.mux[path]
+	_, _, _, ok := h.lookup(path)
 	return ok
 }
 
 // HasHandler returns true if the router has a handle to
 // handle a request to the path and method
 func (h *HttpRouter) HasHandler(path, method string) bool {
-	route, ok := h.mux[path]
+	route, _, _, ok := h.lookup(path)
 	if !ok {
 		return false
 	}
@@ -349,6 +506,10 @@ func (h *HttpRouter) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	traceID := ParseTraceID(req.Header.Get(HttpHeaderTraceID))
 	req = req.WithContext(context.WithValue(req.Context(), log.ContextKeyTraceID, traceID))
 
+	if key := req.Header.Get(HttpHeaderIdempotencyKey); len(key) > 0 {
+		req = req.WithContext(context.WithValue(req.Context(), IdempotencyKey{}, key))
+	}
+
 	h.logger.Debug(req.Context(), "", log.MapFields{
 		"path":      path,
 		"method":    method,
@@ -384,12 +545,37 @@ func (h *HttpRouter) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 		}
 	}()
 
-	route, ok := h.mux[path]
+	route, template, params, ok := h.lookup(path)
 	if !ok {
 		h.reportError(res, req, &HttpError{StatusCode: http.StatusNotFound})
 		return
 	}
 
+	ctx := context.WithValue(req.Context(), RouteTemplate{}, template)
+	ctx = context.WithValue(ctx, RemoteAddr{}, req.RemoteAddr)
+	if extend := newWriteDeadlineExtender(res, req); extend != nil {
+		ctx = context.WithValue(ctx, WriteDeadline{}, func(wait time.Duration) {
+			if err := extend(wait); err != nil {
+				h.logger.Debug(req.Context(), "failed to extend write deadline", log.MapFields{
+					"path":      path,
+					"method":    method,
+					"call_type": "HttpRequestHandleFailure",
+					"err":       err.Error(),
+				})
+			}
+		})
+	}
+	if params != nil {
+		ctx = context.WithValue(ctx, PathParams{}, params)
+	}
+	req = req.WithContext(ctx)
+
+	req, err := decompressRequest(req)
+	if err != nil {
+		h.reportAnyError(res, req, err)
+		return
+	}
+
 	route.ServeHTTP(res, req)
 }
 
@@ -457,10 +643,18 @@ func (h *HttpRouter) reportError(res http.ResponseWriter, req *http.Request, err
 	method := req.Method
 
 	res.Header().Add(HttpHeaderTraceID, strconv.FormatInt(log.GetTraceID(req.Context()), 10))
+	for key, values := range err.Headers {
+		res.Header()[key] = values
+	}
+
+	encoder := h.encoder
+	if err.Cause != nil {
+		encoder = negotiateEncoder(res, req, h.encoder)
+	}
 	res.WriteHeader(err.StatusCode)
 
 	if err.Cause != nil {
-		if eerr := h.encoder.Encode(res, Error{
+		if eerr := encoder.Encode(res, Error{
 			ErrorCode:   err.Cause.ErrorCode().Code(),
 			Description: err.Cause.ErrorCode().Desc(),
 		}); eerr != nil {
@@ -571,13 +765,16 @@ func (h *HttpCorsPreProcessor) ServeHTTP(w http.ResponseWriter, req *http.Reques
 }
 
 // HttpJsonHandler handles requests that expect a body in the JSON format,
-// handles the body and executes the final handler with the expected type
+// handles the body and executes the final handler with the expected type.
+// Bodies can also be sent in any of the binary formats negotiated with
+// NegotiateDecoder, which are decoded as the equivalent JSON would be
 type HttpJsonHandler struct {
 	limit   uint
 	decoder JsonDecoder
 	handler Handler
 	logger  log.Logger
 	factory EntityFactory
+	limiter OperationLimiter
 }
 
 type HttpJsonHandlerProperties struct {
@@ -594,6 +791,10 @@ type HttpJsonHandlerProperties struct {
 	// Factory for creating new instances of objects to which the Http body
 	// will be deserialized. Those instances will be passed to the handler
 	Factory EntityFactory
+
+	// Limiter if set limits the rate at which the operations in the
+	// requests are dispatched to the handler
+	Limiter OperationLimiter
 }
 
 // NewHttpJsonHandlerFactory creates a new instance of an rpc handler
@@ -624,6 +825,7 @@ func NewHttpJsonHandler(properties HttpJsonHandlerProperties) *HttpJsonHandler {
 		handler: properties.Handler,
 		logger:  properties.Logger.ForClass("http", "HttpJsonHandler"),
 		factory: properties.Factory,
+		limiter: properties.Limiter,
 	}
 }
 
@@ -650,10 +852,10 @@ func (h *HttpJsonHandler) ServeHTTP(req *http.Request) (interface{}, error) {
 		return nil, errors.New(errors.ErrHttpContentLengthLimit, nil)
 	}
 
-	// verify that content type is set and it is correct
+	// verify that content type is set and it is supported
 	contentType := req.Header.Get("Content-type")
-	if req.ContentLength > 0 && contentType != "application/json" {
-		h.logger.Debug(req.Context(), "Content-type is not for json", log.MapFields{
+	if req.ContentLength > 0 && len(contentType) == 0 {
+		h.logger.Debug(req.Context(), "Content-type header missing from request", log.MapFields{
 			"path":           req.URL.EscapedPath(),
 			"method":         req.Method,
 			"content_length": req.ContentLength,
@@ -662,6 +864,22 @@ func (h *HttpJsonHandler) ServeHTTP(req *http.Request) (interface{}, error) {
 		return nil, errors.New(errors.ErrHttpContentTypeApplicationJson, nil)
 	}
 
+	var decoder LimitDecoder = h.decoder
+	if req.ContentLength > 0 {
+		d, ok := NegotiateDecoder(contentType)
+		if !ok {
+			h.logger.Debug(req.Context(), "Content-type is not supported", log.MapFields{
+				"path":           req.URL.EscapedPath(),
+				"method":         req.Method,
+				"content_length": req.ContentLength,
+				"content_type":   contentType,
+				"call_type":      "HttpJsonRequestHandleFailure",
+			})
+			return nil, errors.New(errors.ErrHttpContentTypeNotSupported, nil)
+		}
+		decoder = d
+	}
+
 	// parse body into Go object
 	body := h.factory.Create()
 	if body == nil && req.ContentLength > 0 {
@@ -674,22 +892,54 @@ func (h *HttpJsonHandler) ServeHTTP(req *http.Request) (interface{}, error) {
 		return nil, errors.New(errors.ErrDeserializeJSON, nil)
 	}
 
+	// the limit of a compressed body applies to the decompressed size,
+	// which is only known once it is read
+	readLimit := req.ContentLength
+	if len(GetContentEncoding(req.Context())) > 0 {
+		readLimit = int64(h.limit)
+	}
+
 	if body != nil && req.ContentLength > 0 {
-		if err := h.decoder.DecodeWithLimit(req.Body, body, rw.ReadLimitProps{
-			Limit:        req.ContentLength,
+		if err := decoder.DecodeWithLimit(req.Body, body, rw.ReadLimitProps{
+			Limit:        readLimit,
 			FailOnExceed: true,
-		}); err != nil {
-			h.logger.Debug(req.Context(), "failed to decode json", log.MapFields{
+		}); err == rw.ErrLimitExceeded {
+			h.logger.Debug(req.Context(), "body exceeds request limit", log.MapFields{
 				"path":           req.URL.EscapedPath(),
 				"method":         req.Method,
 				"content_length": req.ContentLength,
+				"limit":          h.limit,
+				"call_type":      "HttpJsonRequestHandleFailure",
+			})
+			return nil, errors.New(errors.ErrHttpContentLengthLimit, nil)
+		} else if err != nil {
+			h.logger.Debug(req.Context(), "failed to decode body", log.MapFields{
+				"path":           req.URL.EscapedPath(),
+				"method":         req.Method,
+				"content_length": req.ContentLength,
+				"content_type":   contentType,
 				"call_type":      "HttpJsonRequestHandleFailure",
 				"err":            err.Error(),
 			})
-			return nil, errors.New(errors.ErrDeserializeJSON, nil)
+
+			if _, ok := decoder.(JsonDecoder); ok {
+				return nil, errors.New(errors.ErrDeserializeJSON, nil)
+			}
+			return nil, errors.New(errors.ErrDeserializeBody, nil)
 		}
 	}
 
+	// the routes with path parameters are limited by
+	// their template rather than by each of their paths
+	route := GetRouteTemplate(req.Context())
+	if len(route) == 0 {
+		route = req.URL.EscapedPath()
+	}
+
+	if err := allowOperations(req.Context(), h.limiter, route, body); err != nil {
+		return nil, err
+	}
+
 	// provide the parsed body to the handler and handle execution
 	return h.handler.Handle(req.Context(), body)
 }
@@ -713,14 +963,25 @@ func (f HttpHandlerFactoryFunc) Make(factory EntityFactory, handler Handler) Htt
 // after it has been created
 type HttpBinder struct {
 	handlers      map[string]MethodHandlers
+	routes        map[string]RouteDescription
 	preProcessors []HttpPreProcessor
 	encoder       Encoder
 	logger        log.Logger
 	factory       HttpHandlerFactory
+	openAPI       HttpOpenAPIProps
 }
 
-// Bind is the implementation of HandlerBinder for HttpBinder
+// Bind is the implementation of HandlerBinder for HttpBinder. The uri
+// is the template of the route, in which the segments enclosed in
+// braces are path parameters, as in "/service/{address}/code". The
+// parameters are set in the context of the request
 func (b *HttpBinder) Bind(method string, uri string, handler Handler, factory EntityFactory) {
+	if hasPathParams(uri) {
+		if _, err := parseRoutePattern(uri); err != nil {
+			panic(fmt.Sprintf("invalid route template %s: %s", uri, err.Error()))
+		}
+	}
+
 	route, ok := b.handlers[uri]
 	if !ok {
 		route = make(MethodHandlers)
@@ -728,6 +989,25 @@ func (b *HttpBinder) Bind(method string, uri string, handler Handler, factory En
 	}
 
 	route.Add(method, b.factory.Make(factory, handler))
+	b.routes[method+" "+uri] = describeRoute(method, uri, handler, factory)
+}
+
+// Routes returns the description of the routes that have been bound
+// sorted by path and method
+func (b *HttpBinder) Routes() []RouteDescription {
+	routes := make([]RouteDescription, 0, len(b.routes))
+	for _, route := range b.routes {
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
 }
 
 func (b *HttpBinder) AddPreProcessor(preProcessor HttpPreProcessor) {
@@ -739,6 +1019,17 @@ func (b *HttpBinder) AddPreProcessor(preProcessor HttpPreProcessor) {
 // Bind needs to be used again
 func (b *HttpBinder) Build() *HttpRouter {
 	mux := make(map[string]*HttpRoute)
+	var patterns []patternRoute
+	shapes := make(map[string]string)
+
+	if len(b.openAPI.Path) > 0 {
+		// the document is served without going through the handler
+		// factory so that it can be retrieved without authentication
+		document := NewOpenAPIDocument(b.openAPI.Info, b.Routes())
+		b.handlers[b.openAPI.Path] = MethodHandlers{
+			http.MethodGet: openAPIHandler{document: document},
+		}
+	}
 
 	for path, handlers := range b.handlers {
 		route := NewHttpRoute(HttpRouteProps{
@@ -749,16 +1040,35 @@ func (b *HttpBinder) Build() *HttpRouter {
 		})
 
 		mux[path] = route
+
+		if !hasPathParams(path) {
+			continue
+		}
+
+		// the pattern has already been validated when bound
+		pattern, _ := parseRoutePattern(path)
+		if other, ok := shapes[pattern.shape()]; ok {
+			panic(fmt.Sprintf("route template %s conflicts with %s", path, other))
+		}
+
+		shapes[pattern.shape()] = path
+		patterns = append(patterns, patternRoute{pattern: pattern, route: route})
 	}
 
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].pattern.less(patterns[j].pattern)
+	})
+
 	// avoid modification of the router handlers after the router
 	// handler has been created
 	b.handlers = make(map[string]MethodHandlers)
+	b.routes = make(map[string]RouteDescription)
 
 	return &HttpRouter{
-		encoder: b.encoder,
-		logger:  b.logger.ForClass("http", "router"),
-		mux:     mux,
+		encoder:  b.encoder,
+		logger:   b.logger.ForClass("http", "router"),
+		mux:      mux,
+		patterns: patterns,
 	}
 }
 
@@ -768,6 +1078,21 @@ type HttpBinderProperties struct {
 	Encoder        Encoder
 	Logger         log.Logger
 	HandlerFactory HttpHandlerFactory
+
+	// OpenAPI configures the OpenAPI document that describes
+	// the routes of the router
+	OpenAPI HttpOpenAPIProps
+}
+
+// HttpOpenAPIProps are the properties of the OpenAPI document
+// generated by an HttpBinder
+type HttpOpenAPIProps struct {
+	// Path at which the document is served. If it is empty
+	// the document is not served
+	Path string
+
+	// Info is the metadata of the API included in the document
+	Info OpenAPIInfo
 }
 
 // NewHttpBinder creates a new instance of the HttpBinder. It will
@@ -787,8 +1112,10 @@ func NewHttpBinder(properties HttpBinderProperties) *HttpBinder {
 
 	return &HttpBinder{
 		handlers: make(map[string]MethodHandlers),
+		routes:   make(map[string]RouteDescription),
 		encoder:  properties.Encoder,
 		logger:   properties.Logger,
 		factory:  properties.HandlerFactory,
+		openAPI:  properties.OpenAPI,
 	}
 }
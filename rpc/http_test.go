@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/log"
@@ -57,6 +58,14 @@ func (m HttpMiddlewarePanic) ServeHTTP(req *http.Request) (interface{}, error) {
 	panic("error")
 }
 
+type StreamEvent struct {
+	ID uint64 `json:"id"`
+}
+
+func (e StreamEvent) EventID() uint64 {
+	return e.ID
+}
+
 type ErrEncoder struct{}
 
 func (e ErrEncoder) Encode(w io.Writer, v interface{}) error {
@@ -73,6 +82,68 @@ func setupRouter() *HttpRouter {
 		"/panic": map[string]HttpMiddleware{
 			"GET": HttpMiddlewarePanic{},
 		},
+		"/stream": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareOK{body: StreamFunc(func(ctx context.Context, w StreamWriter) error {
+				for i := uint64(0); i < 2; i++ {
+					if err := w.Write(StreamEvent{ID: i}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})},
+			"POST": HttpMiddlewareOK{body: StreamFunc(func(ctx context.Context, w StreamWriter) error {
+				return errors.New(errors.ErrQueueRetrieve, nil)
+			})},
+		},
+		"/resume": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareOK{body: StreamFunc(func(ctx context.Context, w StreamWriter) error {
+				offset := ResumeStream(ctx, w, 0)
+				for i := offset; i < offset+3; i++ {
+					time.Sleep(30 * time.Millisecond)
+					if err := w.Write(StreamEvent{ID: i}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})},
+		},
+		"/unordered": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareOK{body: StreamFunc(func(ctx context.Context, w StreamWriter) error {
+				offset := ResumeStream(ctx, w, 0)
+				for _, i := range []uint64{offset + 1, offset, offset + 2} {
+					if err := w.Write(StreamEvent{ID: i}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})},
+		},
+		"/wait": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareFunc(func(req *http.Request) (interface{}, error) {
+				ExtendWriteDeadline(req.Context(), 50*time.Millisecond)
+				time.Sleep(50 * time.Millisecond)
+				return map[string]string{"result": "ok"}, nil
+			}),
+			"POST": HttpMiddlewareFunc(func(req *http.Request) (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+				return map[string]string{"result": "ok"}, nil
+			}),
+		},
+		"/limited": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareFunc(func(req *http.Request) (interface{}, error) {
+				err := HttpTooManyRequests(req.Context(), errors.New(errors.ErrRateLimitExceeded, nil))
+				err.Headers = http.Header{"Retry-After": {"2"}}
+				return nil, err
+			}),
+		},
+		"/upgrade": map[string]HttpMiddleware{
+			"GET": HttpMiddlewareOK{body: http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusSwitchingProtocols)
+			})},
+		},
 	}
 
 	mux := make(map[string]*HttpRoute)
@@ -151,6 +222,200 @@ func TestHttpRouterServeHTTPOKWithBody(t *testing.T) {
 	assert.Equal(t, "{\"result\":\"ok\"}\n", string(s))
 }
 
+func TestHttpRouterServeHTTPOKWithBodyAccept(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/path", nil)
+	req.Header.Add("Accept", "application/cbor")
+
+	router.ServeHTTP(recorder, req)
+
+	var m map[string]string
+	assert.Nil(t, CborDecoder{}.Decode(recorder.Body, &m))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, MediaTypeCbor, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, map[string]string{"result": "ok"}, m)
+}
+
+func TestHttpRouterServeHTTPStreamOK(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "id: 0\ndata: {\"id\":0}\n\nid: 1\ndata: {\"id\":1}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPStreamPastWriteTimeout(t *testing.T) {
+	server := httptest.NewUnstartedServer(setupRouter())
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/resume")
+	assert.Nil(t, err)
+	defer res.Body.Close()
+
+	s, err := ioutil.ReadAll(res.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "id: 0\ndata: {\"id\":0}\n\nid: 1\ndata: {\"id\":1}\n\n"+
+		"id: 2\ndata: {\"id\":2}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPExtendWriteDeadline(t *testing.T) {
+	server := httptest.NewUnstartedServer(setupRouter())
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/wait")
+	assert.Nil(t, err)
+	defer res.Body.Close()
+
+	s, err := ioutil.ReadAll(res.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "{\"result\":\"ok\"}\n", string(s))
+}
+
+func TestHttpRouterServeHTTPWriteTimeout(t *testing.T) {
+	server := httptest.NewUnstartedServer(setupRouter())
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	res, err := http.Post(server.URL+"/wait", "application/json", nil)
+	if err == nil {
+		_, err = ioutil.ReadAll(res.Body)
+		res.Body.Close()
+	}
+
+	assert.Error(t, err)
+}
+
+func TestExtendWriteDeadlineNoDeadline(t *testing.T) {
+	assert.NotPanics(t, func() {
+		ExtendWriteDeadline(context.Background(), time.Second)
+	})
+}
+
+func TestHttpRouterServeHTTPStreamLastEventID(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/resume", nil)
+	req.Header.Set(HttpHeaderLastEventID, "4")
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "id: 5\ndata: {\"id\":5}\n\nid: 6\ndata: {\"id\":6}\n\n"+
+		"id: 7\ndata: {\"id\":7}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPStreamUnordered(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/unordered", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	// the ID of each message is the last event up to which all
+	// the events have been delivered
+	assert.Nil(t, err)
+	assert.Equal(t, "data: {\"id\":1}\n\nid: 1\ndata: {\"id\":0}\n\n"+
+		"id: 2\ndata: {\"id\":2}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPStreamUnorderedLastEventID(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/unordered", nil)
+	req.Header.Set(HttpHeaderLastEventID, "4")
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id: 4\ndata: {\"id\":6}\n\nid: 6\ndata: {\"id\":5}\n\n"+
+		"id: 7\ndata: {\"id\":7}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPStreamInvalidLastEventID(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/resume", nil)
+	req.Header.Set(HttpHeaderLastEventID, "invalid")
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id: 0\ndata: {\"id\":0}\n\nid: 1\ndata: {\"id\":1}\n\n"+
+		"id: 2\ndata: {\"id\":2}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPHandler(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/upgrade", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusSwitchingProtocols, recorder.Code)
+}
+
+func TestHttpRouterServeHTTPStreamErr(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/stream", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	s, err := ioutil.ReadAll(recorder.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "event: error\ndata: {\"errorCode\":1028,\"description\":\"Internal Error. Please check the status of the service.\"}\n\n", string(s))
+}
+
+func TestHttpRouterServeHTTPErrHeaders(t *testing.T) {
+	router := setupRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/limited", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+	assert.Equal(t, "{\"errorCode\":3003,\"description\":\"Too many requests. "+
+		"Retry after the time set in the Retry-After header.\"}\n", recorder.Body.String())
+}
+
 func TestHttpRouterServeHTTPPanic(t *testing.T) {
 	router := setupRouter()
 
@@ -245,6 +510,108 @@ func TestHttpBinderBuildRouter(t *testing.T) {
 	assert.Equal(t, "", string(s))
 }
 
+func setupPatternRouter() *HttpRouter {
+	binder := NewHttpBinder(HttpBinderProperties{
+		Encoder:        JsonEncoder{},
+		Logger:         logger,
+		HandlerFactory: HttpHandlerFactoryFunc(simpleHandlerFactory),
+	})
+
+	params := HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return map[string]interface{}{
+			"route":  GetRouteTemplate(ctx),
+			"params": GetPathParams(ctx),
+		}, nil
+	})
+
+	binder.Bind("GET", "/service/{address}/code", params, nil)
+	binder.Bind("GET", "/event/subscriptions/{id}", params, nil)
+	binder.Bind("GET", "/event/subscriptions/list", params, nil)
+	return binder.Build()
+}
+
+func TestHttpRouterServeHTTPPathParams(t *testing.T) {
+	router := setupPatternRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/service/0x00/code", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "{\"params\":{\"address\":\"0x00\"},\"route\":\"/service/{address}/code\"}\n",
+		recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPPathParamsStaticFirst(t *testing.T) {
+	router := setupPatternRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/event/subscriptions/list", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "{\"params\":null,\"route\":\"/event/subscriptions/list\"}\n",
+		recorder.Body.String())
+}
+
+func TestHttpRouterServeHTTPPathParamsNoRoute(t *testing.T) {
+	router := setupPatternRouter()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/service/0x00/stream", nil)
+
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.True(t, router.HasHandler("/service/0x00/code", "GET"))
+	assert.False(t, router.HasHandler("/service/0x00/code", "POST"))
+	assert.False(t, router.HasRoute("/service/0x00"))
+}
+
+func TestHttpRouterStatsRouteTemplate(t *testing.T) {
+	router := setupPatternRouter()
+
+	for _, path := range []string{"/service/0x00/code", "/service/0x01/code"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	metrics := router.Stats()
+	_, ok := metrics["/service/{address}/code"]
+	assert.True(t, ok)
+	_, ok = metrics["/service/0x00/code"]
+	assert.False(t, ok)
+}
+
+func TestHttpBinderBindInvalidTemplate(t *testing.T) {
+	binder := NewHttpBinder(HttpBinderProperties{
+		Encoder:        JsonEncoder{},
+		Logger:         logger,
+		HandlerFactory: HttpHandlerFactoryFunc(simpleHandlerFactory),
+	})
+
+	assert.Panics(t, func() {
+		binder.Bind("GET", "/service/{id}/{id}", HandlerEcho{}, nil)
+	})
+}
+
+func TestHttpBinderBuildConflictingTemplates(t *testing.T) {
+	binder := NewHttpBinder(HttpBinderProperties{
+		Encoder:        JsonEncoder{},
+		Logger:         logger,
+		HandlerFactory: HttpHandlerFactoryFunc(simpleHandlerFactory),
+	})
+
+	binder.Bind("GET", "/service/{address}", HandlerEcho{}, nil)
+	binder.Bind("POST", "/service/{id}", HandlerEcho{}, nil)
+
+	assert.Panics(t, func() {
+		binder.Build()
+	})
+}
+
 func TestHttpJsonHandlerContentLengthMissing(t *testing.T) {
 	handler := NewHttpJsonHandler(HttpJsonHandlerProperties{
 		Limit:   1024,
@@ -333,6 +700,64 @@ func TestHttpJsonHandlerOK(t *testing.T) {
 	assert.Equal(t, map[string]string{"hamburger": "rare", "potato": "fried"}, m)
 }
 
+func TestHttpJsonHandlerContentTypeNotSupported(t *testing.T) {
+	handler := NewHttpJsonHandler(HttpJsonHandlerProperties{
+		Limit:   1024,
+		Handler: HandlerEcho{},
+		Logger:  logger,
+		Factory: mapEntityFactory(),
+	})
+
+	req, _ := http.NewRequest("GET", "/path", bytes.NewBufferString("hamburger"))
+	req.ContentLength = 9
+	req.Header.Add("Content-type", "text/plain")
+
+	v, err := handler.ServeHTTP(req)
+
+	assert.Equal(t, errors.ErrHttpContentTypeNotSupported, err.(errors.Err).ErrorCode())
+	assert.Nil(t, v)
+}
+
+func TestHttpJsonHandlerMsgpackOK(t *testing.T) {
+	handler := NewHttpJsonHandler(HttpJsonHandlerProperties{
+		Limit:   1024,
+		Handler: HandlerEcho{},
+		Logger:  logger,
+		Factory: mapEntityFactory(),
+	})
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(t, MsgpackEncoder{}.Encode(buffer, map[string]string{"hamburger": "rare", "potato": "fried"}))
+	length := buffer.Len()
+
+	req, _ := http.NewRequest("GET", "/path", buffer)
+	req.ContentLength = int64(length)
+	req.Header.Add("Content-type", "application/msgpack")
+
+	v, err := handler.ServeHTTP(req)
+	m := *v.(*map[string]string)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"hamburger": "rare", "potato": "fried"}, m)
+}
+
+func TestHttpJsonHandlerCborErr(t *testing.T) {
+	handler := NewHttpJsonHandler(HttpJsonHandlerProperties{
+		Limit:   1024,
+		Handler: HandlerEcho{},
+		Logger:  logger,
+		Factory: mapEntityFactory(),
+	})
+
+	req, _ := http.NewRequest("GET", "/path", bytes.NewBufferString("\xff"))
+	req.ContentLength = 1
+	req.Header.Add("Content-type", "application/cbor")
+
+	v, err := handler.ServeHTTP(req)
+
+	assert.Equal(t, errors.ErrDeserializeBody, err.(errors.Err).ErrorCode())
+	assert.Nil(t, v)
+}
+
 func TestHttpErrorError(t *testing.T) {
 	e := errors.New(errors.ErrInternalError, nil)
 	err := HttpError{Cause: &e, StatusCode: 400}
@@ -0,0 +1,315 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+)
+
+const (
+	// JsonRpcVersion is the version of the JSON-RPC protocol
+	// supported by the JsonRpcHandler
+	JsonRpcVersion = "2.0"
+
+	// JsonRpcErrInvalidRequest is the JSON-RPC error code returned when
+	// the payload received is not a valid request object
+	JsonRpcErrInvalidRequest = -32600
+
+	// JsonRpcErrMethodNotFound is the JSON-RPC error code returned when
+	// no handler is bound for the method of the request
+	JsonRpcErrMethodNotFound = -32601
+
+	// JsonRpcErrInvalidParams is the JSON-RPC error code returned when
+	// the params of the request cannot be decoded
+	JsonRpcErrInvalidParams = -32602
+
+	// JsonRpcErrInternal is the JSON-RPC error code returned when the
+	// request fails with an unexpected error
+	JsonRpcErrInternal = -32603
+)
+
+// JsonRpcRequest is a JSON-RPC 2.0 request object
+type JsonRpcRequest struct {
+	// JsonRpc is the version of the protocol, which must be 2.0
+	JsonRpc string `json:"jsonrpc"`
+
+	// Method is the name of the method to call
+	Method string `json:"method"`
+
+	// Params is the payload of the request. Only params by name are
+	// supported, since they are decoded as the body of an HTTP request
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// ID is chosen by the client to match the response with the request.
+	// Requests without an ID are notifications and get no response
+	ID json.RawMessage `json:"id,omitempty"`
+}
+
+// JsonRpcError is the error object of a JSON-RPC response
+type JsonRpcError struct {
+	// Code is the error code. Errors returned by the handlers have
+	// the errors.ErrorCode of the error as code
+	Code int `json:"code"`
+
+	// Message is a short description of the error
+	Message string `json:"message"`
+}
+
+// JsonRpcResponse is a JSON-RPC 2.0 response object
+type JsonRpcResponse struct {
+	// JsonRpc is the version of the protocol
+	JsonRpc string `json:"jsonrpc"`
+
+	// Result is the response of the handler in case of success
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error is set in case the request failed
+	Error *JsonRpcError `json:"error,omitempty"`
+
+	// ID of the request the response refers to
+	ID json.RawMessage `json:"id"`
+}
+
+type jsonRpcRoute struct {
+	path    string
+	handler Handler
+	factory EntityFactory
+}
+
+// JsonRpcPayload is the body expected by a JsonRpcHandler. The payload
+// does not count as an operation, since each of the requests it carries
+// is counted when it is dispatched to its handler
+type JsonRpcPayload struct {
+	json.RawMessage
+}
+
+// OperationCount is the implementation of OperationCounter for JsonRpcPayload
+func (p JsonRpcPayload) OperationCount() int {
+	return 0
+}
+
+// JsonRpcBinder is the binder for JSON-RPC requests. The handlers bound to
+// it are reached through a single route with the method name derived from
+// the path they are bound to, so that /service/deploy is reached with the
+// method service_deploy. Only the routes bound to the POST method are
+// served, since JSON-RPC cannot deliver streams of events
+type JsonRpcBinder struct {
+	routes  map[string]jsonRpcRoute
+	logger  log.Logger
+	limiter OperationLimiter
+}
+
+// Bind is the implementation of HandlerBinder for JsonRpcBinder
+func (b *JsonRpcBinder) Bind(method string, path string, handler Handler, factory EntityFactory) {
+	if method != "POST" {
+		return
+	}
+
+	b.routes[JsonRpcMethod(path)] = jsonRpcRoute{path: path, handler: handler, factory: factory}
+}
+
+// Build creates a new JsonRpcHandler with the routes that have been
+// bound and clears the routes of the binder
+func (b *JsonRpcBinder) Build() *JsonRpcHandler {
+	routes := b.routes
+	b.routes = make(map[string]jsonRpcRoute)
+
+	return &JsonRpcHandler{
+		routes:  routes,
+		logger:  b.logger.ForClass("jsonrpc", "handler"),
+		limiter: b.limiter,
+	}
+}
+
+// JsonRpcBinderProperties are the properties used to create
+// a new instance of a JsonRpcBinder
+type JsonRpcBinderProperties struct {
+	// Logger
+	Logger log.Logger
+
+	// Limiter if set limits the rate at which the requests, including
+	// each of the requests of a batch, are dispatched to the handlers
+	Limiter OperationLimiter
+}
+
+// NewJsonRpcBinder creates a new instance of a JsonRpcBinder. It will
+// panic in case there are errors in the construction of the binder
+func NewJsonRpcBinder(properties JsonRpcBinderProperties) *JsonRpcBinder {
+	if properties.Logger == nil {
+		panic("Logger must be set")
+	}
+
+	return &JsonRpcBinder{
+		routes:  make(map[string]jsonRpcRoute),
+		logger:  properties.Logger,
+		limiter: properties.Limiter,
+	}
+}
+
+// JsonRpcMethod returns the name of the JSON-RPC method that
+// reaches the handler bound to the provided path
+func JsonRpcMethod(path string) string {
+	return strings.Replace(strings.Trim(path, "/"), "/", "_", -1)
+}
+
+// JsonRpcHandler is an rpc Handler that dispatches JSON-RPC 2.0 requests
+// and batches of requests to the bound handlers. It expects a
+// *JsonRpcPayload as body, so that it can be served by an HttpJsonHandler
+// with the same body limits and authentication as any other route, which
+// also means that a payload that is not valid JSON is rejected before it
+// reaches the handler. The context of the HTTP request is the context
+// used for all the requests of a batch
+type JsonRpcHandler struct {
+	routes  map[string]jsonRpcRoute
+	logger  log.Logger
+	limiter OperationLimiter
+}
+
+// Handle is the implementation of Handler for JsonRpcHandler. It returns
+// a JsonRpcResponse for a single request and a slice of them for a batch.
+// If no response needs to be sent the returned value is nil
+func (h *JsonRpcHandler) Handle(ctx context.Context, v interface{}) (interface{}, error) {
+	payload := bytes.TrimSpace(v.(*JsonRpcPayload).RawMessage)
+	if len(payload) == 0 || payload[0] != '[' {
+		res, ok := h.handle(ctx, payload)
+		if !ok {
+			return nil, nil
+		}
+
+		return res, nil
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(payload, &batch); err != nil || len(batch) == 0 {
+		return makeJsonRpcErrorResponse(nil, JsonRpcErrInvalidRequest, "Invalid Request"), nil
+	}
+
+	// as with ExecuteServiceBatch, each of the requests of a batch gets
+	// its own idempotency key derived from the key of the HTTP request
+	key := GetIdempotencyKey(ctx)
+
+	responses := make([]JsonRpcResponse, 0, len(batch))
+	for i, req := range batch {
+		reqCtx := ctx
+		if len(key) > 0 {
+			reqCtx = context.WithValue(ctx, IdempotencyKey{}, fmt.Sprintf("%s:%d", key, i))
+		}
+
+		if res, ok := h.handle(reqCtx, req); ok {
+			responses = append(responses, res)
+		}
+	}
+
+	if len(responses) == 0 {
+		return nil, nil
+	}
+
+	return responses, nil
+}
+
+// HasMethod returns true if the handler would dispatch requests
+// for the provided JSON-RPC method
+func (h *JsonRpcHandler) HasMethod(method string) bool {
+	_, ok := h.routes[method]
+	return ok
+}
+
+// handle serves a single request. It returns false if the request
+// is a notification and no response has to be sent
+func (h *JsonRpcHandler) handle(ctx context.Context, payload json.RawMessage) (res JsonRpcResponse, ok bool) {
+	var req JsonRpcRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return makeJsonRpcErrorResponse(nil, JsonRpcErrInvalidRequest, "Invalid Request"), true
+	}
+
+	if req.JsonRpc != JsonRpcVersion || len(req.Method) == 0 {
+		return makeJsonRpcErrorResponse(req.ID, JsonRpcErrInvalidRequest, "Invalid Request"), true
+	}
+
+	result, err := h.call(ctx, req)
+	if len(req.ID) == 0 {
+		return JsonRpcResponse{}, false
+	}
+
+	if err != nil {
+		return JsonRpcResponse{JsonRpc: JsonRpcVersion, Error: err, ID: req.ID}, true
+	}
+
+	return JsonRpcResponse{JsonRpc: JsonRpcVersion, Result: result, ID: req.ID}, true
+}
+
+func (h *JsonRpcHandler) call(ctx context.Context, req JsonRpcRequest) (result json.RawMessage, rpcErr *JsonRpcError) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Warn(ctx, "unexpected panic caught", log.MapFields{
+				"call_type": "JsonRpcRequestHandleFailure",
+				"method":    req.Method,
+				"err":       fmt.Sprintf("%+v", r),
+			})
+			rpcErr = makeJsonRpcError(errors.New(errors.ErrInternalError, nil))
+		}
+	}()
+
+	route, ok := h.routes[req.Method]
+	if !ok {
+		return nil, &JsonRpcError{Code: JsonRpcErrMethodNotFound, Message: "Method not found"}
+	}
+
+	body := route.factory.Create()
+	hasParams := len(req.Params) > 0 && string(req.Params) != "null"
+	if body == nil && hasParams {
+		return nil, &JsonRpcError{Code: JsonRpcErrInvalidParams, Message: "Invalid params"}
+	}
+
+	if body != nil && hasParams {
+		if err := json.Unmarshal(req.Params, body); err != nil {
+			return nil, &JsonRpcError{Code: JsonRpcErrInvalidParams, Message: "Invalid params"}
+		}
+	}
+
+	if err := allowOperations(ctx, h.limiter, route.path, body); err != nil {
+		return nil, makeJsonRpcError(err)
+	}
+
+	v, err := route.handler.Handle(ctx, body)
+	if err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "JsonRpcRequestHandleFailure",
+			"method":    req.Method,
+			"err":       err.Error(),
+		})
+		return nil, makeJsonRpcError(err)
+	}
+
+	p, err := json.Marshal(v)
+	if err != nil {
+		return nil, &JsonRpcError{Code: JsonRpcErrInternal, Message: "Internal error"}
+	}
+
+	return p, nil
+}
+
+// makeJsonRpcError converts an error returned by a handler into the
+// JsonRpcError that is sent to the client. The code of the error is
+// the errors.ErrorCode of the error, as it would be over HTTP
+func makeJsonRpcError(err error) *JsonRpcError {
+	e := makeError(err)
+	return &JsonRpcError{Code: e.ErrorCode, Message: e.Description}
+}
+
+func makeJsonRpcErrorResponse(id json.RawMessage, code int, message string) JsonRpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	return JsonRpcResponse{
+		JsonRpc: JsonRpcVersion,
+		Error:   &JsonRpcError{Code: code, Message: message},
+		ID:      id,
+	}
+}
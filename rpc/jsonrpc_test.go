@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJsonRpc() *JsonRpcHandler {
+	binder := NewJsonRpcBinder(JsonRpcBinderProperties{Logger: logger})
+	binder.Bind("POST", "/service/echo", HandlerEcho{}, mapEntityFactory())
+	binder.Bind("POST", "/service/err", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return nil, errors.New(errors.ErrQueueRetrieve, nil)
+	}), mapEntityFactory())
+	binder.Bind("POST", "/service/key", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return GetIdempotencyKey(ctx), nil
+	}), EntityFactoryFunc(func() interface{} { return nil }))
+	binder.Bind("POST", "/service/panic", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		panic("error")
+	}), EntityFactoryFunc(func() interface{} { return nil }))
+
+	return binder.Build()
+}
+
+func handleJsonRpc(t *testing.T, ctx context.Context, payload string) interface{} {
+	v, err := setupJsonRpc().Handle(ctx, &JsonRpcPayload{RawMessage: json.RawMessage(payload)})
+	assert.Nil(t, err)
+	if v == nil {
+		return nil
+	}
+
+	p, err := json.Marshal(v)
+	assert.Nil(t, err)
+
+	var res interface{}
+	assert.Nil(t, json.Unmarshal(p, &res))
+	return res
+}
+
+func TestNewJsonRpcBinderNoLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		NewJsonRpcBinder(JsonRpcBinderProperties{})
+	})
+}
+
+func TestJsonRpcBinderBuild(t *testing.T) {
+	binder := NewJsonRpcBinder(JsonRpcBinderProperties{Logger: logger})
+	binder.Bind("POST", "/service/echo", HandlerEcho{}, mapEntityFactory())
+	binder.Bind("GET", "/service/stream", HandlerEcho{}, mapEntityFactory())
+
+	handler := binder.Build()
+
+	assert.True(t, handler.HasMethod("service_echo"))
+	assert.False(t, handler.HasMethod("service_stream"))
+	assert.False(t, binder.Build().HasMethod("service_echo"))
+}
+
+func TestJsonRpcMethod(t *testing.T) {
+	assert.Equal(t, "service_deploy", JsonRpcMethod("/service/deploy"))
+	assert.Equal(t, "event_registerAbi", JsonRpcMethod("/event/registerAbi"))
+}
+
+func TestJsonRpcHandlerResponse(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"},"id":1}`)
+
+	assert.Equal(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"result":  map[string]interface{}{"key": "value"},
+		"id":      float64(1),
+	}, res)
+}
+
+func TestJsonRpcHandlerErr(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_err","id":"a"}`)
+
+	assert.Equal(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"error": map[string]interface{}{
+			"code":    float64(1028),
+			"message": "Internal Error. Please check the status of the service.",
+		},
+		"id": "a",
+	}, res)
+}
+
+func TestJsonRpcHandlerPanic(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_panic","id":1}`)
+
+	assert.Equal(t, float64(errors.ErrInternalError.Code()),
+		res.(map[string]interface{})["error"].(map[string]interface{})["code"])
+}
+
+func TestJsonRpcHandlerMethodNotFound(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_unknown","id":1}`)
+
+	assert.Equal(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"error": map[string]interface{}{
+			"code":    float64(JsonRpcErrMethodNotFound),
+			"message": "Method not found",
+		},
+		"id": float64(1),
+	}, res)
+}
+
+func TestJsonRpcHandlerInvalidParams(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_echo","params":["value"],"id":1}`)
+
+	assert.Equal(t, float64(JsonRpcErrInvalidParams),
+		res.(map[string]interface{})["error"].(map[string]interface{})["code"])
+}
+
+func TestJsonRpcHandlerInvalidRequest(t *testing.T) {
+	for _, payload := range []string{
+		`{"jsonrpc":"1.0","method":"service_echo","id":1}`,
+		`{"jsonrpc":"2.0","id":1}`,
+		`1`,
+		`[]`,
+	} {
+		res := handleJsonRpc(t, context.Background(), payload)
+		assert.Equal(t, float64(JsonRpcErrInvalidRequest),
+			res.(map[string]interface{})["error"].(map[string]interface{})["code"], payload)
+	}
+}
+
+func TestJsonRpcHandlerNotification(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(),
+		`{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"}}`)
+
+	assert.Nil(t, res)
+}
+
+func TestJsonRpcHandlerBatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), IdempotencyKey{}, "key")
+	res := handleJsonRpc(t, ctx, `[
+		{"jsonrpc":"2.0","method":"service_key","id":1},
+		{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"}},
+		{"jsonrpc":"2.0","method":"service_key","id":2}
+	]`)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"jsonrpc": "2.0", "result": "key:0", "id": float64(1)},
+		map[string]interface{}{"jsonrpc": "2.0", "result": "key:2", "id": float64(2)},
+	}, res)
+}
+
+func TestJsonRpcHandlerBatchNotifications(t *testing.T) {
+	res := handleJsonRpc(t, context.Background(), `[
+		{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"}},
+		{"jsonrpc":"2.0","method":"service_err"}
+	]`)
+
+	assert.Nil(t, res)
+}
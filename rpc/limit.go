@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// RemoteAddr is the key of the context value set with the network
+// address of the client that sent the request
+type RemoteAddr struct{}
+
+// GetRemoteAddr returns the network address of the client that sent
+// the request, or an empty string if it is not known
+func GetRemoteAddr(ctx context.Context) string {
+	value, ok := ctx.Value(RemoteAddr{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// OperationLimiter limits the rate at which operations are dispatched to
+// the handlers. An operation is dispatched for each http request, for each
+// request of a JSON-RPC batch and for each message received through a
+// WebSocket connection, so that a client is limited in the same way
+// regardless of how it sends its requests
+type OperationLimiter interface {
+	// Allow returns an error if the operations for the route
+	// cannot be dispatched
+	Allow(ctx context.Context, route string, operations int) error
+}
+
+// OperationCounter is implemented by the bodies of the requests that carry
+// more than one operation, so that they are limited as if each of the
+// operations had been sent on its own
+type OperationCounter interface {
+	// OperationCount returns the number of operations in the body
+	OperationCount() int
+}
+
+// CountOperations returns the number of operations in the body
+// of a request, which is one unless the body tells otherwise
+func CountOperations(body interface{}) int {
+	if counter, ok := body.(OperationCounter); ok {
+		return counter.OperationCount()
+	}
+
+	return 1
+}
+
+// OperationRoute returns the name under which the operations sent to
+// the route with the provided path are limited. It is the path relative
+// to the prefix of the version of the API, so /v0/api/service/execute,
+// /v1/api/service/execute and the JSON-RPC method service_execute are all
+// limited as /service/execute
+func OperationRoute(path string) string {
+	if !strings.HasPrefix(path, "/v") {
+		return path
+	}
+
+	i := strings.Index(path[2:], "/")
+	if i <= 0 {
+		return path
+	}
+
+	version, rest := path[2:2+i], path[2+i:]
+	for _, r := range version {
+		if !unicode.IsDigit(r) {
+			return path
+		}
+	}
+
+	if !strings.HasPrefix(rest, "/api/") {
+		return path
+	}
+
+	return rest[len("/api"):]
+}
+
+// allowOperations checks with the limiter, if any, whether the
+// operations in the body can be dispatched
+func allowOperations(ctx context.Context, limiter OperationLimiter, route string, body interface{}) error {
+	if limiter == nil {
+		return nil
+	}
+
+	operations := CountOperations(body)
+	if operations <= 0 {
+		return nil
+	}
+
+	return limiter.Allow(ctx, OperationRoute(route), operations)
+}
@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+// MockOperationLimiter records the operations it is asked to
+// allow and rejects the operations once limit is reached
+type MockOperationLimiter struct {
+	mutex      sync.Mutex
+	limit      int
+	routes     []string
+	operations []int
+}
+
+func (l *MockOperationLimiter) Allow(ctx context.Context, route string, operations int) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.routes = append(l.routes, route)
+	l.operations = append(l.operations, operations)
+	if len(l.routes) > l.limit {
+		return errors.New(errors.ErrRateLimitExceeded, nil)
+	}
+
+	return nil
+}
+
+func (l *MockOperationLimiter) Routes() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]string(nil), l.routes...)
+}
+
+type batchBody struct {
+	Items []string `json:"items"`
+}
+
+func (b *batchBody) OperationCount() int {
+	return len(b.Items)
+}
+
+func TestCountOperations(t *testing.T) {
+	assert.Equal(t, 1, CountOperations(nil))
+	assert.Equal(t, 1, CountOperations(&map[string]string{}))
+	assert.Equal(t, 2, CountOperations(&batchBody{Items: []string{"a", "b"}}))
+	assert.Equal(t, 0, CountOperations(&JsonRpcPayload{}))
+}
+
+func TestOperationRoute(t *testing.T) {
+	assert.Equal(t, "/service/execute", OperationRoute("/v0/api/service/execute"))
+	assert.Equal(t, "/service/execute", OperationRoute("/v1/api/service/execute"))
+	assert.Equal(t, "/service/execute", OperationRoute("/service/execute"))
+	assert.Equal(t, "/service/{address}/code", OperationRoute("/v12/api/service/{address}/code"))
+	assert.Equal(t, "/vx/api/service/execute", OperationRoute("/vx/api/service/execute"))
+	assert.Equal(t, "/v1/rpc", OperationRoute("/v1/rpc"))
+	assert.Equal(t, "/rpc", OperationRoute("/rpc"))
+}
+
+func TestAllowOperationsVersionedRoutes(t *testing.T) {
+	limiter := &MockOperationLimiter{limit: 2}
+
+	assert.Nil(t, allowOperations(context.Background(), limiter, "/v0/api/service/execute", nil))
+	assert.Nil(t, allowOperations(context.Background(), limiter, "/v1/api/service/execute", nil))
+	assert.Equal(t, []string{"/service/execute", "/service/execute"}, limiter.Routes())
+}
+
+func TestAllowOperationsNoLimiter(t *testing.T) {
+	assert.Nil(t, allowOperations(context.Background(), nil, "/path", nil))
+}
+
+func TestAllowOperationsNoOperations(t *testing.T) {
+	limiter := &MockOperationLimiter{}
+
+	assert.Nil(t, allowOperations(context.Background(), limiter, "/path", &batchBody{}))
+	assert.Empty(t, limiter.Routes())
+}
+
+func TestHttpJsonHandlerLimiter(t *testing.T) {
+	limiter := &MockOperationLimiter{limit: 1}
+	handler := NewHttpJsonHandler(HttpJsonHandlerProperties{
+		Limit:   1024,
+		Handler: HandlerEcho{},
+		Logger:  logger,
+		Factory: EntityFactoryFunc(func() interface{} { return &batchBody{} }),
+		Limiter: limiter,
+	})
+
+	serve := func() error {
+		body := `{"items":["a","b","c"]}`
+		req, _ := http.NewRequest("POST", "/path", bytes.NewBufferString(body))
+		req.ContentLength = int64(len(body))
+		req.Header.Add("Content-type", "application/json")
+		_, err := handler.ServeHTTP(req)
+		return err
+	}
+
+	assert.Nil(t, serve())
+	err := serve()
+	assert.Equal(t, errors.ErrRateLimitExceeded, err.(errors.Err).ErrorCode())
+	assert.Equal(t, []string{"/path", "/path"}, limiter.routes)
+	assert.Equal(t, []int{3, 3}, limiter.operations)
+}
+
+func TestJsonRpcHandlerLimiterBatch(t *testing.T) {
+	limiter := &MockOperationLimiter{limit: 1}
+	binder := NewJsonRpcBinder(JsonRpcBinderProperties{Logger: logger, Limiter: limiter})
+	binder.Bind("POST", "/service/echo", HandlerEcho{}, mapEntityFactory())
+	handler := binder.Build()
+
+	v, err := handler.Handle(context.Background(), &JsonRpcPayload{RawMessage: json.RawMessage(`[
+		{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"},"id":1},
+		{"jsonrpc":"2.0","method":"service_echo","params":{"key":"value"},"id":2}
+	]`)})
+	assert.Nil(t, err)
+
+	p, err := json.Marshal(v)
+	assert.Nil(t, err)
+
+	var res []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(p, &res))
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, map[string]interface{}{"key": "value"}, res[0]["result"])
+	assert.Equal(t, float64(errors.ErrRateLimitExceeded.Code()),
+		res[1]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, []string{"/service/echo", "/service/echo"}, limiter.routes)
+	assert.Equal(t, []int{1, 1}, limiter.operations)
+}
+
+func TestWebSocketHandlerLimiter(t *testing.T) {
+	limiter := &MockOperationLimiter{limit: 1}
+	server := setupWebSocketServer(t, WebSocketBinderProperties{Logger: logger, Limiter: limiter}, 0)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 1, Method: "POST", Path: "/echo"})
+	assert.Equal(t, "response", receiveWebSocket(t, conn)["type"])
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 2, Method: "POST", Path: "/echo"})
+	res := receiveWebSocket(t, conn)
+	assert.Equal(t, "error", res["type"])
+	assert.Equal(t, float64(errors.ErrRateLimitExceeded.Code()),
+		res["error"].(map[string]interface{})["errorCode"])
+	assert.Equal(t, []string{"/echo", "/echo"}, limiter.Routes())
+}
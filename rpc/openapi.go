@@ -0,0 +1,372 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification
+// the generated documents follow
+const OpenAPIVersion = "3.0.2"
+
+// RouteDescription describes a route bound to an HttpBinder
+type RouteDescription struct {
+	// Method of the route
+	Method string
+
+	// Path of the route
+	Path string
+
+	// Request is the type of the body expected by the route. It is
+	// nil if the route does not expect a body
+	Request reflect.Type
+
+	// Response is the type of the body returned by the route. It is
+	// nil if the route does not return a body or if it is not known
+	Response reflect.Type
+
+	// OneOf are the types of the bodies returned by a route that
+	// returns bodies of different types
+	OneOf []reflect.Type
+
+	// Stream is true if the route returns a stream of events
+	Stream bool
+}
+
+func describeRoute(method, path string, handler Handler, factory EntityFactory) RouteDescription {
+	route := RouteDescription{Method: method, Path: path}
+
+	if factory != nil {
+		if body := factory.Create(); body != nil {
+			route.Request = reflect.TypeOf(body)
+		}
+	}
+
+	if described, ok := handler.(DescribedHandler); ok {
+		route.Stream = described.Stream
+		if described.Response != nil {
+			route.Response = reflect.TypeOf(described.Response)
+		}
+		for _, response := range described.OneOf {
+			route.OneOf = append(route.OneOf, reflect.TypeOf(response))
+		}
+	}
+
+	return route
+}
+
+// OpenAPIInfo is the metadata of the API
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIDocument is an OpenAPI 3 document that describes the
+// routes served by an HttpRouter
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+}
+
+// OpenAPIPathItem keeps the operations of a path by method
+type OpenAPIPathItem map[string]OpenAPIOperation
+
+// OpenAPIOperation describes a single method of a path
+type OpenAPIOperation struct {
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter describes a parameter of an operation
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody describes the body of a request
+type OpenAPIRequestBody struct {
+	Content  map[string]OpenAPIMediaType `json:"content"`
+	Required bool                        `json:"required"`
+}
+
+// OpenAPIResponse describes a response of an operation
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType describes the schema of a body for a content type
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents keeps the schemas that are referenced
+// from the operations
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPISchema is the subset of the OpenAPI schema object
+// required to describe the entities of the API
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	OneOf                []*OpenAPISchema          `json:"oneOf,omitempty"`
+}
+
+// hasRequestBody returns true if the requests with the method
+// can have a body according to the OpenAPI specification
+func hasRequestBody(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return false
+	default:
+		return true
+	}
+}
+
+// NewOpenAPIDocument generates the OpenAPI document that describes
+// the provided routes
+func NewOpenAPIDocument(info OpenAPIInfo, routes []RouteDescription) OpenAPIDocument {
+	generator := openAPIGenerator{schemas: make(map[string]*OpenAPISchema)}
+
+	// the schemas of the requests are generated first and without the
+	// required fields, which are validated by the handlers instead. The
+	// types used in both requests and responses keep those schemas
+	requests := make([]*OpenAPISchema, len(routes))
+	for i, route := range routes {
+		if route.Request != nil && hasRequestBody(route.Method) {
+			requests[i] = generator.schema(route.Request)
+		}
+	}
+
+	// the fields of the responses that are not omitted when
+	// empty are always present
+	generator.required = true
+	errorSchema := generator.schema(reflect.TypeOf(Error{}))
+
+	paths := make(map[string]OpenAPIPathItem)
+	for i, route := range routes {
+		item, ok := paths[route.Path]
+		if !ok {
+			item = make(OpenAPIPathItem)
+			paths[route.Path] = item
+		}
+
+		op := OpenAPIOperation{Responses: map[string]OpenAPIResponse{
+			"default": {
+				Description: "Error",
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: errorSchema},
+				},
+			},
+		}}
+
+		for _, name := range pathParamNames(route.Path) {
+			op.Parameters = append(op.Parameters, OpenAPIParameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &OpenAPISchema{Type: "string"},
+			})
+		}
+
+		if requests[i] != nil {
+			op.RequestBody = &OpenAPIRequestBody{
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: requests[i]},
+				},
+			}
+		}
+
+		switch {
+		case route.Stream:
+			op.Responses["200"] = OpenAPIResponse{
+				Description: "Stream of server-sent events",
+				Content: map[string]OpenAPIMediaType{
+					"text/event-stream": {Schema: &OpenAPISchema{Type: "string"}},
+				},
+			}
+		case route.Response != nil:
+			op.Responses["200"] = OpenAPIResponse{
+				Description: "OK",
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: generator.schema(route.Response)},
+				},
+			}
+		case len(route.OneOf) > 0:
+			schema := &OpenAPISchema{}
+			for _, t := range route.OneOf {
+				schema.OneOf = append(schema.OneOf, generator.schema(t))
+			}
+
+			op.Responses["200"] = OpenAPIResponse{
+				Description: "OK",
+				Content: map[string]OpenAPIMediaType{
+					"application/json": {Schema: schema},
+				},
+			}
+		default:
+			op.Responses["204"] = OpenAPIResponse{Description: "No Content"}
+		}
+
+		item[strings.ToLower(route.Method)] = op
+	}
+
+	return OpenAPIDocument{
+		OpenAPI:    OpenAPIVersion,
+		Info:       info,
+		Paths:      paths,
+		Components: OpenAPIComponents{Schemas: generator.schemas},
+	}
+}
+
+// openAPIGenerator generates the schemas of Go types from their
+// JSON representation. Named struct types are added to the
+// components of the document and referenced from the operations
+type openAPIGenerator struct {
+	schemas map[string]*OpenAPISchema
+
+	// required is true if the fields that are not omitted
+	// when empty are listed as required
+	required bool
+}
+
+var (
+	rawMessageType     = reflect.TypeOf(json.RawMessage{})
+	jsonRpcPayloadType = reflect.TypeOf(JsonRpcPayload{})
+)
+
+func (g *openAPIGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == rawMessageType || t == jsonRpcPayloadType {
+		return &OpenAPISchema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if len(t.Name()) == 0 {
+			return g.structSchema(t)
+		}
+
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// the schema is registered before it is generated so
+			// that recursive types do not recurse indefinitely
+			g.schemas[name] = &OpenAPISchema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+
+		return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+	default:
+		// interfaces can hold any value
+		return &OpenAPISchema{}
+	}
+}
+
+func (g *openAPIGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	g.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (g *openAPIGenerator) addFields(schema *OpenAPISchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+
+		// embedded structs without a name are flattened as
+		// encoding/json does
+		if field.Anonymous && len(name) == 0 {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(schema, ft)
+				continue
+			}
+		}
+
+		if len(field.PkgPath) > 0 {
+			// unexported field
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.schema(field.Type)
+		if g.required && !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// schemaName returns the name of the schema for a named type. The
+// name includes the last elements of the package path so that types
+// with the same name in different versions of the API do not clash
+func schemaName(t reflect.Type) string {
+	dir, pkg := path.Split(t.PkgPath())
+	parent := path.Base(dir)
+	if len(parent) == 0 || parent == "." || parent == "/" {
+		return pkg + "." + t.Name()
+	}
+
+	return parent + "." + pkg + "." + t.Name()
+}
+
+// openAPIHandler serves the OpenAPI document of a router
+type openAPIHandler struct {
+	document OpenAPIDocument
+}
+
+// ServeHTTP is the implementation of HttpMiddleware for openAPIHandler
+func (h openAPIHandler) ServeHTTP(req *http.Request) (interface{}, error) {
+	return h.document, nil
+}
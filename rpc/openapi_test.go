@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type openAPIRequest struct {
+	Data    string   `json:"data"`
+	Address string   `json:"address,omitempty"`
+	Topics  []string `json:"topics"`
+	Ignored string   `json:"-"`
+}
+
+type openAPIResponse struct {
+	StreamEvent
+	Output []byte           `json:"output"`
+	Event  interface{}      `json:"event,omitempty"`
+	Next   *openAPIResponse `json:"next,omitempty"`
+}
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	document := NewOpenAPIDocument(OpenAPIInfo{Title: "title", Version: "1"}, []RouteDescription{
+		{
+			Method:   "POST",
+			Path:     "/execute",
+			Request:  reflectTypeOf(&openAPIRequest{}),
+			Response: reflectTypeOf(openAPIResponse{}),
+		},
+		{Method: "POST", Path: "/cancel", Request: reflectTypeOf(&openAPIRequest{})},
+		{Method: "GET", Path: "/stream", Stream: true},
+	})
+
+	assert.Equal(t, OpenAPIVersion, document.OpenAPI)
+	assert.Equal(t, OpenAPIInfo{Title: "title", Version: "1"}, document.Info)
+
+	execute := document.Paths["/execute"]["post"]
+	assert.Equal(t, "#/components/schemas/oasis-gateway.rpc.openAPIRequest",
+		execute.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/oasis-gateway.rpc.openAPIResponse",
+		execute.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/oasis-gateway.rpc.Error",
+		execute.Responses["default"].Content["application/json"].Schema.Ref)
+
+	_, ok := document.Paths["/cancel"]["post"].Responses["204"]
+	assert.True(t, ok)
+	_, ok = document.Paths["/stream"]["get"].Responses["200"].Content["text/event-stream"]
+	assert.True(t, ok)
+
+	assert.Equal(t, &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"data":    {Type: "string"},
+			"address": {Type: "string"},
+			"topics":  {Type: "array", Items: &OpenAPISchema{Type: "string"}},
+		},
+	}, document.Components.Schemas["oasis-gateway.rpc.openAPIRequest"])
+
+	assert.Equal(t, &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"id":     {Type: "integer", Format: "int64"},
+			"output": {Type: "string", Format: "byte"},
+			"event":  {},
+			"next":   {Ref: "#/components/schemas/oasis-gateway.rpc.openAPIResponse"},
+		},
+		Required: []string{"id", "output"},
+	}, document.Components.Schemas["oasis-gateway.rpc.openAPIResponse"])
+}
+
+func TestHttpBinderOpenAPI(t *testing.T) {
+	binder := NewHttpBinder(HttpBinderProperties{
+		Encoder:        JsonEncoder{},
+		Logger:         logger,
+		HandlerFactory: HttpHandlerFactoryFunc(simpleHandlerFactory),
+		OpenAPI: HttpOpenAPIProps{
+			Path: "/openapi.json",
+			Info: OpenAPIInfo{Title: "title", Version: "1"},
+		},
+	})
+
+	binder.Bind("POST", "/path", Describe(HandlerEcho{}, StreamEvent{}),
+		EntityFactoryFunc(func() interface{} { return &StreamEvent{} }))
+	assert.Equal(t, []RouteDescription{{
+		Method:   "POST",
+		Path:     "/path",
+		Request:  reflectTypeOf(&StreamEvent{}),
+		Response: reflectTypeOf(StreamEvent{}),
+	}}, binder.Routes())
+
+	router := binder.Build()
+	assert.Empty(t, binder.Routes())
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/openapi.json", nil)
+	router.ServeHTTP(recorder, req)
+
+	var document OpenAPIDocument
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, []string{"post"}, keys(document.Paths["/path"]))
+}
+
+func keys(item OpenAPIPathItem) []string {
+	var keys []string
+	for key := range item {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func reflectTypeOf(v interface{}) reflect.Type {
+	return reflect.TypeOf(v)
+}
+
+func TestNewOpenAPIDocumentPathParams(t *testing.T) {
+	document := NewOpenAPIDocument(OpenAPIInfo{Title: "title", Version: "1"}, []RouteDescription{
+		{Method: "GET", Path: "/service/{address}/code"},
+		{Method: "GET", Path: "/service/status"},
+	})
+
+	assert.Equal(t, []OpenAPIParameter{
+		{Name: "address", In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"}},
+	}, document.Paths["/service/{address}/code"]["get"].Parameters)
+	assert.Nil(t, document.Paths["/service/status"]["get"].Parameters)
+}
+
+func TestNewOpenAPIDocumentGetNoRequestBody(t *testing.T) {
+	document := NewOpenAPIDocument(OpenAPIInfo{Title: "title", Version: "1"}, []RouteDescription{
+		{Method: "GET", Path: "/code", Request: reflectTypeOf(&openAPIRequest{})},
+		{Method: "POST", Path: "/code", Request: reflectTypeOf(&openAPIRequest{})},
+	})
+
+	assert.Nil(t, document.Paths["/code"]["get"].RequestBody)
+	assert.NotNil(t, document.Paths["/code"]["post"].RequestBody)
+}
+
+func TestNewOpenAPIDocumentOneOf(t *testing.T) {
+	document := NewOpenAPIDocument(OpenAPIInfo{Title: "title", Version: "1"}, []RouteDescription{
+		describeRoute("POST", "/execute",
+			DescribeOneOf(HandlerEcho{}, StreamEvent{}, openAPIResponse{}),
+			EntityFactoryFunc(func() interface{} { return &openAPIRequest{} })),
+	})
+
+	assert.Equal(t, &OpenAPISchema{OneOf: []*OpenAPISchema{
+		{Ref: "#/components/schemas/oasis-gateway.rpc.StreamEvent"},
+		{Ref: "#/components/schemas/oasis-gateway.rpc.openAPIResponse"},
+	}}, document.Paths["/execute"]["post"].Responses["200"].Content["application/json"].Schema)
+}
+
+func TestNewOpenAPIDocumentSharedType(t *testing.T) {
+	// the response is listed before the request, but the schema of the
+	// type does not list required fields because it is also a request
+	document := NewOpenAPIDocument(OpenAPIInfo{Title: "title", Version: "1"}, []RouteDescription{
+		{Method: "POST", Path: "/get", Response: reflectTypeOf(openAPIRequest{})},
+		{Method: "POST", Path: "/set", Request: reflectTypeOf(&openAPIRequest{})},
+	})
+
+	assert.Nil(t, document.Components.Schemas["oasis-gateway.rpc.openAPIRequest"].Required)
+}
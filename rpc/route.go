@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PathParams is the key of the context value set with the parameters
+// parsed from the path of a request to a route with a template
+type PathParams struct{}
+
+// RouteTemplate is the key of the context value set with the template
+// of the route that serves a request, as in "/service/{address}/code"
+type RouteTemplate struct{}
+
+// GetPathParams returns the parameters parsed from the path of
+// the request by name. It returns nil if the route has none
+func GetPathParams(ctx context.Context) map[string]string {
+	value := ctx.Value(PathParams{})
+	if value == nil {
+		return nil
+	}
+
+	return value.(map[string]string)
+}
+
+// GetPathParam returns the value of the path parameter with the
+// provided name, or an empty string if it is not set
+func GetPathParam(ctx context.Context, name string) string {
+	return GetPathParams(ctx)[name]
+}
+
+// GetRouteTemplate returns the template of the route that serves
+// the request, or an empty string if the request was not routed
+func GetRouteTemplate(ctx context.Context) string {
+	value := ctx.Value(RouteTemplate{})
+	if value == nil {
+		return ""
+	}
+
+	return value.(string)
+}
+
+// routePattern matches the paths of the requests against a route
+// template. A segment of a template enclosed in braces, as in
+// "{address}", is a parameter that matches any non empty segment
+type routePattern struct {
+	template string
+	segments []string
+
+	// params has the name of the parameter for each of the
+	// segments that are parameters, and is empty for the rest
+	params []string
+}
+
+// isPathParam returns true if the segment of a template is a parameter
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// hasPathParams returns true if the template has any parameters
+func hasPathParams(template string) bool {
+	for _, segment := range strings.Split(template, "/") {
+		if isPathParam(segment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathParamNames returns the names of the parameters of the
+// template in the order in which they appear
+func pathParamNames(template string) []string {
+	var names []string
+	for _, segment := range strings.Split(template, "/") {
+		if isPathParam(segment) {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+
+	return names
+}
+
+// parseRoutePattern parses the template of a route
+func parseRoutePattern(template string) (routePattern, error) {
+	if !strings.HasPrefix(template, "/") {
+		return routePattern{}, stderr.New("template must start with /")
+	}
+
+	segments := strings.Split(template, "/")
+	params := make([]string, len(segments))
+	names := make(map[string]bool)
+
+	for i, segment := range segments {
+		if !isPathParam(segment) {
+			if strings.ContainsAny(segment, "{}") {
+				return routePattern{}, fmt.Errorf("segment %s must be a parameter or not contain braces", segment)
+			}
+			continue
+		}
+
+		name := segment[1 : len(segment)-1]
+		if len(name) == 0 || strings.ContainsAny(name, "{}") {
+			return routePattern{}, fmt.Errorf("invalid parameter %s", segment)
+		}
+		if names[name] {
+			return routePattern{}, fmt.Errorf("parameter %s is defined more than once", name)
+		}
+
+		names[name] = true
+		params[i] = name
+	}
+
+	return routePattern{template: template, segments: segments, params: params}, nil
+}
+
+// shape returns the template with the names of the parameters removed,
+// so that the templates that match the same paths have the same shape
+func (p routePattern) shape() string {
+	segments := make([]string, len(p.segments))
+	for i, segment := range p.segments {
+		if len(p.params[i]) > 0 {
+			segment = "{}"
+		}
+		segments[i] = segment
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// less returns true if the pattern is more specific than the other
+// one, so that it is tried first. Static segments are more specific
+// than parameters at the first segment in which the patterns differ
+func (p routePattern) less(other routePattern) bool {
+	for i := 0; i < len(p.segments) && i < len(other.segments); i++ {
+		param, otherParam := len(p.params[i]) > 0, len(other.params[i]) > 0
+		if param != otherParam {
+			return !param
+		}
+		if !param && p.segments[i] != other.segments[i] {
+			return p.segments[i] < other.segments[i]
+		}
+	}
+
+	if len(p.segments) != len(other.segments) {
+		return len(p.segments) < len(other.segments)
+	}
+
+	return p.template < other.template
+}
+
+// match returns the parameters parsed from the escaped path if
+// it matches the pattern
+func (p routePattern) match(path string) (map[string]string, bool) {
+	segments := strings.Split(path, "/")
+	if len(segments) != len(p.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range segments {
+		name := p.params[i]
+		if len(name) == 0 {
+			if segment != p.segments[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		if len(segment) == 0 {
+			return nil, false
+		}
+
+		value, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, false
+		}
+
+		params[name] = value
+	}
+
+	return params, true
+}
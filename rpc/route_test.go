@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoutePattern(t *testing.T) {
+	pattern, err := parseRoutePattern("/service/{address}/code/{id}")
+	assert.Nil(t, err)
+	assert.Equal(t, "/service/{}/code/{}", pattern.shape())
+	assert.Equal(t, []string{"address", "id"}, pathParamNames(pattern.template))
+}
+
+func TestParseRoutePatternErr(t *testing.T) {
+	for _, template := range []string{
+		"service/{address}",
+		"/service/{}",
+		"/service/{address}/{address}",
+		"/service/code{address}",
+		"/service/{{address}}",
+	} {
+		_, err := parseRoutePattern(template)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestRoutePatternMatch(t *testing.T) {
+	pattern, err := parseRoutePattern("/service/{address}/code")
+	assert.Nil(t, err)
+
+	params, ok := pattern.match("/service/0x00/code")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"address": "0x00"}, params)
+
+	params, ok = pattern.match("/service/a%2Fb/code")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"address": "a/b"}, params)
+
+	for _, path := range []string{
+		"/service//code",
+		"/service/0x00",
+		"/service/0x00/code/",
+		"/services/0x00/code",
+		"/service/%zz/code",
+	} {
+		_, ok := pattern.match(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestRoutePatternLess(t *testing.T) {
+	var patterns []routePattern
+	for _, template := range []string{
+		"/event/{id}",
+		"/event/{id}/ack",
+		"/event/subscriptions/{id}",
+		"/{version}/subscriptions/{id}",
+	} {
+		pattern, err := parseRoutePattern(template)
+		assert.Nil(t, err)
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].less(patterns[j])
+	})
+
+	var templates []string
+	for _, pattern := range patterns {
+		templates = append(templates, pattern.template)
+	}
+
+	assert.Equal(t, []string{
+		"/event/subscriptions/{id}",
+		"/event/{id}",
+		"/event/{id}/ack",
+		"/{version}/subscriptions/{id}",
+	}, templates)
+}
+
+func TestGetPathParam(t *testing.T) {
+	ctx := context.WithValue(context.Background(), PathParams{}, map[string]string{"id": "1"})
+	assert.Equal(t, "1", GetPathParam(ctx, "id"))
+	assert.Equal(t, "", GetPathParam(ctx, "address"))
+	assert.Equal(t, "", GetPathParam(context.Background(), "id"))
+}
@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// HttpHeaderLastEventID is the header set by the clients that resume a
+// stream of server-sent events with the ID of the last event received
+const HttpHeaderLastEventID = "Last-Event-ID"
+
+// LastEventID is the key of the context value set with the ID of the last
+// event received by a client that resumes a stream
+type LastEventID struct{}
+
+// GetLastEventID returns the ID of the last event received by the
+// client if it is resuming a stream
+func GetLastEventID(ctx context.Context) (uint64, bool) {
+	value := ctx.Value(LastEventID{})
+	if value == nil {
+		return 0, false
+	}
+
+	return value.(uint64), true
+}
+
+// ResumeStream returns the offset from which a stream delivers events.
+// When the client resumes a stream, the stream continues after the last
+// event it received rather than from the offset of the request. The
+// writer is told about the offset so that it can let the client know
+// from where to resume the stream if the connection is lost
+func ResumeStream(ctx context.Context, w StreamWriter, offset uint64) uint64 {
+	if id, ok := GetLastEventID(ctx); ok && id+1 > offset {
+		offset = id + 1
+	}
+
+	if w, ok := w.(streamResumer); ok {
+		w.resume(offset)
+	}
+
+	return offset
+}
+
+// streamResumer is implemented by the writers that keep track of the
+// point from which a client can resume a stream
+type streamResumer interface {
+	resume(offset uint64)
+}
+
+// maxPendingEvents is the maximum number of events delivered out of order
+// that a streamCursor keeps track of. The mailboxes do not hold more
+// events than this, so if the limit is reached the events the cursor
+// is waiting for have been discarded and will never be delivered
+const maxPendingEvents = 1024
+
+// streamCursor keeps track of the lowest ID of the events that have not
+// been delivered yet. Events may be delivered out of order, so the events
+// with a higher ID are remembered until the cursor reaches them
+type streamCursor struct {
+	offset    uint64
+	delivered map[uint64]struct{}
+}
+
+// deliver marks the event as delivered and advances the cursor
+// past the events that have all been delivered
+func (c *streamCursor) deliver(id uint64) {
+	if id < c.offset {
+		return
+	}
+
+	c.delivered[id] = struct{}{}
+	if len(c.delivered) > maxPendingEvents {
+		c.offset = id
+		for pending := range c.delivered {
+			if pending < c.offset {
+				c.offset = pending
+			}
+		}
+	}
+
+	for {
+		if _, ok := c.delivered[c.offset]; !ok {
+			break
+		}
+
+		delete(c.delivered, c.offset)
+		c.offset++
+	}
+}
+
+// parseLastEventID parses the Last-Event-ID header of the request. Event
+// IDs that were not generated by the gateway are ignored
+func parseLastEventID(req *http.Request) (uint64, bool) {
+	value := req.Header.Get(HttpHeaderLastEventID)
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// Event is a message that is delivered to a client as part of
+// a Stream
+type Event interface {
+	// EventID is the ID that uniquely identifies the event and it is found
+	// inside a sequence of events
+	EventID() uint64
+}
+
+// StreamWriter delivers the events generated by a Stream to the client
+type StreamWriter interface {
+	// Write sends the event to the client. In case the event cannot be
+	// delivered an error is returned and the stream should be terminated
+	Write(ev Event) error
+}
+
+// Stream is returned by handlers that instead of a single response
+// deliver a sequence of events to the client for as long as the
+// client remains connected
+type Stream interface {
+	// Stream writes events to the provided writer until the context is
+	// cancelled, the stream has no more events or a write fails
+	Stream(ctx context.Context, w StreamWriter) error
+}
+
+// StreamFunc allows functions to implement the Stream interface
+type StreamFunc func(ctx context.Context, w StreamWriter) error
+
+// Stream is the implementation of Stream for StreamFunc
+func (f StreamFunc) Stream(ctx context.Context, w StreamWriter) error {
+	return f(ctx, w)
+}
+
+// HttpEventStreamWriter is a StreamWriter that writes events in the
+// server-sent events format https://html.spec.whatwg.org/multipage/server-sent-events.html
+type HttpEventStreamWriter struct {
+	writer  http.ResponseWriter
+	flusher http.Flusher
+	encoder Encoder
+
+	// cursor is set when the stream can be resumed, in which case the
+	// ID of each message is the ID of the last event up to which all
+	// the events have been delivered, rather than the ID of the event
+	cursor *streamCursor
+}
+
+// NewHttpEventStreamWriter creates a new writer for server-sent events. It
+// returns false if the provided http.ResponseWriter does not support
+// flushing, which is required to deliver events as they are written
+func NewHttpEventStreamWriter(w http.ResponseWriter, encoder Encoder) (*HttpEventStreamWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+
+	return &HttpEventStreamWriter{
+		writer:  w,
+		flusher: flusher,
+		encoder: encoder,
+	}, true
+}
+
+// resume is the implementation of streamResumer for HttpEventStreamWriter
+func (w *HttpEventStreamWriter) resume(offset uint64) {
+	w.cursor = &streamCursor{offset: offset, delivered: make(map[uint64]struct{})}
+}
+
+// Write is the implementation of StreamWriter for HttpEventStreamWriter
+func (w *HttpEventStreamWriter) Write(ev Event) error {
+	if w.cursor == nil {
+		return w.write(fmt.Sprintf("id: %d\n", ev.EventID()), ev)
+	}
+
+	// the message does not have an ID until the first event of
+	// the stream is delivered, so the client resumes the stream
+	// with the offset of the request
+	w.cursor.deliver(ev.EventID())
+	if w.cursor.offset == 0 {
+		return w.write("", ev)
+	}
+
+	return w.write(fmt.Sprintf("id: %d\n", w.cursor.offset-1), ev)
+}
+
+// WriteError writes an error event to the stream so that the client
+// knows the reason of the stream termination
+func (w *HttpEventStreamWriter) WriteError(err Error) error {
+	return w.write("event: error\n", err)
+}
+
+func (w *HttpEventStreamWriter) write(header string, v interface{}) error {
+	var buffer bytes.Buffer
+	if err := w.encoder.Encode(&buffer, v); err != nil {
+		return err
+	}
+
+	var frame bytes.Buffer
+	frame.WriteString(header)
+	for _, line := range bytes.Split(bytes.TrimRight(buffer.Bytes(), "\n"), []byte("\n")) {
+		frame.WriteString("data: ")
+		frame.Write(line)
+		frame.WriteString("\n")
+	}
+	frame.WriteString("\n")
+
+	if _, err := w.writer.Write(frame.Bytes()); err != nil {
+		return err
+	}
+
+	w.flusher.Flush()
+	return nil
+}
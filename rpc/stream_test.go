@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type StreamWriterRecorder struct {
+	events []Event
+}
+
+func (w *StreamWriterRecorder) Write(ev Event) error {
+	w.events = append(w.events, ev)
+	return nil
+}
+
+func TestResumeStreamNoLastEventID(t *testing.T) {
+	assert.Equal(t, uint64(3), ResumeStream(context.TODO(), &StreamWriterRecorder{}, 3))
+}
+
+func TestResumeStreamLastEventID(t *testing.T) {
+	ctx := context.WithValue(context.TODO(), LastEventID{}, uint64(4))
+
+	assert.Equal(t, uint64(5), ResumeStream(ctx, &StreamWriterRecorder{}, 3))
+	assert.Equal(t, uint64(7), ResumeStream(ctx, &StreamWriterRecorder{}, 7))
+}
+
+func TestStreamCursorDeliverUnordered(t *testing.T) {
+	cursor := &streamCursor{offset: 2, delivered: make(map[uint64]struct{})}
+
+	cursor.deliver(3)
+	assert.Equal(t, uint64(2), cursor.offset)
+
+	cursor.deliver(1)
+	assert.Equal(t, uint64(2), cursor.offset)
+
+	cursor.deliver(2)
+	assert.Equal(t, uint64(4), cursor.offset)
+	assert.Equal(t, 0, len(cursor.delivered))
+}
+
+func TestStreamCursorDeliverMaxPending(t *testing.T) {
+	cursor := &streamCursor{offset: 0, delivered: make(map[uint64]struct{})}
+
+	// the event with ID 0 is never delivered, so once the limit
+	// is reached the cursor skips it
+	for i := uint64(1); i <= maxPendingEvents; i++ {
+		cursor.deliver(i)
+	}
+	assert.Equal(t, uint64(0), cursor.offset)
+
+	cursor.deliver(maxPendingEvents + 1)
+	assert.Equal(t, uint64(maxPendingEvents+2), cursor.offset)
+	assert.Equal(t, 0, len(cursor.delivered))
+}
@@ -0,0 +1,498 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/log"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// WebSocketMethodCancel is the method used by a client to cancel
+	// an ongoing stream identified by the request ID
+	WebSocketMethodCancel = "CANCEL"
+
+	// WebSocketResponseTypeResponse is the type of the message that
+	// carries the response to a request
+	WebSocketResponseTypeResponse = "response"
+
+	// WebSocketResponseTypeEvent is the type of the messages that carry
+	// the events of a stream
+	WebSocketResponseTypeEvent = "event"
+
+	// WebSocketResponseTypeError is the type of the message sent when
+	// a request or a stream fails
+	WebSocketResponseTypeError = "error"
+
+	// WebSocketResponseTypeEnd is the type of the message sent when
+	// a stream terminates
+	WebSocketResponseTypeEnd = "end"
+)
+
+// WebSocketRequest is a request sent by a client through a WebSocket
+// connection. Requests are dispatched to the handler bound to the same
+// method and path as they would be if they were sent over HTTP
+type WebSocketRequest struct {
+	// ID is chosen by the client to match the responses and events
+	// sent by the server with the request
+	ID uint64 `json:"id"`
+
+	// Method is the method of the route the request is for
+	Method string `json:"method"`
+
+	// Path is the path of the route the request is for
+	Path string `json:"path"`
+
+	// Body is the payload of the request
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// IdempotencyKey has the same purpose as the Idempotency-Key
+	// header of an HTTP request
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+}
+
+// WebSocketResponse is a message sent by the server through a WebSocket
+// connection as a result of handling a WebSocketRequest
+type WebSocketResponse struct {
+	// ID of the request the message refers to
+	ID uint64 `json:"id"`
+
+	// Type of the message which defines which of the fields are set
+	Type string `json:"type"`
+
+	// Body is the response to the request
+	Body interface{} `json:"body,omitempty"`
+
+	// Event is set in messages that belong to a stream
+	Event interface{} `json:"event,omitempty"`
+
+	// Error is set in case the request failed
+	Error *Error `json:"error,omitempty"`
+}
+
+type webSocketRoute struct {
+	handler Handler
+	factory EntityFactory
+}
+
+// WebSocketBinder is the binder for WebSocket connections. The handlers
+// bound to it can be reached by the requests sent through a single
+// WebSocket connection
+type WebSocketBinder struct {
+	routes      map[string]webSocketRoute
+	logger      log.Logger
+	limit       uint
+	maxInflight uint
+	origins     originPolicy
+	limiter     OperationLimiter
+}
+
+// Bind is the implementation of HandlerBinder for WebSocketBinder
+func (b *WebSocketBinder) Bind(method string, path string, handler Handler, factory EntityFactory) {
+	b.routes[webSocketRouteKey(method, path)] = webSocketRoute{handler: handler, factory: factory}
+}
+
+// Build creates a new WebSocketHandler with the routes that have been
+// bound and clears the routes of the binder
+func (b *WebSocketBinder) Build() *WebSocketHandler {
+	routes := b.routes
+	b.routes = make(map[string]webSocketRoute)
+
+	return &WebSocketHandler{
+		routes:      routes,
+		logger:      b.logger.ForClass("websocket", "handler"),
+		limit:       b.limit,
+		maxInflight: b.maxInflight,
+		origins:     b.origins,
+		limiter:     b.limiter,
+	}
+}
+
+// WebSocketBinderProperties are the properties used to create
+// a new instance of a WebSocketBinder
+type WebSocketBinderProperties struct {
+	// Logger
+	Logger log.Logger
+
+	// Limit is the maximum number of bytes a message received from
+	// the client can have
+	Limit uint
+
+	// MaxInflight is the maximum number of requests sent through a
+	// connection that are handled at the same time. Requests received
+	// when the limit is reached fail until a previous one completes
+	MaxInflight uint
+
+	// Cors is the CORS configuration of the router the handler is
+	// bound to. Handshakes from a different origin are only accepted
+	// if CORS is enabled and allows the origin
+	Cors HttpCorsPreProcessorProps
+
+	// Limiter if set limits the rate at which the requests received
+	// through a connection are dispatched to the handlers
+	Limiter OperationLimiter
+}
+
+// NewWebSocketBinder creates a new instance of a WebSocketBinder. It will
+// panic in case there are errors in the construction of the binder
+func NewWebSocketBinder(properties WebSocketBinderProperties) *WebSocketBinder {
+	if properties.Logger == nil {
+		panic("Logger must be set")
+	}
+
+	limit := properties.Limit
+	if limit == 0 {
+		limit = 1 << 14 // 16 KB
+	}
+
+	maxInflight := properties.MaxInflight
+	if maxInflight == 0 {
+		maxInflight = 16
+	}
+
+	return &WebSocketBinder{
+		routes:      make(map[string]webSocketRoute),
+		logger:      properties.Logger,
+		limit:       limit,
+		maxInflight: maxInflight,
+		origins:     newOriginPolicy(properties.Cors),
+		limiter:     properties.Limiter,
+	}
+}
+
+func webSocketRouteKey(method, path string) string {
+	return fmt.Sprintf("%s %s", method, path)
+}
+
+// originPolicy verifies the origin of the WebSocket handshakes, which
+// browsers always set, so that a page from a different origin cannot
+// open a connection unless CORS allows it. It matches the origins the
+// same way the CORS implementation does
+type originPolicy struct {
+	all       bool
+	origins   map[string]bool
+	wildcards [][2]string
+}
+
+func newOriginPolicy(props HttpCorsPreProcessorProps) originPolicy {
+	policy := originPolicy{origins: make(map[string]bool)}
+	if !props.Enabled {
+		return policy
+	}
+
+	// CORS allows all the origins if none is set
+	if len(props.AllowedOrigins) == 0 {
+		policy.all = true
+	}
+
+	for _, origin := range props.AllowedOrigins {
+		origin = strings.ToLower(origin)
+		if origin == "*" {
+			policy.all = true
+		} else if i := strings.IndexByte(origin, '*'); i >= 0 {
+			policy.wildcards = append(policy.wildcards, [2]string{origin[:i], origin[i+1:]})
+		} else {
+			policy.origins[origin] = true
+		}
+	}
+
+	return policy
+}
+
+// allowed returns true if the request comes from the same origin, from
+// an origin allowed by CORS or from a client that is not a browser
+func (p originPolicy) allowed(req *http.Request) bool {
+	origin := strings.ToLower(req.Header.Get("Origin"))
+	if len(origin) == 0 {
+		return true
+	}
+
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, req.Host) {
+		return true
+	}
+
+	if p.all || p.origins[origin] {
+		return true
+	}
+
+	for _, w := range p.wildcards {
+		if len(origin) >= len(w[0])+len(w[1]) &&
+			strings.HasPrefix(origin, w[0]) && strings.HasSuffix(origin, w[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebSocketHandler is an rpc Handler that upgrades the connection to
+// a WebSocket connection and dispatches the requests received through
+// it to the bound handlers. The context of the request that initiated the
+// connection is the context that will be used for all the requests sent
+// through the connection
+type WebSocketHandler struct {
+	routes      map[string]webSocketRoute
+	logger      log.Logger
+	limit       uint
+	maxInflight uint
+	origins     originPolicy
+	limiter     OperationLimiter
+}
+
+// Handle is the implementation of Handler for WebSocketHandler. It returns
+// an http.Handler that upgrades the connection when served
+func (h *WebSocketHandler) Handle(ctx context.Context, v interface{}) (interface{}, error) {
+	return websocket.Server{
+		// the requests are authenticated before the connection is upgraded,
+		// but the origin still needs to be verified so that pages from other
+		// origins cannot use the credentials a browser sends on their behalf
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			if !h.origins.allowed(req) {
+				h.logger.Debug(ctx, "handshake origin not allowed", log.MapFields{
+					"call_type": "WebSocketHandshakeFailure",
+					"origin":    req.Header.Get("Origin"),
+				})
+				return stderr.New("origin not allowed")
+			}
+
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			h.serve(ctx, conn)
+		},
+	}, nil
+}
+
+// HasRoute returns true if the handler would dispatch requests
+// for the provided method and path
+func (h *WebSocketHandler) HasRoute(method, path string) bool {
+	_, ok := h.routes[webSocketRouteKey(method, path)]
+	return ok
+}
+
+func (h *WebSocketHandler) serve(ctx context.Context, conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	conn.MaxPayloadBytes = int(h.limit)
+
+	// the connection must outlive the read and write timeouts of the http
+	// server, so the deadlines set for the handshake request are cleared
+	// rather than relying on the server to clear them when hijacked
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		h.logger.Debug(ctx, "failed to clear connection deadline", log.MapFields{
+			"call_type": "WebSocketServeFailure",
+			"err":       err.Error(),
+		})
+	}
+
+	session := &webSocketSession{
+		conn:    conn,
+		streams: make(map[uint64]context.CancelFunc),
+	}
+
+	inflight := make(chan struct{}, h.maxInflight)
+
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+		_ = conn.Close()
+	}()
+
+	for {
+		var req WebSocketRequest
+		if err := websocket.JSON.Receive(conn, &req); err != nil {
+			if err != io.EOF {
+				h.logger.Debug(ctx, "failed to receive request", log.MapFields{
+					"call_type": "WebSocketReceiveFailure",
+					"err":       err.Error(),
+				})
+			}
+			return
+		}
+
+		if req.Method == WebSocketMethodCancel {
+			session.cancel(req.ID)
+			continue
+		}
+
+		select {
+		case inflight <- struct{}{}:
+		default:
+			h.logger.Debug(ctx, "too many requests in flight", log.MapFields{
+				"call_type": "WebSocketRequestHandleFailure",
+				"path":      req.Path,
+				"method":    req.Method,
+			})
+			session.sendError(req.ID, errors.New(errors.ErrWebSocketInflightLimitReached, nil))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := h.handle(ctx, session, req)
+
+			// the request stops counting towards the limit before the
+			// client receives its last message, so that the client can
+			// send a new request as soon as it does
+			<-inflight
+			_ = session.send(res)
+		}()
+	}
+}
+
+// handle dispatches the request to its route and returns the
+// last message that needs to be sent to the client for the request
+func (h *WebSocketHandler) handle(ctx context.Context, session *webSocketSession, req WebSocketRequest) (res WebSocketResponse) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Warn(ctx, "unexpected panic caught", log.MapFields{
+				"call_type": "WebSocketRequestHandleFailure",
+				"path":      req.Path,
+				"method":    req.Method,
+				"err":       fmt.Sprintf("%+v", r),
+			})
+			res = makeErrorResponse(req.ID, errors.New(errors.ErrInternalError, nil))
+		}
+	}()
+
+	route, ok := h.routes[webSocketRouteKey(req.Method, req.Path)]
+	if !ok {
+		return makeErrorResponse(req.ID, errors.New(errors.ErrAPINotFound, nil))
+	}
+
+	body := route.factory.Create()
+	hasBody := len(req.Body) > 0 && string(req.Body) != "null"
+	if body == nil && hasBody {
+		return makeErrorResponse(req.ID, errors.New(errors.ErrDeserializeJSON, nil))
+	}
+
+	if body != nil && hasBody {
+		if err := json.Unmarshal(req.Body, body); err != nil {
+			return makeErrorResponse(req.ID, errors.New(errors.ErrDeserializeJSON, err))
+		}
+	}
+
+	if err := allowOperations(ctx, h.limiter, req.Path, body); err != nil {
+		return makeErrorResponse(req.ID, err)
+	}
+
+	if len(req.IdempotencyKey) > 0 {
+		ctx = context.WithValue(ctx, IdempotencyKey{}, req.IdempotencyKey)
+	}
+
+	v, err := route.handler.Handle(ctx, body)
+	if err != nil {
+		h.logger.Debug(ctx, "request failed", log.MapFields{
+			"call_type": "WebSocketRequestHandleFailure",
+			"path":      req.Path,
+			"method":    req.Method,
+			"err":       err.Error(),
+		})
+		return makeErrorResponse(req.ID, err)
+	}
+
+	stream, ok := v.(Stream)
+	if !ok {
+		return WebSocketResponse{ID: req.ID, Type: WebSocketResponseTypeResponse, Body: v}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	session.addStream(req.ID, cancel)
+	defer session.removeStream(req.ID)
+
+	if err := stream.Stream(ctx, webSocketStreamWriter{id: req.ID, session: session}); err != nil {
+		return makeErrorResponse(req.ID, err)
+	}
+
+	return WebSocketResponse{ID: req.ID, Type: WebSocketResponseTypeEnd}
+}
+
+// webSocketSession keeps the state of a WebSocket connection
+type webSocketSession struct {
+	conn    *websocket.Conn
+	mutex   sync.Mutex
+	streams map[uint64]context.CancelFunc
+}
+
+func (s *webSocketSession) send(res WebSocketResponse) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return websocket.JSON.Send(s.conn, res)
+}
+
+func (s *webSocketSession) sendError(id uint64, err error) {
+	_ = s.send(makeErrorResponse(id, err))
+}
+
+func (s *webSocketSession) addStream(id uint64, cancel context.CancelFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.streams[id] = cancel
+}
+
+func (s *webSocketSession) removeStream(id uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.streams, id)
+}
+
+func (s *webSocketSession) cancel(id uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if cancel, ok := s.streams[id]; ok {
+		cancel()
+	}
+}
+
+// webSocketStreamWriter is the StreamWriter that delivers the events
+// of a stream to a WebSocket connection
+type webSocketStreamWriter struct {
+	id      uint64
+	session *webSocketSession
+}
+
+// Write is the implementation of StreamWriter for webSocketStreamWriter
+func (w webSocketStreamWriter) Write(ev Event) error {
+	return w.session.send(WebSocketResponse{ID: w.id, Type: WebSocketResponseTypeEvent, Event: ev})
+}
+
+// makeErrorResponse creates the message sent to the client
+// when the request with the provided ID fails
+func makeErrorResponse(id uint64, err error) WebSocketResponse {
+	rpcErr := makeError(err)
+	return WebSocketResponse{ID: id, Type: WebSocketResponseTypeError, Error: &rpcErr}
+}
+
+// makeError converts an error returned by a handler into the
+// Error that is sent to the client
+func makeError(err error) Error {
+	switch err := err.(type) {
+	case *HttpError:
+		if err.Cause != nil {
+			return makeError(*err.Cause)
+		}
+	case HttpError:
+		if err.Cause != nil {
+			return makeError(*err.Cause)
+		}
+	case errors.Err:
+		return Error{
+			ErrorCode:   err.ErrorCode().Code(),
+			Description: err.ErrorCode().Desc(),
+		}
+	}
+
+	return makeError(errors.New(errors.ErrInternalError, stderr.New("unexpected error type")))
+}
@@ -0,0 +1,310 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+func setupWebSocket(t *testing.T) (*httptest.Server, *websocket.Conn) {
+	server := setupWebSocketServer(t, WebSocketBinderProperties{Logger: logger}, 0)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.Nil(t, err)
+
+	return server, conn
+}
+
+func setupWebSocketServer(
+	t *testing.T,
+	props WebSocketBinderProperties,
+	timeout time.Duration,
+) *httptest.Server {
+	binder := NewWebSocketBinder(props)
+	binder.Bind("POST", "/echo", HandlerEcho{}, mapEntityFactory())
+	binder.Bind("POST", "/err", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return nil, errors.New(errors.ErrQueueRetrieve, nil)
+	}), mapEntityFactory())
+	binder.Bind("GET", "/stream", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return StreamFunc(func(ctx context.Context, w StreamWriter) error {
+			for i := uint64(0); i < 2; i++ {
+				if err := w.Write(StreamEvent{ID: i}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}), nil
+	}), EntityFactoryFunc(func() interface{} { return nil }))
+	binder.Bind("GET", "/block", HandlerFunc(func(ctx context.Context, v interface{}) (interface{}, error) {
+		return StreamFunc(func(ctx context.Context, w StreamWriter) error {
+			if err := w.Write(StreamEvent{ID: 0}); err != nil {
+				return err
+			}
+
+			<-ctx.Done()
+			return nil
+		}), nil
+	}), EntityFactoryFunc(func() interface{} { return nil }))
+
+	handler := binder.Build()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		v, err := handler.Handle(req.Context(), nil)
+		assert.Nil(t, err)
+		v.(http.Handler).ServeHTTP(res, req)
+	}))
+	server.Config.ReadTimeout = timeout
+	server.Config.WriteTimeout = timeout
+	server.Start()
+
+	return server
+}
+
+func sendWebSocket(t *testing.T, conn *websocket.Conn, req WebSocketRequest) {
+	assert.Nil(t, websocket.JSON.Send(conn, req))
+}
+
+func receiveWebSocket(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var res map[string]interface{}
+	assert.Nil(t, websocket.JSON.Receive(conn, &res))
+	return res
+}
+
+func TestNewWebSocketBinderNoLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		NewWebSocketBinder(WebSocketBinderProperties{})
+	})
+}
+
+func TestWebSocketBinderBuild(t *testing.T) {
+	binder := NewWebSocketBinder(WebSocketBinderProperties{Logger: logger})
+	binder.Bind("POST", "/echo", HandlerEcho{}, mapEntityFactory())
+
+	handler := binder.Build()
+
+	assert.True(t, handler.HasRoute("POST", "/echo"))
+	assert.False(t, handler.HasRoute("GET", "/echo"))
+	assert.False(t, binder.Build().HasRoute("POST", "/echo"))
+}
+
+func TestWebSocketHandlerResponse(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{
+		ID:     1,
+		Method: "POST",
+		Path:   "/echo",
+		Body:   json.RawMessage(`{"key":"value"}`),
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(1),
+		"type": "response",
+		"body": map[string]interface{}{"key": "value"},
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerRouteNotFound(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 1, Method: "GET", Path: "/echo"})
+
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(1),
+		"type": "error",
+		"error": map[string]interface{}{
+			"errorCode":   float64(6003),
+			"description": "API not found.",
+		},
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerDeserializeErr(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{
+		ID:     1,
+		Method: "GET",
+		Path:   "/stream",
+		Body:   json.RawMessage(`{"key":"value"}`),
+	})
+
+	res := receiveWebSocket(t, conn)
+	assert.Equal(t, "error", res["type"])
+	assert.Equal(t, float64(errors.ErrDeserializeJSON.Code()),
+		res["error"].(map[string]interface{})["errorCode"])
+}
+
+func TestWebSocketHandlerErr(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 2, Method: "POST", Path: "/err"})
+
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(2),
+		"type": "error",
+		"error": map[string]interface{}{
+			"errorCode":   float64(1028),
+			"description": "Internal Error. Please check the status of the service.",
+		},
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerStream(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 3, Method: "GET", Path: "/stream"})
+
+	assert.Equal(t, map[string]interface{}{
+		"id":    float64(3),
+		"type":  "event",
+		"event": map[string]interface{}{"id": float64(0)},
+	}, receiveWebSocket(t, conn))
+	assert.Equal(t, map[string]interface{}{
+		"id":    float64(3),
+		"type":  "event",
+		"event": map[string]interface{}{"id": float64(1)},
+	}, receiveWebSocket(t, conn))
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(3),
+		"type": "end",
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerStreamCancel(t *testing.T) {
+	server, conn := setupWebSocket(t)
+	defer server.Close()
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 4, Method: "GET", Path: "/block"})
+	assert.Equal(t, map[string]interface{}{
+		"id":    float64(4),
+		"type":  "event",
+		"event": map[string]interface{}{"id": float64(0)},
+	}, receiveWebSocket(t, conn))
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 4, Method: WebSocketMethodCancel})
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(4),
+		"type": "end",
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerPastServerTimeout(t *testing.T) {
+	server := setupWebSocketServer(t, WebSocketBinderProperties{Logger: logger}, 20*time.Millisecond)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	time.Sleep(50 * time.Millisecond)
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 1, Method: "POST", Path: "/echo"})
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(1),
+		"type": "response",
+		"body": map[string]interface{}{},
+	}, receiveWebSocket(t, conn))
+}
+
+func TestWebSocketHandlerInflightLimit(t *testing.T) {
+	server := setupWebSocketServer(t, WebSocketBinderProperties{Logger: logger, MaxInflight: 1}, 0)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 1, Method: "GET", Path: "/block"})
+	assert.Equal(t, "event", receiveWebSocket(t, conn)["type"])
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 2, Method: "POST", Path: "/echo"})
+	res := receiveWebSocket(t, conn)
+	assert.Equal(t, float64(2), res["id"])
+	assert.Equal(t, "error", res["type"])
+	assert.Equal(t, float64(errors.ErrWebSocketInflightLimitReached.Code()),
+		res["error"].(map[string]interface{})["errorCode"])
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 1, Method: WebSocketMethodCancel})
+	assert.Equal(t, map[string]interface{}{
+		"id":   float64(1),
+		"type": "end",
+	}, receiveWebSocket(t, conn))
+
+	sendWebSocket(t, conn, WebSocketRequest{ID: 3, Method: "POST", Path: "/echo"})
+	assert.Equal(t, "response", receiveWebSocket(t, conn)["type"])
+}
+
+func TestWebSocketHandlerOriginNotAllowed(t *testing.T) {
+	server := setupWebSocketServer(t, WebSocketBinderProperties{Logger: logger}, 0)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	_, err := websocket.Dial(url, "", "https://example.com")
+	assert.Error(t, err)
+}
+
+func TestWebSocketHandlerOriginAllowedCors(t *testing.T) {
+	server := setupWebSocketServer(t, WebSocketBinderProperties{
+		Logger: logger,
+		Cors: HttpCorsPreProcessorProps{
+			Enabled:        true,
+			AllowedOrigins: []string{"https://*.example.com"},
+		},
+	}, 0)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, err := websocket.Dial(url, "", "https://app.example.com")
+	assert.Nil(t, err)
+	conn.Close()
+
+	_, err = websocket.Dial(url, "", "https://example.org")
+	assert.Error(t, err)
+}
+
+func TestOriginPolicyAllowed(t *testing.T) {
+	for _, tc := range []struct {
+		props   HttpCorsPreProcessorProps
+		origin  string
+		allowed bool
+	}{
+		{HttpCorsPreProcessorProps{}, "", true},
+		{HttpCorsPreProcessorProps{}, "http://gateway:1234", true},
+		{HttpCorsPreProcessorProps{}, "http://other:1234", false},
+		{HttpCorsPreProcessorProps{AllowedOrigins: []string{"*"}}, "http://other:1234", false},
+		{HttpCorsPreProcessorProps{Enabled: true}, "http://other:1234", true},
+		{HttpCorsPreProcessorProps{Enabled: true, AllowedOrigins: []string{"*"}}, "http://other:1234", true},
+		{HttpCorsPreProcessorProps{Enabled: true, AllowedOrigins: []string{"http://Other:1234"}}, "http://other:1234", true},
+		{HttpCorsPreProcessorProps{Enabled: true, AllowedOrigins: []string{"http://another"}}, "http://other:1234", false},
+	} {
+		req := httptest.NewRequest("GET", "http://gateway:1234/v0/api/ws", nil)
+		if len(tc.origin) > 0 {
+			req.Header.Set("Origin", tc.origin)
+		}
+
+		assert.Equal(t, tc.allowed, newOriginPolicy(tc.props).allowed(req), tc.origin)
+	}
+}
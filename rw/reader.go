@@ -78,7 +78,7 @@ func NewLimitReader(reader io.Reader, props ReadLimitProps) LimitReader {
 	return LimitReader{
 		errOnEOF:     props.ErrOnEOF,
 		failOnExceed: props.FailOnExceed,
-		count:        0,
+		count:        new(int64),
 		limit:        props.Limit,
 		reader:       io.LimitReader(reader, readerLimit),
 	}
@@ -89,7 +89,7 @@ func NewLimitReader(reader io.Reader, props ReadLimitProps) LimitReader {
 type LimitReader struct {
 	failOnExceed bool
 	errOnEOF     bool
-	count        int64
+	count        *int64
 	limit        int64
 	reader       io.Reader
 }
@@ -97,24 +97,19 @@ type LimitReader struct {
 // Read is the implementation of Reader for LimitReader
 func (r LimitReader) Read(p []byte) (int, error) {
 	n, err := r.reader.Read(p)
-	if err != nil {
-		if err == io.EOF {
-			if r.errOnEOF {
-				return n, err
-			}
 
-			return n, nil
-		}
-
-		return n, err
+	// the count is shared by the copies of the reader so that
+	// the limit applies to all the reads and not to each of them
+	*r.count += int64(n)
+	if r.failOnExceed && *r.count > r.limit {
+		return 0, ErrLimitExceeded
 	}
 
-	r.count += int64(n)
-	if r.failOnExceed && r.count > r.limit {
-		return 0, ErrLimitExceeded
+	if err == io.EOF && !r.errOnEOF {
+		return n, nil
 	}
 
-	return n, nil
+	return n, err
 }
 
 // CopyWithLimit copies props.Limit bytes from an io.Reader to an io.Writer.
@@ -87,3 +87,22 @@ func TestCopyWithLimitErrExceed(t *testing.T) {
 	assert.Equal(t, ErrLimitExceeded, err)
 	assert.Equal(t, int64(0), n)
 }
+
+func TestLimitReaderReadWithLimitErrExceedMultipleReads(t *testing.T) {
+	buf := bytes.NewBufferString("some data")
+	p := make([]byte, 4)
+
+	r := NewLimitReader(buf, ReadLimitProps{FailOnExceed: true, Limit: 8})
+
+	n, err := r.Read(p)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+
+	n, err = r.Read(p)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+
+	n, err = r.Read(p)
+	assert.Equal(t, ErrLimitExceeded, err)
+	assert.Equal(t, 0, n)
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"reflect"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/oasislabs/oasis-gateway/auth/insecure"
 	"github.com/oasislabs/oasis-gateway/eth"
 	"github.com/oasislabs/oasis-gateway/eth/ethtest"
+	"github.com/oasislabs/oasis-gateway/rpc"
 	"github.com/oasislabs/oasis-gateway/tests/apitest"
 	"github.com/oasislabs/oasis-gateway/tests/gatewaytest"
 	"github.com/stretchr/testify/assert"
@@ -121,6 +123,108 @@ func (s *ApiTestSuite) TestPathNoContentType() {
 	assert.Equal(s.T(), "{\"errorCode\":2004,\"description\":\"Content-type should be application/json.\"}\n", string(res.Body))
 }
 
+func (s *ApiTestSuite) TestOpenAPINoAuth() {
+	res, err := s.client.Request(apitest.Request{
+		Route: apitest.Route{
+			Method: "GET",
+			Path:   "/openapi.json",
+		},
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, res.Code)
+
+	var document rpc.OpenAPIDocument
+	assert.Nil(s.T(), json.Unmarshal(res.Body, &document))
+	assert.Equal(s.T(), rpc.OpenAPIVersion, document.OpenAPI)
+	assert.Contains(s.T(), document.Paths, "/v0/api/service/execute")
+	assert.Contains(s.T(), document.Paths, "/v1/api/service/execute")
+	assert.Contains(s.T(), document.Components.Schemas, "v0.service.ExecuteServiceRequest")
+}
+
+func (s *ApiTestSuite) TestPathV1NoContentType() {
+	res, err := s.client.Request(apitest.Request{
+		Route: apitest.Route{
+			Method: "POST",
+			Path:   "/v1/api/service/deploy",
+		},
+		Body: []byte("{}"),
+		Headers: map[string]string{
+			insecure.HeaderKey:           "mykey",
+			auth.RequestHeaderSessionKey: "mysession",
+			"Content-length":             "2",
+		},
+	})
+	assert.Nil(s.T(), err)
+
+	assert.Equal(s.T(), http.StatusBadRequest, res.Code)
+	assert.Equal(s.T(), "{\"errorCode\":2004,\"description\":\"Content-type should be application/json.\"}\n", string(res.Body))
+}
+
+func (s *ApiTestSuite) TestJsonRpcNotAuth() {
+	res, err := s.client.Request(apitest.Request{
+		Route: apitest.Route{
+			Method: "POST",
+			Path:   "/rpc",
+		},
+		Body: []byte(`{"jsonrpc":"2.0","method":"session_open","params":{},"id":1}`),
+		Headers: map[string]string{
+			"Content-type": "application/json",
+		},
+	})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusForbidden, res.Code)
+	assert.Equal(s.T(), "{\"errorCode\":7003,\"description\":\"Failed to authenticate request.\"}\n", string(res.Body))
+}
+
+func (s *ApiTestSuite) TestJsonRpcNoSession() {
+	res, err := s.client.Request(apitest.Request{
+		Route: apitest.Route{
+			Method: "POST",
+			Path:   "/rpc",
+		},
+		Body: []byte(`[
+			{"jsonrpc":"2.0","method":"session_open","params":{},"id":1},
+			{"jsonrpc":"2.0","method":"service_deploy","params":{"data":"0x0000"},"id":2}
+		]`),
+		Headers: map[string]string{
+			insecure.HeaderKey: "mykey",
+			"Content-type":     "application/json",
+		},
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, res.Code)
+
+	var responses []rpc.JsonRpcResponse
+	assert.Nil(s.T(), json.Unmarshal(res.Body, &responses))
+	assert.Equal(s.T(), 2, len(responses))
+	assert.Nil(s.T(), responses[0].Error)
+	assert.Contains(s.T(), string(responses[0].Result), "sessionKey")
+	assert.Equal(s.T(), &rpc.JsonRpcError{
+		Code:    7003,
+		Message: "Failed to authenticate request.",
+	}, responses[1].Error)
+}
+
+func (s *ApiTestSuite) TestJsonRpcMethodNotFound() {
+	res, err := s.client.Request(apitest.Request{
+		Route: apitest.Route{
+			Method: "POST",
+			Path:   "/rpc",
+		},
+		Body: []byte(`{"jsonrpc":"2.0","method":"service_stream","id":1}`),
+		Headers: map[string]string{
+			insecure.HeaderKey:           "mykey",
+			auth.RequestHeaderSessionKey: "mysession",
+			"Content-type":               "application/json",
+		},
+	})
+	assert.Nil(s.T(), err)
+
+	assert.Equal(s.T(), http.StatusOK, res.Code)
+	assert.Equal(s.T(), "{\"jsonrpc\":\"2.0\",\"error\":{\"code\":-32601,\"message\":\"Method not found\"},\"id\":1}\n", string(res.Body))
+}
+
 func TestApiTestSuite(t *testing.T) {
 	suite.Run(t, new(ApiTestSuite))
 }
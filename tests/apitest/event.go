@@ -30,6 +30,15 @@ func NewEventClient(router *rpc.HttpRouter) *EventClient {
 	}
 }
 
+// NewEventClientWithSession creates a new instance of an event
+// client that sends its requests within the provided session
+func NewEventClientWithSession(router *rpc.HttpRouter, session string) *EventClient {
+	return &EventClient{
+		client:  NewClient(router),
+		session: session,
+	}
+}
+
 // Subscribe creates a subscription to an event topic
 func (c *EventClient) Subscribe(
 	ctx context.Context,
@@ -59,6 +68,45 @@ func (c *EventClient) Unsubscribe(
 	})
 }
 
+// ListSubscriptions lists the active subscriptions of the session
+func (c *EventClient) ListSubscriptions(
+	ctx context.Context,
+) (event.ListSubscriptionsResponse, error) {
+	var res event.ListSubscriptionsResponse
+	if err := c.client.RequestAPI(&rpc.SimpleJsonDeserializer{
+		O: &res,
+	}, &event.ListSubscriptionsRequest{}, c.session, Route{
+		Method: "POST",
+		Path:   "/v0/api/event/list",
+	}); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// RegisterABI registers the ABI of a service for the session
+func (c *EventClient) RegisterABI(
+	ctx context.Context,
+	req event.RegisterABIRequest,
+) error {
+	return c.client.RequestAPI(nil, &req, c.session, Route{
+		Method: "POST",
+		Path:   "/v0/api/event/registerAbi",
+	})
+}
+
+// AckEvent acknowledges the events of a subscription
+func (c *EventClient) AckEvent(
+	ctx context.Context,
+	req event.AckEventRequest,
+) error {
+	return c.client.RequestAPI(nil, &req, c.session, Route{
+		Method: "POST",
+		Path:   "/v0/api/event/ack",
+	})
+}
+
 // PollEvent polls for subscription events
 func (c *EventClient) PollEvent(
 	ctx context.Context,
@@ -35,3 +35,10 @@ type Response struct {
 	// was handled successfully or not
 	Code int
 }
+
+// Offset returns a pointer to the offset so that it can be set in
+// poll requests, which poll from the committed cursor when no
+// offset is set
+func Offset(offset uint64) *uint64 {
+	return &offset
+}
@@ -66,7 +66,7 @@ func (c *ServiceClient) DeployServiceSync(
 	}
 
 	evs, err := c.PollServiceUntilNotEmpty(ctx, service.PollServiceRequest{
-		Offset: res.ID,
+		Offset: Offset(res.ID),
 		Count:  1,
 	})
 	if err != nil {
@@ -108,7 +108,7 @@ func (c *ServiceClient) ExecuteServiceSync(
 	}
 
 	evs, err := c.PollServiceUntilNotEmpty(ctx, service.PollServiceRequest{
-		Offset: res.ID,
+		Offset: Offset(res.ID),
 		Count:  1,
 	})
 	if err != nil {
@@ -0,0 +1,66 @@
+package apitest
+
+import (
+	"context"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/session"
+	"github.com/oasislabs/oasis-gateway/rpc"
+)
+
+// SessionClient is the client implementation for the
+// Session API
+type SessionClient struct {
+	client *Client
+}
+
+// NewSessionClient creates a new instance of a session client
+// with an underlying client ready to be used to execute a
+// router API
+func NewSessionClient(router *rpc.HttpRouter) *SessionClient {
+	return &SessionClient{client: NewClient(router)}
+}
+
+// Open opens a session with a key issued by the gateway
+func (c *SessionClient) Open(
+	ctx context.Context,
+	req session.OpenSessionRequest,
+) (session.OpenSessionResponse, error) {
+	var res session.OpenSessionResponse
+	if err := c.client.RequestAPI(&rpc.SimpleJsonDeserializer{
+		O: &res,
+	}, &req, "", Route{
+		Method: "POST",
+		Path:   "/v0/api/session/open",
+	}); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Refresh extends the TTL of the session identified by key
+func (c *SessionClient) Refresh(
+	ctx context.Context,
+	key string,
+	req session.RefreshSessionRequest,
+) (session.RefreshSessionResponse, error) {
+	var res session.RefreshSessionResponse
+	if err := c.client.RequestAPI(&rpc.SimpleJsonDeserializer{
+		O: &res,
+	}, &req, key, Route{
+		Method: "POST",
+		Path:   "/v0/api/session/refresh",
+	}); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Close closes the session identified by key
+func (c *SessionClient) Close(ctx context.Context, key string) error {
+	return c.client.RequestAPI(nil, &session.CloseSessionRequest{}, key, Route{
+		Method: "POST",
+		Path:   "/v0/api/session/close",
+	})
+}
@@ -2,12 +2,17 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/oasislabs/oasis-gateway/api/v0/event"
 	backend "github.com/oasislabs/oasis-gateway/backend/core"
 	"github.com/oasislabs/oasis-gateway/concurrent"
@@ -51,7 +56,7 @@ func (s *EventsTestSuite) TestSubscribeErrEvent() {
 	assert.Equal(s.T(),
 		&rpc.Error{
 			ErrorCode:   2012,
-			Description: "Only logs topic supported for subscriptions.",
+			Description: "Only logs, newHeads and receipts topics supported for subscriptions.",
 		}, err)
 }
 
@@ -79,7 +84,7 @@ func (s *EventsTestSuite) TestSubscribeOK() {
 
 	res, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
 		Events: []string{"logs"},
-		Filter: "address=address&topic=0x0000000000000000000000000000000000000000000000000000000000000000&topic=0x0000000000000000000000000000000000000000000000000000000000000001",
+		Filter: "address=0x0000000000000000000000000000000000000001&topic=0x0000000000000000000000000000000000000000000000000000000000000000&topic=0x0000000000000000000000000000000000000000000000000000000000000001",
 	})
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), event.SubscribeResponse{
@@ -88,7 +93,7 @@ func (s *EventsTestSuite) TestSubscribeOK() {
 
 	s.ethclient.AssertCalled(s.T(), "SubscribeFilterLogs",
 		mock.Anything, ethereum.FilterQuery{
-			Addresses: []common.Address{common.HexToAddress("address")},
+			Addresses: []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000001")},
 			Topics: [][]common.Hash{
 				{common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000")},
 				{common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000001")},
@@ -97,7 +102,7 @@ func (s *EventsTestSuite) TestSubscribeOK() {
 
 	evs, err := s.eventclient.PollEventUntilNotEmpty(context.TODO(), event.PollEventRequest{
 		ID:     0,
-		Offset: 0,
+		Offset: apitest.Offset(0),
 		Count:  1,
 	})
 	assert.Nil(s.T(), err)
@@ -105,8 +110,9 @@ func (s *EventsTestSuite) TestSubscribeOK() {
 		Offset: 0x0,
 		Events: []event.Event{
 			event.DataEvent{
-				ID:   0x0,
-				Data: "0x",
+				ID:      0x0,
+				Address: "0x0000000000000000000000000000000000000000",
+				Data:    "0x",
 				Topics: []string{
 					"0x0000000000000000000000000000000000000000000000000000000000000000",
 					"0x0000000000000000000000000000000000000000000000000000000000000001",
@@ -115,6 +121,218 @@ func (s *EventsTestSuite) TestSubscribeOK() {
 		}}, evs)
 }
 
+func (s *EventsTestSuite) TestAckEventOK() {
+	sub := &ethtest.MockSubscription{ErrC: make(chan error, 1)}
+
+	ethtest.ImplementMockWithOverwrite(s.ethclient,
+		ethtest.MockMethods{
+			"SubscribeFilterLogs": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					c := args.Get(2).(chan<- types.Log)
+					for i := 0; i < 2; i++ {
+						c <- types.Log{Address: common.HexToAddress("0x0000000000000000000000000000000000000001")}
+					}
+				},
+			},
+		})
+
+	res, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events: []string{"logs"},
+		Filter: "address=0x0000000000000000000000000000000000000001",
+	})
+	assert.Nil(s.T(), err)
+
+	_, err = s.eventclient.PollEventUntilNotEmpty(context.TODO(), event.PollEventRequest{
+		ID:     res.ID,
+		Offset: apitest.Offset(1),
+	})
+	assert.Nil(s.T(), err)
+
+	err = s.eventclient.AckEvent(context.TODO(), event.AckEventRequest{ID: res.ID, EventID: 0})
+	assert.Nil(s.T(), err)
+
+	// polling without an offset continues after the acknowledged event
+	evs, err := s.eventclient.PollEvent(context.TODO(), event.PollEventRequest{ID: res.ID})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(1), evs.Offset)
+	assert.Equal(s.T(), 1, len(evs.Events))
+	assert.Equal(s.T(), uint64(1), evs.Events[0].EventID())
+}
+
+func (s *EventsTestSuite) TestAckEventErrSubscriptionNotFound() {
+	err := s.eventclient.AckEvent(context.TODO(), event.AckEventRequest{ID: 10, EventID: 0})
+
+	assert.Equal(s.T(), &rpc.Error{
+		ErrorCode:   6002,
+		Description: "Subscription not found.",
+	}, err)
+}
+
+func (s *EventsTestSuite) TestSubscribeDecodedLogOK() {
+	sub := &ethtest.MockSubscription{ErrC: make(chan error, 1)}
+	address := "0x0000000000000000000000000000000000000001"
+
+	ethtest.ImplementMockWithOverwrite(s.ethclient,
+		ethtest.MockMethods{
+			"SubscribeFilterLogs": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					c := args.Get(2).(chan<- types.Log)
+					c <- types.Log{
+						Address:     common.HexToAddress(address),
+						BlockNumber: 1,
+						Topics: []common.Hash{
+							crypto.Keccak256Hash([]byte("Ping(uint256)")),
+							common.HexToHash("0x05"),
+						},
+					}
+				},
+			},
+		})
+
+	err := s.eventclient.RegisterABI(context.TODO(), event.RegisterABIRequest{
+		Address: address,
+		ABI: []byte(`[{"type": "event", "name": "Ping", "inputs": [
+			{"name": "n", "type": "uint256", "indexed": true}
+		]}]`),
+	})
+	assert.Nil(s.T(), err)
+
+	_, err = s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events: []string{"logs"},
+		Filter: "address=" + address,
+	})
+	assert.Nil(s.T(), err)
+
+	evs, err := s.eventclient.PollEventUntilNotEmpty(context.TODO(), event.PollEventRequest{
+		ID:     0,
+		Offset: apitest.Offset(0),
+		Count:  1,
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), event.PollEventResponse{
+		Offset: 0x0,
+		Events: []event.Event{
+			event.DataEvent{
+				ID:      0x0,
+				Address: address,
+				Data:    "0x",
+				Topics: []string{
+					crypto.Keccak256Hash([]byte("Ping(uint256)")).Hex(),
+					common.HexToHash("0x05").Hex(),
+				},
+				Event: "Ping",
+				Args:  map[string]interface{}{"n": "5"},
+			},
+		}}, evs)
+}
+
+func (s *EventsTestSuite) TestRegisterABIErrInvalidABI() {
+	err := s.eventclient.RegisterABI(context.TODO(), event.RegisterABIRequest{
+		Address: "0x0000000000000000000000000000000000000001",
+		ABI:     []byte(`{}`),
+	})
+
+	assert.Equal(s.T(),
+		&rpc.Error{
+			ErrorCode:   2017,
+			Description: "Provided invalid contract ABI.",
+		}, err)
+}
+
+func (s *EventsTestSuite) TestSubscribeCallbackURLOK() {
+	sub := &ethtest.MockSubscription{ErrC: make(chan error, 1)}
+	received := make(chan event.DataEvent, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var ev event.DataEvent
+		if err := json.NewDecoder(req.Body).Decode(&ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received <- ev
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ethtest.ImplementMockWithOverwrite(s.ethclient,
+		ethtest.MockMethods{
+			"SubscribeFilterLogs": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+				Run: func(args mock.Arguments) {
+					c := args.Get(2).(chan<- types.Log)
+					c <- types.Log{
+						Address: common.HexToAddress("0x0000000000000000000000000000000000000001"),
+						Data:    []byte{1},
+					}
+				},
+			},
+		})
+
+	_, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events:      []string{"logs"},
+		Filter:      "address=0x0000000000000000000000000000000000000001",
+		CallbackURL: server.URL,
+	})
+	assert.Nil(s.T(), err)
+
+	select {
+	case ev := <-received:
+		assert.Equal(s.T(), event.DataEvent{
+			ID:      0,
+			Address: "0x0000000000000000000000000000000000000001",
+			Data:    "0x01",
+		}, ev)
+	case <-time.After(5 * time.Second):
+		assert.Fail(s.T(), "event not delivered to callback url")
+	}
+}
+
+func (s *EventsTestSuite) TestSubscribeErrInvalidCallbackURL() {
+	_, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events:      []string{"logs"},
+		Filter:      "address=0x0000000000000000000000000000000000000001",
+		CallbackURL: "localhost",
+	})
+
+	assert.Equal(s.T(),
+		&rpc.Error{
+			ErrorCode:   2018,
+			Description: "Provided invalid callback URL.",
+		}, err)
+}
+
+func (s *EventsTestSuite) TestSubscribeNewHeadsOK() {
+	ethtest.ImplementMock(s.ethclient)
+
+	res, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events: []string{"newHeads"},
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), event.SubscribeResponse{
+		ID: 0,
+	}, res)
+
+	s.ethclient.AssertCalled(s.T(), "SubscribeNewHead", mock.Anything, mock.Anything)
+}
+
+func (s *EventsTestSuite) TestSubscribeReceiptsErrNoAddress() {
+	_, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events: []string{"receipts"},
+	})
+
+	assert.Equal(s.T(),
+		&rpc.Error{
+			ErrorCode:   2006,
+			Description: "Provided invalid address.",
+		}, err)
+}
+
 func (s *EventsTestSuite) TestUnsubscribeErrNoExists() {
 	ethtest.ImplementMock(s.ethclient)
 
@@ -148,7 +366,7 @@ func (s *EventsTestSuite) TestUnsubscribeOK() {
 
 	res, err := s.eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
 		Events: []string{"logs"},
-		Filter: "address=address",
+		Filter: "address=0x0000000000000000000000000000000000000001",
 	})
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), event.SubscribeResponse{
@@ -159,10 +377,24 @@ func (s *EventsTestSuite) TestUnsubscribeOK() {
 	assert.Equal(s.T(), uint64(1), subStats["currentSubscriptions"])
 	assert.Equal(s.T(), uint64(1), subStats["totalSubscriptionCount"])
 
+	list, err := s.eventclient.ListSubscriptions(context.TODO())
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(list.Subscriptions))
+	assert.Equal(s.T(), uint64(0), list.Subscriptions[0].ID)
+	assert.Equal(s.T(), "logs", list.Subscriptions[0].Event)
+	assert.Equal(s.T(), []string{"0x0000000000000000000000000000000000000001"}, list.Subscriptions[0].Addresses)
+	assert.True(s.T(), list.Subscriptions[0].CreatedAtMs > 0)
+
 	err = s.eventclient.Unsubscribe(context.TODO(), event.UnsubscribeRequest{
 		ID: 0,
 	})
 	assert.Nil(s.T(), err)
+
+	list, err = s.eventclient.ListSubscriptions(context.TODO())
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), event.ListSubscriptionsResponse{
+		Subscriptions: []event.Subscription{},
+	}, list)
 	subStats = s.request.Stats()["subscriptions"].(stats.Metrics)
 	assert.Equal(s.T(), uint64(0), subStats["subscriptionCount"])
 	assert.Equal(s.T(), uint64(0), subStats["currentSubscriptions"])
@@ -170,7 +402,7 @@ func (s *EventsTestSuite) TestUnsubscribeOK() {
 
 	_, err = s.eventclient.PollEventUntilNotEmpty(context.TODO(), event.PollEventRequest{
 		ID:     0,
-		Offset: 0,
+		Offset: apitest.Offset(0),
 		Count:  1,
 	})
 
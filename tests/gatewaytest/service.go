@@ -13,6 +13,7 @@ import (
 	"github.com/oasislabs/oasis-gateway/backend"
 	backendcore "github.com/oasislabs/oasis-gateway/backend/core"
 	"github.com/oasislabs/oasis-gateway/backend/eth"
+	callbackconfig "github.com/oasislabs/oasis-gateway/callback"
 	"github.com/oasislabs/oasis-gateway/callback/callbacktest"
 	"github.com/oasislabs/oasis-gateway/eth/ethtest"
 	"github.com/oasislabs/oasis-gateway/gateway"
@@ -23,13 +24,19 @@ import (
 )
 
 func NewPublicRouter(config *gateway.Config, provider *Provider) *rpc.HttpRouter {
+	return gateway.NewPublicRouter(config, NewServiceGroup(provider))
+}
+
+// NewServiceGroup creates the group of services used by the public
+// APIs from the services in the provider
+func NewServiceGroup(provider *Provider) *gateway.ServiceGroup {
 	request := provider.MustGet(reflect.TypeOf(&backendcore.RequestManager{})).(*backendcore.RequestManager)
 	authenticator := provider.MustGet(reflect.TypeOf((*authcore.Auth)(nil)).Elem()).(authcore.Auth)
 
-	return gateway.NewPublicRouter(config, &gateway.ServiceGroup{
+	return &gateway.ServiceGroup{
 		Request:       request,
 		Authenticator: authenticator,
-	})
+	}
 }
 
 func NewServices(ctx context.Context, config *gateway.Config) (*Provider, error) {
@@ -88,6 +95,11 @@ func NewServices(ctx context.Context, config *gateway.Config) (*Provider, error)
 		Logger: gateway.RootLogger,
 		MQueue: mqueue,
 		Client: backendclient,
+		// the test servers that receive the webhooks listen on loopback
+		Webhooks: gateway.NewWebhookClient(&callbackconfig.Webhook{
+			AllowedHosts: []string{"127.0.0.1", "localhost"},
+			TimeoutMs:    10000,
+		}),
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	apigrpc "github.com/oasislabs/oasis-gateway/api/grpc"
+	auth "github.com/oasislabs/oasis-gateway/auth/core"
+	"github.com/oasislabs/oasis-gateway/auth/insecure"
+	"github.com/oasislabs/oasis-gateway/errors"
+	"github.com/oasislabs/oasis-gateway/gateway"
+	"github.com/oasislabs/oasis-gateway/ratelimit"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/oasislabs/oasis-gateway/tests/apitest"
+	"github.com/oasislabs/oasis-gateway/tests/gatewaytest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRouteLimitAcrossTransports(t *testing.T) {
+	provider, err := gatewaytest.NewServices(context.TODO(), Config)
+	assert.Nil(t, err)
+
+	config := *Config
+	config.RateLimitConfig = ratelimit.Config{
+		Enabled:  true,
+		Provider: ratelimit.ProviderMem,
+		Key:      ratelimit.KeyAAD,
+		Limit:    ratelimit.Limit{Rate: 1000, Burst: 1000},
+		Routes: map[string]ratelimit.Limit{
+			"/service/poll": {Rate: 0.001, Burst: 4},
+		},
+	}
+
+	group := gatewaytest.NewServiceGroup(provider)
+	group.RateLimiter = ratelimit.NewMemLimiter()
+	client := apitest.NewClient(gateway.NewPublicRouter(&config, group))
+
+	server := gateway.NewPublicGrpcServer(&config, group)
+	lis := bufconn.Listen(1 << 16)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) { return lis.Dial() }))
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	headers := map[string]string{
+		insecure.HeaderKey:           "mykey",
+		auth.RequestHeaderSessionKey: "mysession",
+		"Content-type":               "application/json",
+	}
+
+	pollHTTP := func(path string) apitest.Response {
+		res, err := client.Request(apitest.Request{
+			Route:   apitest.Route{Method: "POST", Path: path},
+			Body:    []byte(`{"offset":0}`),
+			Headers: headers,
+		})
+		assert.Nil(t, err)
+		return res
+	}
+
+	pollJsonRpc := func() *rpc.JsonRpcError {
+		res, err := client.Request(apitest.Request{
+			Route:   apitest.Route{Method: "POST", Path: "/rpc"},
+			Body:    []byte(`{"jsonrpc":"2.0","method":"service_poll","params":{"offset":0},"id":1}`),
+			Headers: headers,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response rpc.JsonRpcResponse
+		assert.Nil(t, json.Unmarshal(res.Body, &response))
+		return response.Error
+	}
+
+	pollGrpc := func() error {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(
+			insecure.HeaderKey, "mykey", auth.RequestHeaderSessionKey, "mysession"))
+		_, err := apigrpc.NewServiceClient(conn).PollService(ctx, &apigrpc.PollServiceRequest{})
+		return err
+	}
+
+	// each way of reaching the route takes a token from the same bucket
+	assert.Equal(t, http.StatusOK, pollHTTP("/v0/api/service/poll").Code)
+	assert.Equal(t, http.StatusOK, pollHTTP("/v1/api/service/poll").Code)
+	assert.Nil(t, pollJsonRpc())
+	assert.Nil(t, pollGrpc())
+
+	assert.Equal(t, http.StatusTooManyRequests, pollHTTP("/v0/api/service/poll").Code)
+	assert.Equal(t, http.StatusTooManyRequests, pollHTTP("/v1/api/service/poll").Code)
+	assert.Equal(t, errors.ErrRateLimitExceeded.Code(), pollJsonRpc().Code)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(pollGrpc()))
+
+	// the routes without a limit of their own are not affected
+	assert.Equal(t, http.StatusOK, pollHTTP("/v0/api/event/poll").Code)
+}
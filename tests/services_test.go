@@ -75,10 +75,10 @@ func (s *ServicesTestSuite) TestDeployServiceOK() {
 	})
 
 	assert.Nil(s.T(), err)
-	assert.Equal(s.T(), service.DeployServiceEvent{
-		ID:      0,
-		Address: "0x0000000000000000000000000000000000000000",
-	}, ev)
+	event := ev.(service.DeployServiceEvent)
+	assert.Equal(s.T(), uint64(0), event.ID)
+	assert.Equal(s.T(), "0x0000000000000000000000000000000000000000", event.Address)
+	s.assertMockTransaction(event.Transaction)
 }
 
 func (s *ServicesTestSuite) TestExecuteServiceEmptyAddress() {
@@ -113,11 +113,35 @@ func (s *ServicesTestSuite) TestExecuteServiceOK() {
 		Data:    "0x0000000000000000000000000000000000000000",
 	})
 	assert.Nil(s.T(), err)
-	assert.Equal(s.T(), service.ExecuteServiceEvent{
-		ID:      0,
-		Address: "0x0000000000000000000000000000000000000000",
-		Output:  "0x73756363657373",
-	}, ev)
+	event := ev.(service.ExecuteServiceEvent)
+	assert.Equal(s.T(), uint64(0), event.ID)
+	assert.Equal(s.T(), "0x0000000000000000000000000000000000000000", event.Address)
+	assert.Equal(s.T(), "0x73756363657373", event.Output)
+	s.assertMockTransaction(event.Transaction)
+}
+
+// assertMockTransaction verifies that the transaction metadata matches
+// the receipt returned by the default ethtest mock. The wallet that pays
+// for the transaction depends on the configuration so only its presence
+// is checked
+func (s *ServicesTestSuite) assertMockTransaction(tx *service.Transaction) {
+	if !assert.NotNil(s.T(), tx) {
+		return
+	}
+
+	assert.NotEmpty(s.T(), tx.From)
+	assert.Equal(s.T(), "0x00000000000000000000000000000000000000000000000000000000000000000", tx.Hash)
+	assert.Equal(s.T(), uint64(5), tx.BlockNumber)
+	assert.Equal(s.T(), "0x0000000000000000000000000000000000000000000000000000000000000004", tx.BlockHash)
+	assert.Equal(s.T(), uint64(21000), tx.GasUsed)
+	assert.Equal(s.T(), []service.Log{
+		{
+			Address: "0x0000000000000000000000000000000000000000",
+			Topics:  []string{"0x0000000000000000000000000000000000000000000000000000000000000002"},
+			Data:    "0x03",
+			Index:   0,
+		},
+	}, tx.Logs)
 }
 
 func (s *ServicesTestSuite) TestExecuteServiceErrStatus0() {
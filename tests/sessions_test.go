@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oasislabs/oasis-gateway/api/v0/event"
+	"github.com/oasislabs/oasis-gateway/api/v0/session"
+	"github.com/oasislabs/oasis-gateway/eth"
+	"github.com/oasislabs/oasis-gateway/eth/ethtest"
+	"github.com/oasislabs/oasis-gateway/rpc"
+	"github.com/oasislabs/oasis-gateway/tests/apitest"
+	"github.com/oasislabs/oasis-gateway/tests/gatewaytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionsTestSuite struct {
+	suite.Suite
+	ethclient     *ethtest.MockClient
+	router        *rpc.HttpRouter
+	sessionclient *apitest.SessionClient
+}
+
+func (s *SessionsTestSuite) SetupTest() {
+	provider, err := gatewaytest.NewServices(context.TODO(), Config)
+	if err != nil {
+		panic(err)
+	}
+
+	s.ethclient = provider.MustGet(reflect.TypeOf((*eth.Client)(nil)).Elem()).(*ethtest.MockClient)
+	s.router = gatewaytest.NewPublicRouter(Config, provider)
+	s.sessionclient = apitest.NewSessionClient(s.router)
+}
+
+func (s *SessionsTestSuite) TestOpenSessionOK() {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+
+	res, err := s.sessionclient.Open(context.TODO(), session.OpenSessionRequest{TTLMs: 60000})
+	assert.Nil(s.T(), err)
+	assert.NotEmpty(s.T(), res.SessionKey)
+	assert.True(s.T(), res.ExpiresAtMs >= now+60000)
+
+	refreshed, err := s.sessionclient.Refresh(context.TODO(), res.SessionKey, session.RefreshSessionRequest{})
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), refreshed.ExpiresAtMs > res.ExpiresAtMs)
+}
+
+func (s *SessionsTestSuite) TestOpenSessionErrInvalidTTL() {
+	_, err := s.sessionclient.Open(context.TODO(), session.OpenSessionRequest{TTLMs: 1})
+
+	assert.Equal(s.T(), &rpc.Error{
+		ErrorCode:   2019,
+		Description: "Provided invalid session TTL.",
+	}, err)
+}
+
+func (s *SessionsTestSuite) TestRefreshSessionErrNotFound() {
+	_, err := s.sessionclient.Refresh(context.TODO(), "mysession", session.RefreshSessionRequest{})
+
+	assert.Equal(s.T(), &rpc.Error{
+		ErrorCode:   6005,
+		Description: "Session not found or already closed.",
+	}, err)
+}
+
+func (s *SessionsTestSuite) TestCloseSessionDestroysSubscriptions() {
+	sub := &ethtest.MockSubscription{ErrC: make(chan error, 1)}
+	ethtest.ImplementMockWithOverwrite(s.ethclient,
+		ethtest.MockMethods{
+			"SubscribeFilterLogs": ethtest.MockMethod{
+				Arguments: []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Return:    []interface{}{sub, nil},
+			},
+		})
+
+	res, err := s.sessionclient.Open(context.TODO(), session.OpenSessionRequest{})
+	assert.Nil(s.T(), err)
+
+	eventclient := apitest.NewEventClientWithSession(s.router, res.SessionKey)
+	_, err = eventclient.Subscribe(context.TODO(), event.SubscribeRequest{
+		Events: []string{"logs"},
+		Filter: "address=0x0000000000000000000000000000000000000001",
+	})
+	assert.Nil(s.T(), err)
+
+	subs, err := eventclient.ListSubscriptions(context.TODO())
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(subs.Subscriptions))
+
+	assert.Nil(s.T(), s.sessionclient.Close(context.TODO(), res.SessionKey))
+
+	subs, err = eventclient.ListSubscriptions(context.TODO())
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, len(subs.Subscriptions))
+
+	_, err = s.sessionclient.Refresh(context.TODO(), res.SessionKey, session.RefreshSessionRequest{})
+	assert.Equal(s.T(), 6005, err.(*rpc.Error).ErrorCode)
+}
+
+func TestSessionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionsTestSuite))
+}
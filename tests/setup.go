@@ -56,6 +56,9 @@ func Initialize() error {
 	gateway.RootLogger.Info(gateway.RootContext, "callback config configuration parsed", log.MapFields{
 		"callType": "CallbackConfigParseSuccess",
 	}, &Config.CallbackConfig)
+	gateway.RootLogger.Info(gateway.RootContext, "rate limit configuration parsed", log.MapFields{
+		"callType": "RateLimitConfigParseSuccess",
+	}, &Config.RateLimitConfig)
 
 	return nil
 }
@@ -1,5 +1,7 @@
 package tx
 
+import "github.com/ethereum/go-ethereum/core/types"
+
 // ExecuteRequest is the request to execute an Ethereum transaction
 type ExecuteRequest struct {
 	// AAD is the identifier of the original issuer for the transaction data
@@ -15,8 +17,47 @@ type ExecuteRequest struct {
 	Data []byte
 }
 
+// EstimateGasRequest is the request to estimate the gas
+// of an Ethereum transaction
+type EstimateGasRequest struct {
+	// Address to which the transaction is sent, or empty for
+	// a deployment
+	Address string
+
+	// Transaction data
+	Data []byte
+}
+
+// EstimateGasResponse is the response to an EstimateGasRequest
+type EstimateGasResponse struct {
+	// Gas is the estimated gas of the transaction
+	Gas uint64
+
+	// Fallback is true if the gas of an execution of a confidential
+	// service could not be estimated, in which case Gas is the gas
+	// provided to the transactions sent to confidential services
+	Fallback bool
+}
+
 type ExecuteResponse struct {
 	Address string
 	Output  string
 	Hash    string
+
+	// BlockNumber is the number of the block in which the
+	// transaction was included
+	BlockNumber uint64
+
+	// BlockHash is the hash of the block in which the
+	// transaction was included
+	BlockHash string
+
+	// GasUsed is the amount of gas used by the transaction
+	GasUsed uint64
+
+	// From is the address of the wallet that paid for the transaction
+	From string
+
+	// Logs are the logs generated by the transaction
+	Logs []*types.Log
 }
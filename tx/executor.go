@@ -3,8 +3,10 @@ package tx
 import (
 	"context"
 	"crypto/ecdsa"
+	stderr "errors"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/oasislabs/oasis-gateway/concurrent"
@@ -31,6 +33,10 @@ type Executor struct {
 	client    eth.Client
 	logger    log.Logger
 	callbacks Callbacks
+
+	// wallets are the addresses of the wallets that
+	// send the transactions
+	wallets []common.Address
 }
 
 func NewExecutor(ctx context.Context, services *ExecutorServices, props *ExecutorProps) (*Executor, error) {
@@ -51,14 +57,16 @@ func NewExecutor(ctx context.Context, services *ExecutorServices, props *Executo
 
 	// Create a worker for each provided private key
 	for _, pk := range props.PrivateKeys {
-		address := crypto.PubkeyToAddress(pk.PublicKey).Hex()
+		address := crypto.PubkeyToAddress(pk.PublicKey)
 		req := createOwnerRequest{PrivateKey: pk}
-		if err := s.master.Create(ctx, address, &req); err != nil {
+		if err := s.master.Create(ctx, address.Hex(), &req); err != nil {
 			if err := s.master.Stop(); err != nil {
 				return nil, err
 			}
 			return nil, err
 		}
+
+		s.wallets = append(s.wallets, address)
 	}
 
 	return s, nil
@@ -149,3 +157,37 @@ func (s *Executor) Execute(ctx context.Context, req ExecuteRequest) (ExecuteResp
 
 	return res.(ExecuteResponse), nil
 }
+
+// Wallet returns the address of the wallet used as the sender of the
+// requests that are not sent as transactions, so that they execute as
+// the transactions sent by the executor would. It returns false if the
+// executor does not have any wallets
+func (s *Executor) Wallet() (common.Address, bool) {
+	if len(s.wallets) == 0 {
+		return common.Address{}, false
+	}
+
+	return s.wallets[0], true
+}
+
+// EstimateGas estimates the gas of a transaction as if it was sent from
+// one of the wallets of the executor. If the execution of a confidential
+// service cannot be estimated, the gas provided to the transactions sent
+// to confidential services is returned instead
+func (s *Executor) EstimateGas(ctx context.Context, req EstimateGasRequest) (EstimateGasResponse, errors.Err) {
+	wallet, ok := s.Wallet()
+	if !ok {
+		return EstimateGasResponse{}, errors.New(errors.ErrEstimateGas, stderr.New("no wallet available to estimate gas"))
+	}
+
+	if len(req.Address) == 0 {
+		gas, err := estimateGasNonConfidential(ctx, s.client, s.logger, wallet, 0, req.Address, req.Data)
+		if err != nil {
+			return EstimateGasResponse{}, err
+		}
+
+		return EstimateGasResponse{Gas: gas}, nil
+	}
+
+	return estimateExecutionGas(ctx, s.client, s.logger, wallet, req.Address, req.Data)
+}
@@ -41,7 +41,18 @@ type Callbacks interface {
 // for a transaction that succeeds
 const StatusOK = 1
 
-const gasPrice int64 = 1000000000
+// GasPrice is the gas price used for the transactions sent
+// by a WalletOwner
+const GasPrice int64 = 1000000000
+
+// EstimateGasFailure is the gas returned by the gas estimation when it
+// fails because the transaction execution fails. It far exceeds the
+// limit of gas in a block
+const EstimateGasFailure uint64 = 2251799813685248
+
+// ConfidentialGas is the gas used for the transactions to services, for
+// which the gas cannot be estimated in case the service is confidential
+const ConfidentialGas uint64 = 15177522
 
 var retryConfig = concurrent.RetryConfig{
 	Random:            false,
@@ -217,18 +228,79 @@ func (e *WalletOwner) signTransaction(tx *types.Transaction) (*types.Transaction
 }
 
 func (e *WalletOwner) estimateGas(ctx context.Context, id uint64, address string, data []byte) (uint64, errors.Err) {
+	return estimateGas(ctx, e.client, e.logger, e.wallet.Address(), id, address, data)
+}
+
+// estimateGas returns the gas provided to a transaction sent from the
+// wallet with the provided address
+func estimateGas(
+	ctx context.Context,
+	client eth.Client,
+	logger log.Logger,
+	from common.Address,
+	id uint64,
+	address string,
+	data []byte,
+) (uint64, errors.Err) {
 	if len(address) == 0 {
-		return e.estimateGasNonConfidential(ctx, id, address, data)
+		return estimateGasNonConfidential(ctx, client, logger, from, id, address, data)
 	}
 
 	// TODO(stan): parse the data to identify whether the contract is confidential.
 	// estimateGas does not work for confidential contracts so in that case we provide a reasonable
 	// amount of gas that may work
-	return 15177522, nil
+	return ConfidentialGas, nil
 }
 
-func (e *WalletOwner) estimateGasNonConfidential(ctx context.Context, id uint64, address string, data []byte) (uint64, errors.Err) {
-	e.logger.Debug(ctx, "", log.MapFields{
+// estimateExecutionGas estimates the gas of the execution of the service at
+// address sent from the wallet with the provided address. The execution of
+// a confidential service cannot always be estimated, so if the estimation
+// fails for a confidential service the gas provided to the transactions sent
+// to confidential services is returned instead
+func estimateExecutionGas(
+	ctx context.Context,
+	client eth.Client,
+	logger log.Logger,
+	from common.Address,
+	address string,
+	data []byte,
+) (EstimateGasResponse, errors.Err) {
+	gas, err := estimateGasNonConfidential(ctx, client, logger, from, 0, address, data)
+	if err == nil {
+		return EstimateGasResponse{Gas: gas}, nil
+	}
+
+	if !isConfidential(ctx, client, address) {
+		return EstimateGasResponse{}, err
+	}
+
+	logger.Debug(ctx, "", log.MapFields{
+		"call_type": "EstimateGasConfidentialFallback",
+		"address":   address,
+		"gas":       ConfidentialGas,
+		"err":       err.Error(),
+	})
+
+	return EstimateGasResponse{Gas: ConfidentialGas, Fallback: true}, nil
+}
+
+// isConfidential returns true if the service at address is confidential,
+// which is the case if the key manager has a public key for it
+func isConfidential(ctx context.Context, client eth.Client, address string) bool {
+	pk, err := client.GetPublicKey(ctx, common.HexToAddress(address))
+	return err == nil && len(pk.PublicKey) > 0
+}
+
+func estimateGasNonConfidential(
+	ctx context.Context,
+	client eth.Client,
+	logger log.Logger,
+	from common.Address,
+	id uint64,
+	address string,
+	data []byte,
+) (uint64, errors.Err) {
+	logger.Debug(ctx, "", log.MapFields{
 		"call_type": "EstimateGasAttempt",
 		"id":        id,
 		"address":   address,
@@ -241,8 +313,8 @@ func (e *WalletOwner) estimateGasNonConfidential(ctx context.Context, id uint64,
 		to = &hex
 	}
 
-	gas, err := e.client.EstimateGas(ctx, ethereum.CallMsg{
-		From:     e.wallet.Address(),
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From:     from,
 		To:       to,
 		Gas:      0,
 		GasPrice: nil,
@@ -251,30 +323,34 @@ func (e *WalletOwner) estimateGasNonConfidential(ctx context.Context, id uint64,
 	})
 
 	if err != nil {
-		e.logger.Debug(ctx, "", log.MapFields{
+		logger.Debug(ctx, "", log.MapFields{
 			"call_type": "EstimateGasFailure",
 			"id":        id,
 			"address":   address,
 			"err":       err.Error(),
 		})
+		if err == eth.ErrExecutionReverted {
+			return 0, errors.New(errors.ErrEstimateGasExecution, err)
+		}
+
 		return 0, errors.New(errors.ErrEstimateGas, err)
 	}
 
 	// when the gateway fails to estimate the gas of a transaction
 	// returns this number which far exceeds the limit of gas in
 	// a block. In this case, we should just return an error
-	if gas == 2251799813685248 {
+	if gas == EstimateGasFailure {
 		err := stderr.New("gas estimation could not be completed because of execution failure")
-		e.logger.Debug(ctx, "", log.MapFields{
+		logger.Debug(ctx, "", log.MapFields{
 			"call_type": "EstimateGasFailure",
 			"id":        id,
 			"address":   address,
 			"err":       err.Error(),
 		})
-		return 0, errors.New(errors.ErrEstimateGas, err)
+		return 0, errors.New(errors.ErrEstimateGasExecution, err)
 	}
 
-	e.logger.Debug(ctx, "", log.MapFields{
+	logger.Debug(ctx, "", log.MapFields{
 		"call_type": "EstimateGasSuccess",
 		"id":        id,
 		"address":   address,
@@ -290,10 +366,10 @@ func (e *WalletOwner) generateAndSignTransaction(ctx context.Context, req sendTr
 	var tx *types.Transaction
 	if len(req.Address) == 0 {
 		tx = types.NewContractCreation(nonce,
-			big.NewInt(0), gas, big.NewInt(gasPrice), req.Data)
+			big.NewInt(0), gas, big.NewInt(GasPrice), req.Data)
 	} else {
 		tx = types.NewTransaction(nonce, common.HexToAddress(req.Address),
-			big.NewInt(0), gas, big.NewInt(gasPrice), req.Data)
+			big.NewInt(0), gas, big.NewInt(GasPrice), req.Data)
 	}
 
 	return e.wallet.SignTransaction(tx)
@@ -372,6 +448,10 @@ func (e *WalletOwner) executeTransaction(ctx context.Context, req ExecuteRequest
 	contractAddress := req.Address
 	gas, err := e.estimateGas(ctx, req.ID, req.Address, req.Data)
 	if err != nil {
+		if ctx.Err() != nil {
+			return ExecuteResponse{}, errors.New(errors.ErrRequestCancelled, ctx.Err())
+		}
+
 		e.logger.Debug(ctx, "failed to estimate gas", log.MapFields{
 			"call_type": "ExecuteTransactionFailure",
 			"id":        req.ID,
@@ -381,6 +461,14 @@ func (e *WalletOwner) executeTransaction(ctx context.Context, req ExecuteRequest
 		return ExecuteResponse{}, err
 	}
 
+	// the request can only be cancelled before the transaction is sent.
+	// After that point the request needs to complete regardless so that
+	// the outcome of the transaction is reported to the caller
+	if ctx.Err() != nil {
+		return ExecuteResponse{}, errors.New(errors.ErrRequestCancelled, ctx.Err())
+	}
+	ctx = detachedContext{parent: ctx}
+
 	res, err := e.sendTransaction(ctx, sendTransactionRequest{
 		AAD:     req.AAD,
 		ID:      req.ID,
@@ -465,9 +553,14 @@ func (e *WalletOwner) executeTransaction(ctx context.Context, req ExecuteRequest
 	e.consumedBalance = e.consumedBalance.Add(e.consumedBalance, &gasUsed)
 
 	return ExecuteResponse{
-		Address: contractAddress,
-		Output:  res.Output,
-		Hash:    res.Hash,
+		Address:     contractAddress,
+		Output:      res.Output,
+		Hash:        res.Hash,
+		BlockNumber: receipt.BlockNumber,
+		BlockHash:   receipt.BlockHash.Hex(),
+		GasUsed:     receipt.GasUsed,
+		From:        e.wallet.Address().Hex(),
+		Logs:        receipt.Logs,
 	}, nil
 }
 
@@ -480,7 +573,7 @@ func (e *WalletOwner) getCode(ctx context.Context, addr common.Address) (string,
 	return code, nil
 }
 
-func (e *WalletOwner) transactionReceipt(ctx context.Context, hash string) (*types.Receipt, errors.Err) {
+func (e *WalletOwner) transactionReceipt(ctx context.Context, hash string) (*eth.Receipt, errors.Err) {
 	receipt, err := e.client.TransactionReceipt(ctx, common.HexToHash(hash))
 	if err != nil {
 		return nil, errors.New(errors.ErrTransactionReceipt, err)
@@ -488,3 +581,29 @@ func (e *WalletOwner) transactionReceipt(ctx context.Context, hash string) (*typ
 
 	return receipt, nil
 }
+
+// detachedContext keeps the values of its parent context but it is
+// not cancelled when its parent is
+type detachedContext struct {
+	parent context.Context
+}
+
+// Deadline is the implementation of context.Context for detachedContext
+func (c detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done is the implementation of context.Context for detachedContext
+func (c detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err is the implementation of context.Context for detachedContext
+func (c detachedContext) Err() error {
+	return nil
+}
+
+// Value is the implementation of context.Context for detachedContext
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/oasislabs/oasis-gateway/callback/callbacktest"
 	callback "github.com/oasislabs/oasis-gateway/callback/client"
+	"github.com/oasislabs/oasis-gateway/errors"
 	"github.com/oasislabs/oasis-gateway/eth"
 	"github.com/oasislabs/oasis-gateway/eth/ethtest"
 	"github.com/stretchr/testify/assert"
@@ -40,8 +41,10 @@ func mockClientForNonce(client *ethtest.MockClient) {
 	client.On("TransactionReceipt",
 		mock.AnythingOfType("*context.emptyCtx"),
 		mock.AnythingOfType("common.Hash")).
-		Return(&types.Receipt{
-			ContractAddress: common.HexToAddress(strings.Repeat("0", 20)),
+		Return(&eth.Receipt{
+			Receipt: types.Receipt{
+				ContractAddress: common.HexToAddress(strings.Repeat("0", 20)),
+			},
 		}, nil)
 	client.On("SendTransaction",
 		mock.AnythingOfType("*context.emptyCtx"),
@@ -78,8 +81,10 @@ func mockClientForWalletOutOfFundsBodyCallback(client *ethtest.MockClient) {
 	client.On("TransactionReceipt",
 		mock.AnythingOfType("*context.emptyCtx"),
 		mock.AnythingOfType("common.Hash")).
-		Return(&types.Receipt{
-			ContractAddress: common.HexToAddress(strings.Repeat("0", 20)),
+		Return(&eth.Receipt{
+			Receipt: types.Receipt{
+				ContractAddress: common.HexToAddress(strings.Repeat("0", 20)),
+			},
 		}, nil)
 	client.On("SendTransaction",
 		mock.AnythingOfType("*context.emptyCtx"),
@@ -199,6 +204,26 @@ func TestExecuteTransactionExceedsBalance(t *testing.T) {
 		}))
 }
 
+func TestExecuteTransactionCancelled(t *testing.T) {
+	mockclient := &ethtest.MockClient{}
+	ethtest.ImplementMock(mockclient)
+	owner, err := newOwner(mockclient)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	_, err = owner.executeTransaction(ctx, ExecuteRequest{
+		ID:      0,
+		Address: strings.Repeat("0", 20),
+		Data:    []byte(""),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, errors.ErrRequestCancelled, err.(errors.Err).ErrorCode())
+	mockclient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
 func TestOwnerWalletReachedFundsThresholdOnNewOK(t *testing.T) {
 	mockclient := &ethtest.MockClient{}
 	ethtest.ImplementMock(mockclient)